@@ -0,0 +1,324 @@
+package arpeggio
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Pattern represents the order of notes played by Arpeggiator.
+type Pattern uint8
+
+const (
+	// Up plays the held notes from the lowest to the highest.
+	Up Pattern = iota
+	// Down plays the held notes from the highest to the lowest.
+	Down
+	// UpDown plays the held notes up and down without repeating the highest and the lowest.
+	UpDown
+	// Random plays the held notes in random order without repeating the same note twice in a row.
+	Random
+	// AsPlayed plays the held notes in the order they were pressed.
+	AsPlayed
+)
+
+// Note represents a held note.
+type Note struct {
+	Channel  uint8
+	Note     constant.Note
+	Velocity uint8
+}
+
+// Arpeggiator plays the held notes one by one.
+//
+// For live input, call Press and Release for the note events and Next at each step. For MIDI data, Generate
+// arpeggiates the chords in a track at the rate.
+type Arpeggiator struct {
+	pattern Pattern
+	rate    NoteValue
+	gate    float64
+	octaves int
+	latch   bool
+	random  *rand.Rand
+
+	// pressed is the notes which are physically down, and held is the notes which are played.
+	pressed []Note
+	held    []Note
+	step    int
+	last    int
+}
+
+func remove(notes []Note, channel uint8, note constant.Note) []Note {
+	for i, n := range notes {
+		if n.Channel == channel && n.Note == note {
+			return append(notes[:i], notes[i+1:]...)
+		}
+	}
+
+	return notes
+}
+
+// Press holds the note. With latch, the first note pressed after all notes are released replaces the held notes.
+func (a *Arpeggiator) Press(channel uint8, note constant.Note, velocity uint8) {
+	if a.latch && len(a.pressed) == 0 {
+		a.held = nil
+	}
+	if len(a.held) == 0 {
+		a.step = 0
+		a.last = -1
+	}
+
+	n := Note{Channel: channel, Note: note, Velocity: velocity}
+
+	a.pressed = append(remove(a.pressed, channel, note), n)
+	a.held = append(remove(a.held, channel, note), n)
+}
+
+// Release releases the note. With latch, the note keeps being played until a new note is pressed.
+func (a *Arpeggiator) Release(channel uint8, note constant.Note) {
+	a.pressed = remove(a.pressed, channel, note)
+
+	if !a.latch {
+		a.held = remove(a.held, channel, note)
+	}
+}
+
+// Held returns the notes being played in the order they were pressed.
+func (a *Arpeggiator) Held() []Note {
+	return append([]Note{}, a.held...)
+}
+
+// Sequence returns the notes of one cycle of pattern. It's in ascending order for Random.
+func (a *Arpeggiator) Sequence() []Note {
+	notes := append([]Note{}, a.held...)
+
+	if a.pattern != AsPlayed {
+		sort.SliceStable(notes, func(i, j int) bool {
+			return notes[i].Note < notes[j].Note
+		})
+	}
+
+	var sequence []Note
+
+	for octave := 0; octave < a.octaves; octave++ {
+		for _, n := range notes {
+			if note := int(n.Note) + octave*12; note <= 0x7f {
+				n.Note = constant.Note(note)
+				sequence = append(sequence, n)
+			}
+		}
+	}
+
+	switch a.pattern {
+	case Down:
+		for i, j := 0, len(sequence)-1; i < j; i, j = i+1, j-1 {
+			sequence[i], sequence[j] = sequence[j], sequence[i]
+		}
+	case UpDown:
+		for i := len(sequence) - 2; i > 0; i-- {
+			sequence = append(sequence, sequence[i])
+		}
+	}
+
+	return sequence
+}
+
+// Next returns the note of the next step. It returns false if no note is held.
+func (a *Arpeggiator) Next() (Note, bool) {
+	sequence := a.Sequence()
+	if len(sequence) == 0 {
+		return Note{}, false
+	}
+	if a.pattern != Random {
+		n := sequence[a.step%len(sequence)]
+		a.step++
+		return n, true
+	}
+
+	i := a.random.Intn(len(sequence))
+	if len(sequence) > 1 && i == a.last {
+		i = (i + 1 + a.random.Intn(len(sequence)-1)) % len(sequence)
+	}
+
+	a.last = i
+
+	return sequence[i], true
+}
+
+// Reset releases all notes.
+func (a *Arpeggiator) Reset() {
+	a.pressed = nil
+	a.held = nil
+	a.step = 0
+	a.last = -1
+}
+
+// Generate returns the track in which the chords of the track are arpeggiated. The steps are placed on the grid of
+// rate from the beginning of track, and the arpeggio starts at the first step at or after the chord is pressed.
+// The events except note events are kept. The ticksPerQuarterNote is the time division of MIDI data.
+func (a *Arpeggiator) Generate(track *midi.Track, ticksPerQuarterNote uint32) (*midi.Track, error) {
+	stepTicks := a.rate.Ticks(ticksPerQuarterNote)
+	if stepTicks == 0 {
+		return nil, fmt.Errorf("midi: rate %v is shorter than a tick", a.rate)
+	}
+
+	gateTicks := uint32(float64(stepTicks) * a.gate)
+	if gateTicks == 0 {
+		gateTicks = 1
+	}
+
+	a.Reset()
+	defer a.Reset()
+
+	tes := []midi.TimedEvent{}
+	source := track.TimedEvents()
+
+	var end, next uint32
+	var running bool
+
+	if len(source) > 0 {
+		end = source[len(source)-1].Tick
+	}
+
+	play := func(until uint32) error {
+		for ; running && next < until; next += stepTicks {
+			n, ok := a.Next()
+			if !ok {
+				running = false
+				break
+			}
+
+			off := next + gateTicks
+			if off > end {
+				off = end
+			}
+
+			noteOn, err := event.NewNoteOnEvent(nil, n.Channel, n.Note, n.Velocity)
+			if err != nil {
+				return err
+			}
+			noteOff, err := event.NewNoteOffEvent(nil, n.Channel, n.Note, 0)
+			if err != nil {
+				return err
+			}
+
+			tes = append(tes, midi.TimedEvent{Tick: next, Event: noteOn}, midi.TimedEvent{Tick: off, Event: noteOff})
+		}
+
+		return nil
+	}
+
+	for _, te := range source {
+		if err := play(te.Tick); err != nil {
+			return nil, err
+		}
+
+		switch v := te.Event.(type) {
+		case *event.NoteOnEvent:
+			if v.Velocity() == 0 {
+				a.Release(v.Channel(), v.Note())
+				continue
+			}
+
+			a.Press(v.Channel(), v.Note(), v.Velocity())
+
+			if !running {
+				running = true
+				next = (te.Tick + stepTicks - 1) / stepTicks * stepTicks
+			}
+		case *event.NoteOffEvent:
+			a.Release(v.Channel(), v.Note())
+		case *event.NoteAfterTouchEvent, *event.EndOfTrackEvent:
+		default:
+			tes = append(tes, midi.TimedEvent{Tick: te.Tick, Event: te.Event.Clone()})
+		}
+	}
+
+	if err := play(end); err != nil {
+		return nil, err
+	}
+
+	eot, err := event.NewEndOfTrackEvent(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return midi.NewTrackFromTimedEvents(append(tes, midi.TimedEvent{Tick: end, Event: eot})), nil
+}
+
+// SetPattern sets pattern. The default is Up.
+func (a *Arpeggiator) SetPattern(pattern Pattern) *Arpeggiator {
+	a.pattern = pattern
+
+	return a
+}
+
+// SetRate sets the length of step used by Generate. The default is Sixteenth.
+func (a *Arpeggiator) SetRate(rate NoteValue) error {
+	if rate.numerator == 0 || rate.denominator == 0 {
+		return fmt.Errorf("midi: rate must be greater than 0")
+	}
+
+	a.rate = rate
+
+	return nil
+}
+
+// SetGate sets the length of notes relative to the step used by Generate, which is greater than 0 and less than
+// or equal to 1. The default is 0.5.
+func (a *Arpeggiator) SetGate(gate float64) error {
+	if gate <= 0 || gate > 1 {
+		return fmt.Errorf("midi: gate must be greater than 0 and less than or equal to 1 (%v)", gate)
+	}
+
+	a.gate = gate
+
+	return nil
+}
+
+// SetOctaves sets the number of octaves which the arpeggio spans. The default is 1.
+func (a *Arpeggiator) SetOctaves(octaves int) error {
+	if octaves < 1 || octaves > 10 {
+		return fmt.Errorf("midi: octaves must be 1 to 10 (%v)", octaves)
+	}
+
+	a.octaves = octaves
+
+	return nil
+}
+
+// SetLatch sets latch. With latch, the released notes keep being played until a new chord is pressed.
+// Turning off latch releases the notes which are not pressed.
+func (a *Arpeggiator) SetLatch(latch bool) *Arpeggiator {
+	a.latch = latch
+
+	if !latch {
+		a.held = append([]Note{}, a.pressed...)
+	}
+
+	return a
+}
+
+// SetSeed sets the seed of Random pattern. The default is 1, so that the random arpeggio is reproducible.
+func (a *Arpeggiator) SetSeed(seed int64) *Arpeggiator {
+	a.random = rand.New(rand.NewSource(seed))
+
+	return a
+}
+
+// NewArpeggiator returns Arpeggiator which plays Up pattern in sixteenth notes.
+func NewArpeggiator() *Arpeggiator {
+	a := &Arpeggiator{
+		pattern: Up,
+		rate:    Sixteenth,
+		gate:    0.5,
+		octaves: 1,
+		last:    -1,
+	}
+
+	return a.SetSeed(1)
+}
@@ -0,0 +1,265 @@
+package arpeggio
+
+import (
+	"bytes"
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+type timedEvent struct {
+	tick  uint32
+	event event.Event
+}
+
+func noteOnEvent(channel uint8, note constant.Note, velocity uint8) event.Event {
+	e, _ := event.NewNoteOnEvent(nil, channel, note, velocity)
+	return e
+}
+
+func noteOffEvent(channel uint8, note constant.Note) event.Event {
+	e, _ := event.NewNoteOffEvent(nil, channel, note, 0x00)
+	return e
+}
+
+func newTrack(end uint32, tes ...timedEvent) *midi.Track {
+	var s []midi.TimedEvent
+
+	for _, te := range tes {
+		s = append(s, midi.TimedEvent{Tick: te.tick, Event: te.event})
+	}
+
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	return midi.NewTrackFromTimedEvents(append(s, midi.TimedEvent{Tick: end, Event: eot}))
+}
+
+func expectTrack(t *testing.T, track *midi.Track, expected []timedEvent) {
+	t.Helper()
+
+	actual := track.TimedEvents()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v events actual: %v", len(expected), actual)
+	}
+	for i, e := range expected {
+		if e.tick != actual[i].Tick || !bytes.Equal(e.event.Serialize(), actual[i].Event.Serialize()) {
+			t.Fatalf("[%v] expected: %v %v actual: %v %v", i, e.tick, e.event, actual[i].Tick, actual[i].Event)
+		}
+	}
+}
+
+func notes(ns []Note) []constant.Note {
+	var s []constant.Note
+
+	for _, n := range ns {
+		s = append(s, n.Note)
+	}
+
+	return s
+}
+
+func TestArpeggiator_Sequence(t *testing.T) {
+	for _, c := range []struct {
+		pattern  Pattern
+		octaves  int
+		expected []constant.Note
+	}{
+		{Up, 1, []constant.Note{constant.C3, constant.E3, constant.G3}},
+		{Down, 1, []constant.Note{constant.G3, constant.E3, constant.C3}},
+		{UpDown, 1, []constant.Note{constant.C3, constant.E3, constant.G3, constant.E3}},
+		{AsPlayed, 1, []constant.Note{constant.E3, constant.C3, constant.G3}},
+		{Up, 2, []constant.Note{constant.C3, constant.E3, constant.G3, constant.C4, constant.E4, constant.G4}},
+	} {
+		a := NewArpeggiator().SetPattern(c.pattern)
+		a.SetOctaves(c.octaves)
+		a.Press(0, constant.E3, 0x40)
+		a.Press(0, constant.C3, 0x40)
+		a.Press(0, constant.G3, 0x40)
+
+		if actual := notes(a.Sequence()); len(actual) != len(c.expected) {
+			t.Fatalf("expected: %v actual: %v", c.expected, actual)
+		} else {
+			for i := range actual {
+				if actual[i] != c.expected[i] {
+					t.Fatalf("expected: %v actual: %v", c.expected, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestArpeggiator_Next(t *testing.T) {
+	a := NewArpeggiator()
+
+	if _, ok := a.Next(); ok {
+		t.Fatal("expected no note")
+	}
+
+	a.Press(0, constant.C3, 0x40)
+	a.Press(0, constant.E3, 0x50)
+
+	for _, expected := range []Note{{0, constant.C3, 0x40}, {0, constant.E3, 0x50}, {0, constant.C3, 0x40}} {
+		if actual, ok := a.Next(); !ok || actual != expected {
+			t.Fatalf("expected: %v actual: %v", expected, actual)
+		}
+	}
+
+	a.Release(0, constant.C3)
+
+	if actual, _ := a.Next(); actual.Note != constant.E3 {
+		t.Fatalf("expected: %v actual: %v", constant.E3, actual.Note)
+	}
+
+	a.Release(0, constant.E3)
+
+	if _, ok := a.Next(); ok {
+		t.Fatal("expected no note")
+	}
+}
+
+func TestArpeggiator_Next_random(t *testing.T) {
+	play := func(seed int64) []constant.Note {
+		a := NewArpeggiator().SetPattern(Random).SetSeed(seed)
+		a.Press(0, constant.C3, 0x40)
+		a.Press(0, constant.E3, 0x40)
+		a.Press(0, constant.G3, 0x40)
+
+		var s []constant.Note
+
+		for i := 0; i < 100; i++ {
+			n, _ := a.Next()
+			s = append(s, n.Note)
+		}
+
+		return s
+	}
+
+	s := play(1)
+	counts := map[constant.Note]int{}
+
+	for i, n := range s {
+		counts[n]++
+
+		if i > 0 && s[i-1] == n {
+			t.Fatalf("repeated %v at %v", n, i)
+		}
+	}
+	if len(counts) != 3 {
+		t.Fatalf("expected: 3 notes actual: %v", counts)
+	}
+
+	r := play(1)
+
+	for i := range s {
+		if s[i] != r[i] {
+			t.Fatalf("expected: %v actual: %v", s, r)
+		}
+	}
+}
+
+func TestArpeggiator_SetLatch(t *testing.T) {
+	a := NewArpeggiator().SetLatch(true)
+	a.Press(0, constant.C3, 0x40)
+	a.Press(0, constant.E3, 0x40)
+	a.Release(0, constant.C3)
+	a.Release(0, constant.E3)
+
+	if actual := notes(a.Held()); len(actual) != 2 {
+		t.Fatalf("expected: 2 notes actual: %v", actual)
+	}
+
+	// The new chord replaces the latched one.
+	a.Press(0, constant.G3, 0x40)
+	a.Press(0, constant.C4, 0x40)
+
+	if actual := notes(a.Sequence()); len(actual) != 2 || actual[0] != constant.G3 || actual[1] != constant.C4 {
+		t.Fatalf("expected: [G3 C4] actual: %v", actual)
+	}
+
+	a.Release(0, constant.C4)
+	a.SetLatch(false)
+
+	if actual := notes(a.Held()); len(actual) != 1 || actual[0] != constant.G3 {
+		t.Fatalf("expected: [G3] actual: %v", actual)
+	}
+}
+
+func TestArpeggiator_Generate(t *testing.T) {
+	program, _ := event.NewProgramChangeEvent(nil, 0, constant.AcousticGrandPiano)
+
+	track := newTrack(1200,
+		timedEvent{0, program},
+		timedEvent{10, noteOnEvent(0, constant.E3, 0x50)},
+		timedEvent{10, noteOnEvent(0, constant.C3, 0x40)},
+		timedEvent{720, noteOffEvent(0, constant.C3)},
+		timedEvent{730, noteOffEvent(0, constant.E3)},
+	)
+
+	a := NewArpeggiator()
+
+	if err := a.SetRate(Eighth); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := a.Generate(track, 480)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	// The arpeggio starts at the next step after the chord, and the note released at the step isn't played.
+	expectTrack(t, actual, []timedEvent{
+		{0, program},
+		{240, noteOnEvent(0, constant.C3, 0x40)},
+		{360, noteOffEvent(0, constant.C3)},
+		{480, noteOnEvent(0, constant.E3, 0x50)},
+		{600, noteOffEvent(0, constant.E3)},
+		{720, noteOnEvent(0, constant.E3, 0x50)},
+		{840, noteOffEvent(0, constant.E3)},
+		{1200, eot},
+	})
+
+	a.SetLatch(true)
+
+	if actual, err = a.Generate(track, 480); err != nil {
+		t.Fatal(err)
+	}
+
+	// The latched chord keeps being played until the end of track.
+	expectTrack(t, actual, []timedEvent{
+		{0, program},
+		{240, noteOnEvent(0, constant.C3, 0x40)},
+		{360, noteOffEvent(0, constant.C3)},
+		{480, noteOnEvent(0, constant.E3, 0x50)},
+		{600, noteOffEvent(0, constant.E3)},
+		{720, noteOnEvent(0, constant.C3, 0x40)},
+		{840, noteOffEvent(0, constant.C3)},
+		{960, noteOnEvent(0, constant.E3, 0x50)},
+		{1080, noteOffEvent(0, constant.E3)},
+		{1200, eot},
+	})
+}
+
+func TestArpeggiator_error(t *testing.T) {
+	a := NewArpeggiator()
+
+	if err := a.SetGate(0); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := a.SetOctaves(11); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := a.SetRate(NoteValue{}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	a.SetRate(ThirtySecond)
+
+	if _, err := a.Generate(newTrack(0), 4); err == nil {
+		t.Fatal("expected error")
+	}
+}
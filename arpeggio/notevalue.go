@@ -0,0 +1,58 @@
+/*
+Package arpeggio generates arpeggios and strums from held chords.
+
+Arpeggiator plays the held notes one by one with a pattern, and Strum spreads the notes of chords like a guitar.
+Both generate standard note on and note off events from a track, and Arpeggiator also works on live input with
+Press, Release and Next.
+*/
+package arpeggio
+
+import "fmt"
+
+// NoteValue represents the length of note relative to the whole note, e.g. 1/16 for sixteenth note.
+type NoteValue struct {
+	numerator   uint32
+	denominator uint32
+}
+
+var (
+	Whole        = NoteValue{1, 1}
+	Half         = NoteValue{1, 2}
+	Quarter      = NoteValue{1, 4}
+	Eighth       = NoteValue{1, 8}
+	Sixteenth    = NoteValue{1, 16}
+	ThirtySecond = NoteValue{1, 32}
+)
+
+// Dotted returns the dotted note value, which is 1.5 times longer.
+func (v NoteValue) Dotted() NoteValue {
+	return NoteValue{v.numerator * 3, v.denominator * 2}
+}
+
+// Triplet returns the triplet note value, which is 2/3 times shorter.
+func (v NoteValue) Triplet() NoteValue {
+	return NoteValue{v.numerator * 2, v.denominator * 3}
+}
+
+// Ticks returns the length in ticks. The fraction of tick is truncated.
+func (v NoteValue) Ticks(ticksPerQuarterNote uint32) uint32 {
+	if v.denominator == 0 {
+		return 0
+	}
+
+	return uint32(uint64(ticksPerQuarterNote) * 4 * uint64(v.numerator) / uint64(v.denominator))
+}
+
+// String returns string representation of note value, e.g. "1/16".
+func (v NoteValue) String() string {
+	return fmt.Sprintf("%v/%v", v.numerator, v.denominator)
+}
+
+// NewNoteValue returns NoteValue of numerator / denominator of the whole note, e.g. 3/16 for dotted eighth note.
+func NewNoteValue(numerator, denominator uint32) (NoteValue, error) {
+	if numerator == 0 || denominator == 0 {
+		return NoteValue{}, fmt.Errorf("midi: numerator and denominator of note value must be greater than 0")
+	}
+
+	return NoteValue{numerator, denominator}, nil
+}
@@ -0,0 +1,37 @@
+package arpeggio
+
+import "testing"
+
+func TestNoteValue_Ticks(t *testing.T) {
+	for _, c := range []struct {
+		value    NoteValue
+		expected uint32
+	}{
+		{Whole, 1920},
+		{Quarter, 480},
+		{Sixteenth, 120},
+		{Eighth.Dotted(), 360},
+		{Eighth.Triplet(), 160},
+		{ThirtySecond, 60},
+	} {
+		if actual := c.value.Ticks(480); actual != c.expected {
+			t.Fatalf("%v expected: %v actual: %v", c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestNewNoteValue(t *testing.T) {
+	v, err := NewNoteValue(3, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != Eighth.Dotted() {
+		t.Fatalf("expected: %v actual: %v", Eighth.Dotted(), v)
+	}
+	if expected, actual := "3/16", v.String(); expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+	if _, err := NewNoteValue(0, 4); err == nil {
+		t.Fatal("expected error")
+	}
+}
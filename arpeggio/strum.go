@@ -0,0 +1,259 @@
+package arpeggio
+
+import (
+	"fmt"
+	"sort"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Direction represents the direction of strum.
+type Direction uint8
+
+const (
+	// DownStroke strums from the lowest string to the highest.
+	DownStroke Direction = iota
+	// UpStroke strums from the highest string to the lowest.
+	UpStroke
+)
+
+// StandardTuning is the open strings of guitar in standard tuning from the lowest.
+var StandardTuning = []constant.Note{constant.E1, constant.A1, constant.D2, constant.G2, constant.B2, constant.E3}
+
+// Strum spreads the notes of chords over time like strumming a guitar.
+type Strum struct {
+	delay     uint32
+	slope     int
+	direction Direction
+	tuning    []constant.Note
+	frets     int
+}
+
+// chord is the notes pressed at the same tick on the same channel.
+type chord struct {
+	tick     uint32
+	channel  uint8
+	notes    []constant.Note
+	velocity uint8
+	off      uint32
+}
+
+// Voice returns the notes played on the strings for the chord, from the lowest string. Each string plays the lowest
+// note within the reach of frets whose pitch class is in the chord, and the string is muted if there is no such
+// note or the note is already played on another string. Without tuning, it returns the notes in ascending order.
+func (s *Strum) Voice(notes []constant.Note) []constant.Note {
+	var voicing []constant.Note
+
+	played := map[constant.Note]bool{}
+
+	if len(s.tuning) == 0 {
+		for _, n := range notes {
+			if !played[n] {
+				played[n] = true
+				voicing = append(voicing, n)
+			}
+		}
+
+		sort.Slice(voicing, func(i, j int) bool {
+			return voicing[i] < voicing[j]
+		})
+
+		return voicing
+	}
+
+	classes := map[int]bool{}
+
+	for _, n := range notes {
+		classes[int(n)%12] = true
+	}
+	for _, open := range s.tuning {
+		for fret := 0; fret <= s.frets; fret++ {
+			n := int(open) + fret
+			if n > 0x7f {
+				break
+			}
+			if classes[n%12] {
+				if !played[constant.Note(n)] {
+					played[constant.Note(n)] = true
+					voicing = append(voicing, constant.Note(n))
+				}
+				break
+			}
+		}
+	}
+
+	return voicing
+}
+
+// Generate returns the track in which the chords of the track are strummed. The notes pressed at the same tick on
+// the same channel are voiced as a chord, and the strings are played one by one with the delay in ticks.
+// The velocity of the first string is the highest velocity of chord, and the velocity slope is added for each
+// string. All strings are released when the last note of chord is released. The events except note events are kept.
+func (s *Strum) Generate(track *midi.Track) (*midi.Track, error) {
+	var chords []*chord
+	var end uint32
+
+	tes := []midi.TimedEvent{}
+	open := map[uint16]*chord{}
+	last := map[uint8]*chord{}
+
+	release := func(tick uint32, channel uint8, note constant.Note) {
+		key := uint16(channel)<<7 | uint16(note)
+
+		if c, ok := open[key]; ok {
+			if tick > c.off {
+				c.off = tick
+			}
+			delete(open, key)
+		}
+	}
+
+	for _, te := range track.TimedEvents() {
+		if te.Tick > end {
+			end = te.Tick
+		}
+
+		switch v := te.Event.(type) {
+		case *event.NoteOnEvent:
+			if v.Velocity() == 0 {
+				release(te.Tick, v.Channel(), v.Note())
+				continue
+			}
+
+			c := last[v.Channel()]
+			if c == nil || c.tick != te.Tick {
+				c = &chord{tick: te.Tick, channel: v.Channel(), off: te.Tick}
+				chords = append(chords, c)
+				last[v.Channel()] = c
+			}
+			if v.Velocity() > c.velocity {
+				c.velocity = v.Velocity()
+			}
+
+			c.notes = append(c.notes, v.Note())
+			open[uint16(v.Channel())<<7|uint16(v.Note())] = c
+		case *event.NoteOffEvent:
+			release(te.Tick, v.Channel(), v.Note())
+		case *event.NoteAfterTouchEvent, *event.EndOfTrackEvent:
+		default:
+			tes = append(tes, midi.TimedEvent{Tick: te.Tick, Event: te.Event.Clone()})
+		}
+	}
+
+	// The notes which are not released are released at the end of track.
+	for _, c := range open {
+		c.off = end
+	}
+
+	var ons, offs []midi.TimedEvent
+
+	for _, c := range chords {
+		voicing := s.Voice(c.notes)
+
+		if s.direction == UpStroke {
+			for i, j := 0, len(voicing)-1; i < j; i, j = i+1, j-1 {
+				voicing[i], voicing[j] = voicing[j], voicing[i]
+			}
+		}
+
+		for i, n := range voicing {
+			tick := c.tick + uint32(i)*s.delay
+			off := c.off
+			if off <= tick {
+				off = tick + 1
+			}
+			if off > end {
+				end = off
+			}
+
+			velocity := int(c.velocity) + i*s.slope
+			if velocity < 1 {
+				velocity = 1
+			}
+			if velocity > 0x7f {
+				velocity = 0x7f
+			}
+
+			noteOn, err := event.NewNoteOnEvent(nil, c.channel, n, uint8(velocity))
+			if err != nil {
+				return nil, err
+			}
+			noteOff, err := event.NewNoteOffEvent(nil, c.channel, n, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			ons = append(ons, midi.TimedEvent{Tick: tick, Event: noteOn})
+			offs = append(offs, midi.TimedEvent{Tick: off, Event: noteOff})
+		}
+	}
+
+	// The note offs precede the other events at the same tick so that the next chord isn't cut off, and the note ons
+	// follow them so that e.g. program change takes effect.
+	tes = append(append(offs, tes...), ons...)
+
+	eot, err := event.NewEndOfTrackEvent(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return midi.NewTrackFromTimedEvents(append(tes, midi.TimedEvent{Tick: end, Event: eot})), nil
+}
+
+// SetDelay sets the delay between strings in ticks.
+func (s *Strum) SetDelay(delay uint32) *Strum {
+	s.delay = delay
+
+	return s
+}
+
+// SetVelocitySlope sets the velocity added for each string. The default is 0, and a negative slope makes the later
+// strings softer.
+func (s *Strum) SetVelocitySlope(slope int) *Strum {
+	s.slope = slope
+
+	return s
+}
+
+// SetDirection sets direction. The default is DownStroke.
+func (s *Strum) SetDirection(direction Direction) *Strum {
+	s.direction = direction
+
+	return s
+}
+
+// SetTuning sets the open strings from the lowest. The default is StandardTuning. The empty tuning disables
+// voicing, and the notes of chord are played as they are.
+func (s *Strum) SetTuning(tuning ...constant.Note) error {
+	for _, n := range tuning {
+		if n > 0x7f {
+			return fmt.Errorf("midi: maximum note number is 127 (0x7f)")
+		}
+	}
+
+	s.tuning = append([]constant.Note{}, tuning...)
+
+	return nil
+}
+
+// SetFrets sets the number of frets which can be reached from the open strings. The default is 4.
+func (s *Strum) SetFrets(frets int) error {
+	if frets < 0 || frets > 24 {
+		return fmt.Errorf("midi: frets must be 0 to 24 (%v)", frets)
+	}
+
+	s.frets = frets
+
+	return nil
+}
+
+// NewStrum returns Strum which strums down in standard tuning with the delay in ticks.
+func NewStrum(delay uint32) *Strum {
+	return &Strum{
+		delay:  delay,
+		tuning: append([]constant.Note{}, StandardTuning...),
+		frets:  4,
+	}
+}
@@ -0,0 +1,98 @@
+package arpeggio
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestStrum_Voice(t *testing.T) {
+	s := NewStrum(0)
+
+	for _, c := range []struct {
+		chord    []constant.Note
+		expected []constant.Note
+	}{
+		// Open C major: 032010.
+		{[]constant.Note{constant.C3, constant.E3, constant.G3}, []constant.Note{constant.E1, constant.C2, constant.E2, constant.G2, constant.C3, constant.E3}},
+		// Open E minor: 022000.
+		{[]constant.Note{constant.E3, constant.G3, constant.B3}, []constant.Note{constant.E1, constant.B1, constant.E2, constant.G2, constant.B2, constant.E3}},
+	} {
+		actual := s.Voice(c.chord)
+		if len(actual) != len(c.expected) {
+			t.Fatalf("expected: %v actual: %v", c.expected, actual)
+		}
+		for i := range actual {
+			if actual[i] != c.expected[i] {
+				t.Fatalf("expected: %v actual: %v", c.expected, actual)
+			}
+		}
+	}
+
+	s.SetTuning()
+
+	if actual := s.Voice([]constant.Note{constant.G3, constant.C3, constant.G3}); len(actual) != 2 || actual[0] != constant.C3 || actual[1] != constant.G3 {
+		t.Fatalf("expected: [C3 G3] actual: %v", actual)
+	}
+}
+
+func TestStrum_Generate(t *testing.T) {
+	controller, _ := event.NewControllerEvent(nil, 0, constant.Hold1, 0x7f)
+
+	track := newTrack(960,
+		timedEvent{0, noteOnEvent(0, constant.C3, 0x60)},
+		timedEvent{0, noteOnEvent(0, constant.E3, 0x64)},
+		timedEvent{0, noteOnEvent(0, constant.G3, 0x50)},
+		timedEvent{400, noteOffEvent(0, constant.C3)},
+		timedEvent{480, noteOffEvent(0, constant.E3)},
+		timedEvent{480, noteOffEvent(0, constant.G3)},
+		timedEvent{480, controller},
+		timedEvent{480, noteOnEvent(0, constant.E3, 0x40)},
+	)
+
+	s := NewStrum(10).SetVelocitySlope(-10)
+	s.SetTuning(constant.E1, constant.A1, constant.D2)
+
+	actual, err := s.Generate(track)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	expectTrack(t, actual, []timedEvent{
+		{0, noteOnEvent(0, constant.E1, 0x64)},
+		{10, noteOnEvent(0, constant.C2, 0x5a)},
+		{20, noteOnEvent(0, constant.E2, 0x50)},
+		{480, noteOffEvent(0, constant.E1)},
+		{480, noteOffEvent(0, constant.C2)},
+		{480, noteOffEvent(0, constant.E2)},
+		{480, controller},
+		{480, noteOnEvent(0, constant.E1, 0x40)},
+		{490, noteOnEvent(0, constant.E2, 0x36)},
+		{960, noteOffEvent(0, constant.E1)},
+		{960, noteOffEvent(0, constant.E2)},
+		{960, eot},
+	})
+
+	s.SetDirection(UpStroke)
+
+	if actual, err = s.Generate(track); err != nil {
+		t.Fatal(err)
+	}
+	if e := actual.Events[0].(*event.NoteOnEvent); e.Note() != constant.E2 {
+		t.Fatalf("expected: %v actual: %v", constant.E2, e.Note())
+	}
+}
+
+func TestStrum_error(t *testing.T) {
+	s := NewStrum(0)
+
+	if err := s.SetFrets(-1); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := s.SetTuning(0x80); err == nil {
+		t.Fatal("expected error")
+	}
+}
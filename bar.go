@@ -0,0 +1,113 @@
+package midi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/moutend/go-midi/event"
+)
+
+// Position represents a musical position. Bar and Beat start from 1.
+type Position struct {
+	Bar  int
+	Beat int
+
+	// Tick is the offset in ticks from the beginning of the beat.
+	Tick uint32
+}
+
+// String returns string representation of position, e.g. "12:3:120".
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d:%d", p.Bar, p.Beat, p.Tick)
+}
+
+type meter struct {
+	tick         uint32
+	bar          int
+	ticksPerBar  uint32
+	ticksPerBeat uint32
+}
+
+// meters returns the time signatures in effect. The time signature which is changed in the middle of bar starts a new bar.
+func (m *MIDI) meters() ([]meter, error) {
+	ticksPerQuarterNote, err := m.TimeDivision().BPM()
+	if err != nil {
+		return nil, err
+	}
+
+	newMeter := func(numerator, denominator uint8) meter {
+		ticksPerBeat := uint32(ticksPerQuarterNote) * 4 >> denominator
+		if ticksPerBeat == 0 {
+			ticksPerBeat = 1
+		}
+		if numerator == 0 {
+			numerator = 4
+		}
+		return meter{ticksPerBar: ticksPerBeat * uint32(numerator), ticksPerBeat: ticksPerBeat}
+	}
+
+	ms := []meter{newMeter(4, 2)}
+	ms[0].bar = 1
+
+	for _, te := range m.TimedEvents() {
+		e, ok := te.Event.(*event.TimeSignatureEvent)
+		if !ok {
+			continue
+		}
+
+		last := ms[len(ms)-1]
+		next := newMeter(e.Numerator(), e.Denominator())
+		next.tick = te.Tick
+		next.bar = last.bar + int((te.Tick-last.tick+last.ticksPerBar-1)/last.ticksPerBar)
+
+		if te.Tick == last.tick {
+			ms[len(ms)-1] = next
+			continue
+		}
+
+		ms = append(ms, next)
+	}
+
+	return ms, nil
+}
+
+// BarTick returns the absolute time in ticks of the beginning of bar. The bar starts from 1.
+func (m *MIDI) BarTick(bar int) (uint32, error) {
+	if bar < 1 {
+		return 0, fmt.Errorf("midi: bar must be greater than 0")
+	}
+
+	ms, err := m.meters()
+	if err != nil {
+		return 0, err
+	}
+
+	i := sort.Search(len(ms), func(i int) bool {
+		return ms[i].bar > bar
+	}) - 1
+
+	return ms[i].tick + uint32(bar-ms[i].bar)*ms[i].ticksPerBar, nil
+}
+
+// Position returns the musical position of the tick.
+func (m *MIDI) Position(tick uint32) (Position, error) {
+	ms, err := m.meters()
+	if err != nil {
+		return Position{}, err
+	}
+
+	i := sort.Search(len(ms), func(i int) bool {
+		return ms[i].tick > tick
+	}) - 1
+
+	offset := tick - ms[i].tick
+	rest := offset % ms[i].ticksPerBar
+
+	p := Position{
+		Bar:  ms[i].bar + int(offset/ms[i].ticksPerBar),
+		Beat: int(rest/ms[i].ticksPerBeat) + 1,
+		Tick: rest % ms[i].ticksPerBeat,
+	}
+
+	return p, nil
+}
@@ -0,0 +1,73 @@
+package midi
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestMeterMIDI(tes ...TimedEvent) *MIDI {
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	m := &MIDI{
+		Tracks: []*Track{NewTrackFromTimedEvents(append(tes, TimedEvent{Tick: 10000, Event: eot}))},
+	}
+	m.TimeDivision().SetBPM(480)
+
+	return m
+}
+
+func TestMIDI_BarTick(t *testing.T) {
+	threeFour, _ := event.NewTimeSignatureEvent(nil, 3, 2, 24, 8)
+	sixEight, _ := event.NewTimeSignatureEvent(nil, 6, 3, 36, 8)
+
+	m := newTestMeterMIDI(TimedEvent{Tick: 0, Event: threeFour}, TimedEvent{Tick: 2880, Event: sixEight})
+
+	if _, err := m.BarTick(0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	for bar, expected := range map[int]uint32{1: 0, 2: 1440, 3: 2880, 5: 5760} {
+		actual, err := m.BarTick(bar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected != actual {
+			t.Fatalf("expected: %v actual: %v (bar: %v)", expected, actual, bar)
+		}
+	}
+
+	// The time signature which is changed in the middle of bar starts a new bar.
+	threeFour, _ = event.NewTimeSignatureEvent(nil, 3, 2, 24, 8)
+	m = newTestMeterMIDI(TimedEvent{Tick: 1000, Event: threeFour})
+
+	if actual, _ := m.BarTick(3); actual != 2440 {
+		t.Fatalf("expected: 2440 actual: %v", actual)
+	}
+}
+
+func TestMIDI_Position(t *testing.T) {
+	sixEight, _ := event.NewTimeSignatureEvent(nil, 6, 3, 36, 8)
+
+	m := newTestMeterMIDI(TimedEvent{Tick: 3840, Event: sixEight})
+
+	for tick, expected := range map[uint32]string{
+		0:    "1:1:0",
+		1000: "1:3:40",
+		3840: "3:1:0",
+		5400: "4:1:120",
+	} {
+		actual, err := m.Position(tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected != actual.String() {
+			t.Fatalf("expected: %v actual: %v (tick: %v)", expected, actual, tick)
+		}
+	}
+
+	m.TimeDivision().SetFPS(25, 40)
+
+	if _, err := m.Position(0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
@@ -0,0 +1,152 @@
+package bend
+
+import (
+	"fmt"
+	"math"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/event"
+)
+
+// Curve maps the progress of glide from 0.0 to 1.0 to the progress of pitch from 0.0 to 1.0.
+type Curve func(float64) float64
+
+// Linear changes pitch at constant speed.
+func Linear(x float64) float64 {
+	return x
+}
+
+// EaseInOut changes pitch slowly at the beginning and the end.
+func EaseInOut(x float64) float64 {
+	return (1 - math.Cos(math.Pi*x)) / 2
+}
+
+// Glide represents a pitch glide from a pitch to another.
+type Glide struct {
+	Channel uint8
+
+	// From and To are pitch in semitones relative to the note.
+	From float64
+	To   float64
+
+	// Sensitivity is the pitch bend sensitivity in semitones. DefaultSensitivity is used if it's 0.
+	Sensitivity float64
+
+	// Duration is the length of glide in ticks.
+	Duration uint32
+
+	// Resolution is the interval between events in ticks.
+	Resolution uint32
+
+	// Curve is the shape of glide. Linear is used if it's nil.
+	Curve Curve
+}
+
+// Events returns the pitch bend events of glide which starts at the tick.
+// The pitch reaches To at start+Duration.
+func (g Glide) Events(start uint32) ([]midi.TimedEvent, error) {
+	if g.Resolution == 0 {
+		return nil, fmt.Errorf("midi: resolution must be greater than 0")
+	}
+
+	curve := g.Curve
+	if curve == nil {
+		curve = Linear
+	}
+
+	sensitivity := g.Sensitivity
+	if sensitivity == 0 {
+		sensitivity = DefaultSensitivity
+	}
+
+	return generate(g.Channel, sensitivity, start, g.Duration, g.Resolution, func(tick uint32) float64 {
+		if g.Duration == 0 {
+			return g.To
+		}
+		return g.From + (g.To-g.From)*curve(float64(tick)/float64(g.Duration))
+	})
+}
+
+// Vibrato represents a periodic pitch modulation.
+type Vibrato struct {
+	Channel uint8
+
+	// Depth is the amplitude of vibrato in semitones.
+	Depth float64
+
+	// Period is the length of a cycle in ticks. It determines the rate of vibrato.
+	Period uint32
+
+	// Delay is the length in ticks before the vibrato reaches the full depth.
+	Delay uint32
+
+	// Sensitivity is the pitch bend sensitivity in semitones. DefaultSensitivity is used if it's 0.
+	Sensitivity float64
+
+	// Duration is the length of vibrato in ticks.
+	Duration uint32
+
+	// Resolution is the interval between events in ticks.
+	Resolution uint32
+}
+
+// Events returns the pitch bend events of vibrato which starts at the tick.
+// The pitch returns to the center at start+Duration.
+func (v Vibrato) Events(start uint32) ([]midi.TimedEvent, error) {
+	if v.Resolution == 0 {
+		return nil, fmt.Errorf("midi: resolution must be greater than 0")
+	}
+	if v.Period == 0 {
+		return nil, fmt.Errorf("midi: period must be greater than 0")
+	}
+
+	sensitivity := v.Sensitivity
+	if sensitivity == 0 {
+		sensitivity = DefaultSensitivity
+	}
+
+	return generate(v.Channel, sensitivity, start, v.Duration, v.Resolution, func(tick uint32) float64 {
+		if tick >= v.Duration {
+			return 0
+		}
+
+		depth := v.Depth
+		if tick < v.Delay {
+			depth *= float64(tick) / float64(v.Delay)
+		}
+
+		return depth * math.Sin(2*math.Pi*float64(tick)/float64(v.Period))
+	})
+}
+
+// generate samples the pitch every resolution ticks and returns the pitch bend events.
+// The events which don't change the pitch are omitted.
+func generate(channel uint8, sensitivity float64, start, duration, resolution uint32, pitch func(uint32) float64) ([]midi.TimedEvent, error) {
+	tes := []midi.TimedEvent{}
+	previous := -1
+
+	for tick := uint32(0); ; tick += resolution {
+		if tick > duration {
+			tick = duration
+		}
+
+		semitones := math.Max(-sensitivity, math.Min(sensitivity, pitch(tick)))
+
+		e, err := event.NewPitchBendEvent(nil, channel, event.PitchBendCenter)
+		if err != nil {
+			return nil, err
+		}
+		if err := e.SetSemitones(semitones, sensitivity); err != nil {
+			return nil, err
+		}
+		if int(e.Pitch()) != previous {
+			tes = append(tes, midi.TimedEvent{Tick: start + tick, Event: e})
+			previous = int(e.Pitch())
+		}
+		if tick == duration {
+			break
+		}
+	}
+
+	return tes, nil
+}
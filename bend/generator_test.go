@@ -0,0 +1,85 @@
+package bend
+
+import (
+	"math"
+	"testing"
+
+	"github.com/moutend/go-midi/event"
+)
+
+func TestGlide_Events(t *testing.T) {
+	if _, err := (Glide{Duration: 480}).Events(0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+
+	g := Glide{
+		Channel:     2,
+		From:        0,
+		To:          -12,
+		Sensitivity: 12,
+		Duration:    480,
+		Resolution:  120,
+	}
+
+	tes, err := g.Events(960)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTicks := []uint32{960, 1080, 1200, 1320, 1440}
+	expectedPitches := []uint16{0x2000, 0x1800, 0x1000, 0x0800, 0x0000}
+
+	if len(expectedTicks) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v events", len(expectedTicks), len(tes))
+	}
+	for i, te := range tes {
+		e := te.Event.(*event.PitchBendEvent)
+		if te.Tick != expectedTicks[i] || e.Pitch() != expectedPitches[i] || e.Channel() != 2 {
+			t.Fatalf("unexpected event at %v: %v", te.Tick, e)
+		}
+	}
+
+	g.Curve = EaseInOut
+
+	tes, _ = g.Events(0)
+	if actual := tes[2].Event.(*event.PitchBendEvent).Semitones(12); math.Abs(actual+6) > 0.01 {
+		t.Fatalf("expected: -6 actual: %v", actual)
+	}
+}
+
+func TestVibrato_Events(t *testing.T) {
+	if _, err := (Vibrato{Resolution: 10}).Events(0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+
+	v := Vibrato{
+		Depth:      0.5,
+		Period:     240,
+		Duration:   480,
+		Resolution: 60,
+	}
+
+	tes, err := v.Events(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []float64{0, 0.5, 0, -0.5, 0, 0.5, 0, -0.5, 0}
+
+	if len(expected) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(tes))
+	}
+	for i, te := range tes {
+		actual := te.Event.(*event.PitchBendEvent).Semitones(DefaultSensitivity)
+		if math.Abs(expected[i]-actual) > 0.001 || te.Tick != uint32(i*60) {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v at %v", i, expected[i], i, actual, te.Tick)
+		}
+	}
+
+	v.Delay = 240
+
+	tes, _ = v.Events(0)
+	if actual := tes[1].Event.(*event.PitchBendEvent).Semitones(DefaultSensitivity); math.Abs(actual-0.125) > 0.001 {
+		t.Fatalf("expected: 0.125 actual: %v", actual)
+	}
+}
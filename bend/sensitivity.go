@@ -0,0 +1,87 @@
+/*
+Package bend converts pitch bend events to semitones with the pitch bend sensitivity in effect,
+and generates pitch bend curves such as glides and vibratos.
+*/
+package bend
+
+import (
+	"sort"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/parameter"
+)
+
+// DefaultSensitivity is the pitch bend sensitivity in semitones assumed until RPN 0 is received.
+const DefaultSensitivity = 2.0
+
+type sensitivityChange struct {
+	tick      uint32
+	semitones float64
+}
+
+// SensitivityMap holds the pitch bend sensitivity of each channel over time.
+type SensitivityMap struct {
+	channels [16][]sensitivityChange
+}
+
+// At returns the pitch bend sensitivity in semitones of the channel at the tick.
+// The change at the tick is taken into account.
+func (s *SensitivityMap) At(channel uint8, tick uint32) float64 {
+	changes := s.channels[channel&0x0f]
+
+	i := sort.Search(len(changes), func(i int) bool {
+		return changes[i].tick > tick
+	})
+	if i == 0 {
+		return DefaultSensitivity
+	}
+
+	return changes[i-1].semitones
+}
+
+// Semitones returns the pitch of event at the tick in semitones.
+func (s *SensitivityMap) Semitones(e *event.PitchBendEvent, tick uint32) float64 {
+	return e.Semitones(s.At(e.Channel(), tick))
+}
+
+// Cents returns the pitch of event at the tick in cents.
+func (s *SensitivityMap) Cents(e *event.PitchBendEvent, tick uint32) float64 {
+	return e.Cents(s.At(e.Channel(), tick))
+}
+
+// Set sets the pitch bend sensitivity in semitones of the channel from the tick.
+// The changes must be set in chronological order.
+func (s *SensitivityMap) Set(channel uint8, tick uint32, semitones float64) {
+	changes := s.channels[channel&0x0f]
+
+	if n := len(changes); n > 0 && changes[n-1].tick == tick {
+		changes[n-1].semitones = semitones
+		return
+	}
+
+	s.channels[channel&0x0f] = append(changes, sensitivityChange{tick, semitones})
+}
+
+// NewSensitivityMap returns SensitivityMap built from RPN 0 (pitch bend sensitivity) of all tracks.
+func NewSensitivityMap(m *midi.MIDI) *SensitivityMap {
+	s := &SensitivityMap{}
+	d := parameter.NewDecoder()
+
+	for _, te := range m.TimedEvents() {
+		e, ok := te.Event.(*event.ControllerEvent)
+		if !ok {
+			continue
+		}
+
+		message, ok := d.Decode(e)
+		if !ok {
+			continue
+		}
+		if semitones, err := message.PitchBendSensitivity(); err == nil {
+			s.Set(message.Channel, te.Tick, semitones)
+		}
+	}
+
+	return s
+}
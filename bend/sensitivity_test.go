@@ -0,0 +1,49 @@
+package bend
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/parameter"
+)
+
+func TestSensitivityMap_At(t *testing.T) {
+	message, _ := parameter.NewPitchBendSensitivity(1, 12, 0)
+	es, _ := parameter.Encode(message)
+
+	tes := []midi.TimedEvent{}
+	for _, e := range es {
+		tes = append(tes, midi.TimedEvent{Tick: 960, Event: e})
+	}
+
+	m := &midi.MIDI{
+		Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)},
+	}
+	s := NewSensitivityMap(m)
+
+	for _, c := range []struct {
+		channel   uint8
+		tick      uint32
+		semitones float64
+	}{
+		{1, 0, DefaultSensitivity},
+		{1, 959, DefaultSensitivity},
+		{1, 960, 12},
+		{1, 1920, 12},
+		{0, 1920, DefaultSensitivity},
+	} {
+		if actual := s.At(c.channel, c.tick); actual != c.semitones {
+			t.Fatalf("expected: %v actual: %v (channel: %v, tick: %v)", c.semitones, actual, c.channel, c.tick)
+		}
+	}
+
+	e, _ := event.NewPitchBendEvent(nil, 1, 0x1000)
+
+	if actual := s.Semitones(e, 0); actual != -1 {
+		t.Fatalf("expected: -1 actual: %v", actual)
+	}
+	if actual := s.Cents(e, 960); actual != -600 {
+		t.Fatalf("expected: -600 actual: %v", actual)
+	}
+}
@@ -0,0 +1,298 @@
+package chase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/parameter"
+)
+
+// Note represents a sounding note.
+type Note struct {
+	Note     constant.Note
+	Velocity uint8
+
+	// Tick is the absolute time when the note started.
+	Tick uint32
+}
+
+// Selection represents the parameter selected by RPN or NRPN.
+type Selection struct {
+	Registered bool
+	Number     uint16
+}
+
+// IsNull reports whether no parameter is selected.
+func (s Selection) IsNull() bool {
+	return s.Number == uint16(constant.NullParameter)
+}
+
+// Channel represents the state of a channel.
+type Channel struct {
+	// Program is valid only if ProgramSet is true.
+	Program    constant.GM
+	ProgramSet bool
+
+	// Controllers holds the last values of controllers except for RPN, NRPN and data entry.
+	Controllers map[constant.Control]uint8
+
+	// RegisteredParameters and NonRegisteredParameters hold the 14-bit values of parameters.
+	RegisteredParameters    map[constant.RegisteredParameter]uint16
+	NonRegisteredParameters map[uint16]uint16
+
+	// Selected is the parameter which is changed by the following data entry.
+	Selected Selection
+
+	// PitchBend is the 14-bit pitch. The default is event.PitchBendCenter.
+	PitchBend uint16
+
+	// Pressure is the value of channel after touch.
+	Pressure uint8
+
+	// Notes are the sounding notes in the order of start.
+	Notes []Note
+
+	rpn  uint16
+	nrpn uint16
+}
+
+// Controller returns the value of controller. The second return value is false if the controller has never been set.
+func (c *Channel) Controller(control constant.Control) (uint8, bool) {
+	value, ok := c.Controllers[control]
+	return value, ok
+}
+
+// Events returns the events which recreate the state of channel except for notes.
+// The events are ordered as bank select, program change, controllers, parameters, pitch bend and channel after touch.
+func (c *Channel) Events(channel uint8) ([]event.Event, error) {
+	if channel > 0x0f {
+		return nil, fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+	}
+
+	es := []event.Event{}
+
+	controller := func(control constant.Control, value uint8) error {
+		e, err := event.NewControllerEvent(nil, channel, control, value)
+		if err != nil {
+			return err
+		}
+		es = append(es, e)
+		return nil
+	}
+
+	for _, control := range []constant.Control{constant.BankSelect, constant.BankSelectLSB} {
+		if value, ok := c.Controllers[control]; ok {
+			if err := controller(control, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if c.ProgramSet {
+		e, err := event.NewProgramChangeEvent(nil, channel, c.Program)
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	controls := []int{}
+	for control := range c.Controllers {
+		if control != constant.BankSelect && control != constant.BankSelectLSB {
+			controls = append(controls, int(control))
+		}
+	}
+	sort.Ints(controls)
+
+	for _, control := range controls {
+		if err := controller(constant.Control(control), c.Controllers[constant.Control(control)]); err != nil {
+			return nil, err
+		}
+	}
+
+	messages := []parameter.Message{}
+
+	registered := []int{}
+	for number := range c.RegisteredParameters {
+		registered = append(registered, int(number))
+	}
+	sort.Ints(registered)
+
+	for _, number := range registered {
+		m, err := parameter.NewRegisteredParameterChange(channel, constant.RegisteredParameter(number), c.RegisteredParameters[constant.RegisteredParameter(number)])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+
+	nonRegistered := []int{}
+	for number := range c.NonRegisteredParameters {
+		nonRegistered = append(nonRegistered, int(number))
+	}
+	sort.Ints(nonRegistered)
+
+	for _, number := range nonRegistered {
+		m, err := parameter.NewNonRegisteredParameterChange(channel, uint16(number), c.NonRegisteredParameters[uint16(number)])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	for _, m := range messages {
+		ces, err := parameter.Encode(m)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range ces {
+			es = append(es, e)
+		}
+	}
+
+	// Restore the selection, so that the following data entry changes the same parameter as the original.
+	if len(messages) > 0 || !c.Selected.IsNull() {
+		msb, lsb := constant.RegisteredParameterNumberMSB, constant.RegisteredParameterNumberLSB
+		if !c.Selected.Registered && !c.Selected.IsNull() {
+			msb, lsb = constant.NonRegisteredParameterNumberMSB, constant.NonRegisteredParameterNumberLSB
+		}
+		if err := controller(msb, uint8(c.Selected.Number>>7&0x7f)); err != nil {
+			return nil, err
+		}
+		if err := controller(lsb, uint8(c.Selected.Number&0x7f)); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.PitchBend != event.PitchBendCenter {
+		e, err := event.NewPitchBendEvent(nil, channel, c.PitchBend)
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+	if c.Pressure != 0 {
+		e, err := event.NewChannelAfterTouchEvent(nil, channel, c.Pressure)
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	return es, nil
+}
+
+// NoteEvents returns the note on events which restart the sounding notes.
+func (c *Channel) NoteEvents(channel uint8) ([]event.Event, error) {
+	es := []event.Event{}
+
+	for _, n := range c.Notes {
+		e, err := event.NewNoteOnEvent(nil, channel, n.Note, n.Velocity)
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	return es, nil
+}
+
+func (c *Channel) release(note constant.Note) {
+	for i, n := range c.Notes {
+		if n.Note == note {
+			c.Notes = append(c.Notes[:i:i], c.Notes[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Channel) clone() Channel {
+	v := *c
+	v.Controllers = nil
+	v.RegisteredParameters = nil
+	v.NonRegisteredParameters = nil
+	v.Notes = append([]Note{}, c.Notes...)
+
+	if c.Controllers != nil {
+		v.Controllers = map[constant.Control]uint8{}
+		for control, value := range c.Controllers {
+			v.Controllers[control] = value
+		}
+	}
+	if c.RegisteredParameters != nil {
+		v.RegisteredParameters = map[constant.RegisteredParameter]uint16{}
+		for number, value := range c.RegisteredParameters {
+			v.RegisteredParameters[number] = value
+		}
+	}
+	if c.NonRegisteredParameters != nil {
+		v.NonRegisteredParameters = map[uint16]uint16{}
+		for number, value := range c.NonRegisteredParameters {
+			v.NonRegisteredParameters[number] = value
+		}
+	}
+
+	return v
+}
+
+// resetControllers resets the state as reset all controllers message does. The program, the bank, the volume,
+// the pan and the values of parameters are kept.
+func (c *Channel) resetControllers() {
+	for control := range c.Controllers {
+		if _, ok := control.ResetValue(); ok {
+			delete(c.Controllers, control)
+		}
+	}
+
+	c.Selected = Selection{Registered: true, Number: uint16(constant.NullParameter)}
+	c.PitchBend = event.PitchBendCenter
+	c.Pressure = 0
+	c.rpn = uint16(constant.NullParameter)
+	c.nrpn = uint16(constant.NullParameter)
+}
+
+func newChannel() Channel {
+	return Channel{
+		Selected:  Selection{Registered: true, Number: uint16(constant.NullParameter)},
+		PitchBend: event.PitchBendCenter,
+		rpn:       uint16(constant.NullParameter),
+		nrpn:      uint16(constant.NullParameter),
+	}
+}
+
+// Snapshot represents the state of 16 channels at a tick.
+type Snapshot struct {
+	Tick     uint32
+	Channels [16]Channel
+}
+
+// Events returns the events which recreate the state of all channels except for notes.
+// The delta times of events are 0, so that they can be put at tick 0 of an excerpt.
+func (s *Snapshot) Events() ([]event.Event, error) {
+	es := []event.Event{}
+
+	for i := range s.Channels {
+		ces, err := s.Channels[i].Events(uint8(i))
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, ces...)
+	}
+
+	return es, nil
+}
+
+// NoteEvents returns the note on events which restart the sounding notes of all channels.
+func (s *Snapshot) NoteEvents() ([]event.Event, error) {
+	es := []event.Event{}
+
+	for i := range s.Channels {
+		ces, err := s.Channels[i].NoteEvents(uint8(i))
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, ces...)
+	}
+
+	return es, nil
+}
@@ -0,0 +1,67 @@
+package chase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestSnapshot_Events(t *testing.T) {
+	s := At(newTestMIDI(t), 481)
+
+	es, err := s.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]byte{
+		{0xb0, 0x00, 0x79},
+		{0xb0, 0x20, 0x01},
+		{0xc0, byte(constant.ElectricPiano1)},
+		{0xb0, 0x07, 0x50},
+		{0xb0, 0x65, 0x00},
+		{0xb0, 0x64, 0x00},
+		{0xb0, 0x06, 0x0c},
+		{0xb0, 0x26, 0x00},
+		{0xb0, 0x65, 0x00},
+		{0xb0, 0x64, 0x00},
+		{0xe0, 0x00, 0x60},
+	}
+
+	if len(expected) != len(es) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(es))
+	}
+	for i, e := range es {
+		if actual := e.Serialize(); !reflect.DeepEqual(expected[i], actual) {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected[i], i, actual)
+		}
+		if e.DeltaTime().Quantity().Uint32() != 0 {
+			t.Fatalf("delta time must be 0")
+		}
+	}
+
+	es, err = s.NoteEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 1 {
+		t.Fatalf("expected: 1 event actual: %v events", len(es))
+	}
+	if e := es[0].(*event.NoteOnEvent); e.Note() != constant.E3 || e.Velocity() != 90 {
+		t.Fatalf("unexpected event: %v", e)
+	}
+}
+
+func TestSnapshot_Events_empty(t *testing.T) {
+	s := NewTracker().Snapshot()
+
+	es, err := s.Events()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 0 {
+		t.Fatalf("expected: 0 events actual: %v events", len(es))
+	}
+}
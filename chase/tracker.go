@@ -0,0 +1,149 @@
+/*
+Package chase tracks the state of MIDI channels such as programs, controllers and pitch bends,
+so that the playback or the rendering can start from the middle of a song.
+*/
+package chase
+
+import (
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/parameter"
+)
+
+// Tracker replays channel events and keeps the state of 16 channels.
+type Tracker struct {
+	tick     uint32
+	decoder  *parameter.Decoder
+	channels [16]Channel
+}
+
+// Apply applies the event at the tick. The events must be applied in chronological order.
+// The events other than channel events are ignored.
+func (t *Tracker) Apply(tick uint32, e event.Event) {
+	t.tick = tick
+
+	switch v := e.(type) {
+	case *event.NoteOnEvent:
+		c := &t.channels[v.Channel()]
+		if v.Velocity() == 0 {
+			c.release(v.Note())
+		} else {
+			c.Notes = append(c.Notes, Note{Note: v.Note(), Velocity: v.Velocity(), Tick: tick})
+		}
+	case *event.NoteOffEvent:
+		t.channels[v.Channel()].release(v.Note())
+	case *event.ProgramChangeEvent:
+		c := &t.channels[v.Channel()]
+		c.Program = v.Program()
+		c.ProgramSet = true
+	case *event.PitchBendEvent:
+		t.channels[v.Channel()].PitchBend = v.Pitch()
+	case *event.ChannelAfterTouchEvent:
+		t.channels[v.Channel()].Pressure = v.Velocity()
+	case *event.ControllerEvent:
+		t.applyController(v)
+	}
+}
+
+func (t *Tracker) applyController(e *event.ControllerEvent) {
+	c := &t.channels[e.Channel()]
+	control := e.Control()
+	value := uint16(e.Value())
+
+	switch control {
+	case constant.RegisteredParameterNumberMSB:
+		c.rpn = value<<7 | c.rpn&0x7f
+		c.Selected = Selection{Registered: true, Number: c.rpn}
+	case constant.RegisteredParameterNumberLSB:
+		c.rpn = c.rpn&0x3f80 | value
+		c.Selected = Selection{Registered: true, Number: c.rpn}
+	case constant.NonRegisteredParameterNumberMSB:
+		c.nrpn = value<<7 | c.nrpn&0x7f
+		c.Selected = Selection{Registered: false, Number: c.nrpn}
+	case constant.NonRegisteredParameterNumberLSB:
+		c.nrpn = c.nrpn&0x3f80 | value
+		c.Selected = Selection{Registered: false, Number: c.nrpn}
+	case constant.DataEntry, constant.DataEntryLSB, constant.DataIncrement, constant.DataDecrement:
+	case constant.ResetAllControllers:
+		t.decoder.Decode(e)
+		c.resetControllers()
+		return
+	default:
+		if control.IsChannelMode() {
+			if control.EndsNotes() {
+				c.Notes = nil
+			}
+			return
+		}
+		if c.Controllers == nil {
+			c.Controllers = map[constant.Control]uint8{}
+		}
+		c.Controllers[control] = uint8(value)
+		return
+	}
+
+	m, ok := t.decoder.Decode(e)
+	if !ok {
+		return
+	}
+
+	switch m.Type {
+	case parameter.RegisteredParameterChange:
+		if c.RegisteredParameters == nil {
+			c.RegisteredParameters = map[constant.RegisteredParameter]uint16{}
+		}
+		c.RegisteredParameters[constant.RegisteredParameter(m.Number)] = m.Value
+	case parameter.NonRegisteredParameterChange:
+		if c.NonRegisteredParameters == nil {
+			c.NonRegisteredParameters = map[uint16]uint16{}
+		}
+		c.NonRegisteredParameters[m.Number] = m.Value
+	}
+}
+
+// Snapshot returns the copy of current state.
+func (t *Tracker) Snapshot() *Snapshot {
+	s := &Snapshot{Tick: t.tick}
+
+	for i := range t.channels {
+		s.Channels[i] = t.channels[i].clone()
+	}
+
+	return s
+}
+
+// Reset resets the state of all channels.
+func (t *Tracker) Reset() {
+	t.tick = 0
+	t.decoder.Reset()
+
+	for i := range t.channels {
+		t.channels[i] = newChannel()
+	}
+}
+
+// NewTracker returns Tracker.
+func NewTracker() *Tracker {
+	t := &Tracker{decoder: parameter.NewDecoder()}
+	t.Reset()
+
+	return t
+}
+
+// At returns the state of channels just before the tick. The events at the tick are not applied
+// because they belong to the excerpt which starts at the tick.
+func At(m *midi.MIDI, tick uint32) *Snapshot {
+	t := NewTracker()
+
+	for _, te := range m.TimedEvents() {
+		if te.Tick >= tick {
+			break
+		}
+		t.Apply(te.Tick, te.Event)
+	}
+
+	t.tick = tick
+
+	return t.Snapshot()
+}
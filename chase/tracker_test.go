@@ -0,0 +1,157 @@
+package chase
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/parameter"
+)
+
+func newTestMIDI(t *testing.T) *midi.MIDI {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0)(event.NewControllerEvent(nil, 0, constant.BankSelect, 0x79))
+	at(0)(event.NewControllerEvent(nil, 0, constant.BankSelectLSB, 0x01))
+	at(0)(event.NewProgramChangeEvent(nil, 0, constant.ElectricPiano1))
+	at(0)(event.NewControllerEvent(nil, 0, constant.MainVolume, 100))
+
+	sensitivity, _ := parameter.NewPitchBendSensitivity(0, 12, 0)
+	es, _ := parameter.Encode(sensitivity)
+	for _, e := range es {
+		at(0)(e, nil)
+	}
+
+	at(240)(event.NewNoteOnEvent(nil, 0, constant.C3, 100))
+	at(240)(event.NewNoteOnEvent(nil, 0, constant.E3, 90))
+	at(360)(event.NewPitchBendEvent(nil, 0, 0x3000))
+	at(480)(event.NewNoteOffEvent(nil, 0, constant.C3, 0))
+	at(480)(event.NewControllerEvent(nil, 0, constant.MainVolume, 80))
+	at(600)(event.NewChannelAfterTouchEvent(nil, 1, 30))
+	at(960)(event.NewNoteOffEvent(nil, 0, constant.E3, 0))
+
+	return &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+}
+
+func TestAt(t *testing.T) {
+	m := newTestMIDI(t)
+
+	s := At(m, 480)
+
+	if s.Tick != 480 {
+		t.Fatalf("expected: 480 actual: %v", s.Tick)
+	}
+
+	c := s.Channels[0]
+
+	if !c.ProgramSet || c.Program != constant.ElectricPiano1 {
+		t.Fatalf("expected: %v actual: %v", constant.ElectricPiano1, c.Program)
+	}
+	if value, ok := c.Controller(constant.MainVolume); !ok || value != 100 {
+		t.Fatalf("expected: 100 actual: %v", value)
+	}
+	if value, ok := c.Controller(constant.DataEntry); ok {
+		t.Fatalf("data entry must not be stored as controller: %v", value)
+	}
+	if value := c.RegisteredParameters[constant.PitchBendSensitivity]; value != 12<<7 {
+		t.Fatalf("expected: %v actual: %v", 12<<7, value)
+	}
+	if c.PitchBend != 0x3000 {
+		t.Fatalf("expected: 0x3000 actual: %#x", c.PitchBend)
+	}
+	if len(c.Notes) != 2 || c.Notes[0].Note != constant.C3 || c.Notes[1].Note != constant.E3 || c.Notes[0].Tick != 240 {
+		t.Fatalf("unexpected notes: %v", c.Notes)
+	}
+
+	s = At(m, 481)
+	c = s.Channels[0]
+
+	if value, _ := c.Controller(constant.MainVolume); value != 80 {
+		t.Fatalf("expected: 80 actual: %v", value)
+	}
+	if len(c.Notes) != 1 || c.Notes[0].Note != constant.E3 {
+		t.Fatalf("unexpected notes: %v", c.Notes)
+	}
+	if s.Channels[1].Pressure != 0 {
+		t.Fatalf("expected: 0 actual: %v", s.Channels[1].Pressure)
+	}
+	if s = At(m, 601); s.Channels[1].Pressure != 30 {
+		t.Fatalf("expected: 30 actual: %v", s.Channels[1].Pressure)
+	}
+}
+
+func TestTracker_Snapshot(t *testing.T) {
+	tracker := NewTracker()
+
+	e, _ := event.NewNoteOnEvent(nil, 3, constant.A4, 64)
+	tracker.Apply(10, e)
+
+	s := tracker.Snapshot()
+
+	e, _ = event.NewNoteOnEvent(nil, 3, constant.A4, 0)
+	tracker.Apply(20, e)
+
+	if len(s.Channels[3].Notes) != 1 {
+		t.Fatalf("snapshot must not be changed by the following events")
+	}
+	if actual := tracker.Snapshot(); len(actual.Channels[3].Notes) != 0 || actual.Tick != 20 {
+		t.Fatalf("unexpected snapshot: %v", actual)
+	}
+
+	tracker.Reset()
+
+	if actual := tracker.Snapshot(); actual.Channels[3].PitchBend != event.PitchBendCenter || !actual.Channels[3].Selected.IsNull() {
+		t.Fatalf("unexpected snapshot: %v", actual)
+	}
+}
+
+func TestTracker_Apply_channelMode(t *testing.T) {
+	tracker := NewTracker()
+	apply := func(e event.Event, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		tracker.Apply(0, e)
+	}
+
+	apply(event.NewNoteOnEvent(nil, 0, constant.C3, 100))
+	apply(event.NewNoteOnEvent(nil, 1, constant.C3, 100))
+	apply(event.NewControllerEvent(nil, 0, constant.MainVolume, 90))
+	apply(event.NewControllerEvent(nil, 0, constant.Modulation, 64))
+	apply(event.NewControllerEvent(nil, 0, constant.RegisteredParameterNumberMSB, 0))
+	apply(event.NewControllerEvent(nil, 0, constant.RegisteredParameterNumberLSB, 0))
+	apply(event.NewPitchBendEvent(nil, 0, 0x3000))
+	apply(event.NewChannelModeEvent(nil, 0, constant.AllNotesOff, 0))
+	apply(event.NewChannelModeEvent(nil, 0, constant.ResetAllControllers, 0))
+
+	s := tracker.Snapshot()
+	c := s.Channels[0]
+
+	if len(c.Notes) != 0 {
+		t.Fatalf("expected: 0 notes actual: %v notes", len(c.Notes))
+	}
+	if len(s.Channels[1].Notes) != 1 {
+		t.Fatalf("expected: 1 note actual: %v notes", len(s.Channels[1].Notes))
+	}
+	if value, ok := c.Controller(constant.MainVolume); !ok || value != 90 {
+		t.Fatalf("expected: 90 actual: %v", value)
+	}
+	if _, ok := c.Controller(constant.Modulation); ok {
+		t.Fatalf("modulation must be reset")
+	}
+	if !c.Selected.IsNull() {
+		t.Fatalf("parameter must be deselected")
+	}
+	if c.PitchBend != event.PitchBendCenter {
+		t.Fatalf("expected: 0x%x actual: 0x%x", event.PitchBendCenter, c.PitchBend)
+	}
+}
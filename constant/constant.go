@@ -13,7 +13,23 @@ const (
 	Meta                   = 0xff
 )
 
+// System common and system real-time messages. They are sent only on the wire and never appear in standard MIDI file.
 const (
+	MTCQuarterFrame     = 0xf1
+	SongPositionPointer = 0xf2
+	SongSelect          = 0xf3
+	TuneRequest         = 0xf6
+	EndOfExclusive      = 0xf7
+	TimingClock         = 0xf8
+	Start               = 0xfa
+	Continue            = 0xfb
+	Stop                = 0xfc
+	ActiveSensing       = 0xfe
+	SystemReset         = 0xff
+)
+
+const (
+	SequenceNumber      = 0x00
 	Text                = 0x01
 	CopyrightNotice     = 0x02
 	SequenceOrTrackName = 0x03
@@ -21,12 +37,22 @@ const (
 	Lyrics              = 0x05
 	Marker              = 0x06
 	CuePoint            = 0x07
-	MIDIPortPrefix      = 0x20
-	MIDIChannelPrefix   = 0x21
+	ProgramName         = 0x08
+	DeviceName          = 0x09
+	MIDIChannelPrefix   = 0x20
+	MIDIPortPrefix      = 0x21
 	SetTempo            = 0x51
 	SMPTEOffset         = 0x54
 	TimeSignature       = 0x58
 	KeySignature        = 0x59
+	XMFPatchTypePrefix  = 0x60
 	SequencerSpecific   = 0x7f
 	EndOfTrack          = 0x2f
 )
+
+// Patch types of XMF patch type prefix meta event.
+const (
+	GeneralMIDI1 = 0x01
+	GeneralMIDI2 = 0x02
+	DLS          = 0x03
+)
@@ -76,6 +76,14 @@ const (
 	NonRegisteredParameterNumberMSB Control = 0x63
 	RegisteredParameterNumberLSB    Control = 0x64
 	RegisteredParameterNumberMSB    Control = 0x65
+	AllSoundOff                     Control = 0x78
+	ResetAllControllers             Control = 0x79
+	LocalControl                    Control = 0x7a
+	AllNotesOff                     Control = 0x7b
+	OmniOff                         Control = 0x7c
+	OmniOn                          Control = 0x7d
+	MonoOn                          Control = 0x7e
+	PolyOn                          Control = 0x7f
 )
 
 func ParseControlName(s string) (Control, error) {
@@ -86,3 +94,55 @@ func ParseControlName(s string) (Control, error) {
 	}
 	return 0, fmt.Errorf("invalid")
 }
+
+// IsMSB reports whether the control is the MSB of 14-bit controller (0 to 31).
+func (c Control) IsMSB() bool {
+	return c < 0x20
+}
+
+// IsLSB reports whether the control is the LSB of 14-bit controller (32 to 63).
+func (c Control) IsLSB() bool {
+	return c >= 0x20 && c < 0x40
+}
+
+// LSB returns the LSB control paired with the MSB control, e.g. MainVolumeLSB for MainVolume.
+// It returns false if the control is not the MSB of 14-bit controller.
+func (c Control) LSB() (Control, bool) {
+	if !c.IsMSB() {
+		return c, false
+	}
+	return c + 0x20, true
+}
+
+// MSB returns the MSB control paired with the LSB control, e.g. MainVolume for MainVolumeLSB.
+// It returns false if the control is not the LSB of 14-bit controller.
+func (c Control) MSB() (Control, bool) {
+	if !c.IsLSB() {
+		return c, false
+	}
+	return c - 0x20, true
+}
+
+// IsChannelMode reports whether the control is channel mode message (120 to 127), e.g. all notes off.
+func (c Control) IsChannelMode() bool {
+	return c >= AllSoundOff && c <= PolyOn
+}
+
+// EndsNotes reports whether the channel mode message turns off all notes of the channel.
+// Omni off, omni on, mono on and poly on turn off all notes as well as all notes off.
+func (c Control) EndsNotes() bool {
+	return c == AllSoundOff || c == AllNotesOff || (c >= OmniOff && c <= PolyOn)
+}
+
+// ResetValue returns the value of control after reset all controllers as recommended practice RP-015 says.
+// It returns false if the control is not reset, e.g. main volume and pan.
+func (c Control) ResetValue() (uint8, bool) {
+	switch c {
+	case Modulation, ModulationLSB, ExpressionLSB, Hold1, PortamentoOnOff, Sostenuto, SoftPedal:
+		return 0, true
+	case Expression, NonRegisteredParameterNumberLSB, NonRegisteredParameterNumberMSB, RegisteredParameterNumberLSB, RegisteredParameterNumberMSB:
+		return 0x7f, true
+	}
+
+	return 0, false
+}
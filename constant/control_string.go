@@ -4,64 +4,164 @@ package constant
 
 import "strconv"
 
-const (
-	_Control_name_0 = "BankSelectModulationBreathController"
-	_Control_name_1 = "FootControllerPortamentoTimeDataEntryMainVolumeBalance"
-	_Control_name_2 = "PanExpressionEffectControl1EffectControl2"
-	_Control_name_3 = "GeneralPurposeController1GeneralPurposeController2GeneralPurposeController3GeneralPurposeController4"
-	_Control_name_4 = "BankSelectLSBModulationLSBBreathControllerLSB"
-	_Control_name_5 = "FootControllerLSBPortamentoTimeLSBDataEntryLSBMainVolumeLSBBalanceLSB"
-	_Control_name_6 = "PanLSBExpressionLSBEffectControl1LSBEffectControl2LSB"
-	_Control_name_7 = "GeneralPurposeController1LSBGeneralPurposeController2LSBGeneralPurposeController3LSBGeneralPurposeController4LSB"
-	_Control_name_8 = "Hold1PortamentoOnOffSostenutoSoftPedalLegatoFootswitchHold2SoundVariationHarmonicIntensityReleaseTimeAttackTimeBrightnessDecayTimeVibratoRateVibratoDepthVibratoDelayUndefinedSoundControllerGeneralPurposeController5GeneralPurposeController6GeneralPurposeController7GeneralPurposeController8PortamentoControl"
-	_Control_name_9 = "ReverbSendLevelTremoloDepthChorusSendLevelCelesteDepthPhaserDepthDataIncrementDataDecrementNonRegisteredParameterNumberLSBNonRegisteredParameterNumberMSBRegisteredParameterNumberLSBRegisteredParameterNumberMSB"
-)
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[BankSelect-0]
+	_ = x[Modulation-1]
+	_ = x[BreathController-2]
+	_ = x[FootController-4]
+	_ = x[PortamentoTime-5]
+	_ = x[DataEntry-6]
+	_ = x[MainVolume-7]
+	_ = x[Balance-8]
+	_ = x[Pan-10]
+	_ = x[Expression-11]
+	_ = x[EffectControl1-12]
+	_ = x[EffectControl2-13]
+	_ = x[GeneralPurposeController1-16]
+	_ = x[GeneralPurposeController2-17]
+	_ = x[GeneralPurposeController3-18]
+	_ = x[GeneralPurposeController4-19]
+	_ = x[BankSelectLSB-32]
+	_ = x[ModulationLSB-33]
+	_ = x[BreathControllerLSB-34]
+	_ = x[FootControllerLSB-36]
+	_ = x[PortamentoTimeLSB-37]
+	_ = x[DataEntryLSB-38]
+	_ = x[MainVolumeLSB-39]
+	_ = x[BalanceLSB-40]
+	_ = x[PanLSB-42]
+	_ = x[ExpressionLSB-43]
+	_ = x[EffectControl1LSB-44]
+	_ = x[EffectControl2LSB-45]
+	_ = x[GeneralPurposeController1LSB-48]
+	_ = x[GeneralPurposeController2LSB-49]
+	_ = x[GeneralPurposeController3LSB-50]
+	_ = x[GeneralPurposeController4LSB-51]
+	_ = x[Hold1-64]
+	_ = x[PortamentoOnOff-65]
+	_ = x[Sostenuto-66]
+	_ = x[SoftPedal-67]
+	_ = x[LegatoFootswitch-68]
+	_ = x[Hold2-69]
+	_ = x[SoundVariation-70]
+	_ = x[HarmonicIntensity-71]
+	_ = x[ReleaseTime-72]
+	_ = x[AttackTime-73]
+	_ = x[Brightness-74]
+	_ = x[DecayTime-75]
+	_ = x[VibratoRate-76]
+	_ = x[VibratoDepth-77]
+	_ = x[VibratoDelay-78]
+	_ = x[UndefinedSoundController-79]
+	_ = x[GeneralPurposeController5-80]
+	_ = x[GeneralPurposeController6-81]
+	_ = x[GeneralPurposeController7-82]
+	_ = x[GeneralPurposeController8-83]
+	_ = x[PortamentoControl-84]
+	_ = x[ReverbSendLevel-91]
+	_ = x[TremoloDepth-92]
+	_ = x[ChorusSendLevel-93]
+	_ = x[CelesteDepth-94]
+	_ = x[PhaserDepth-95]
+	_ = x[DataIncrement-96]
+	_ = x[DataDecrement-97]
+	_ = x[NonRegisteredParameterNumberLSB-98]
+	_ = x[NonRegisteredParameterNumberMSB-99]
+	_ = x[RegisteredParameterNumberLSB-100]
+	_ = x[RegisteredParameterNumberMSB-101]
+	_ = x[AllSoundOff-120]
+	_ = x[ResetAllControllers-121]
+	_ = x[LocalControl-122]
+	_ = x[AllNotesOff-123]
+	_ = x[OmniOff-124]
+	_ = x[OmniOn-125]
+	_ = x[MonoOn-126]
+	_ = x[PolyOn-127]
+}
+
+const _Control_name = "BankSelectModulationBreathControllerFootControllerPortamentoTimeDataEntryMainVolumeBalancePanExpressionEffectControl1EffectControl2GeneralPurposeController1GeneralPurposeController2GeneralPurposeController3GeneralPurposeController4BankSelectLSBModulationLSBBreathControllerLSBFootControllerLSBPortamentoTimeLSBDataEntryLSBMainVolumeLSBBalanceLSBPanLSBExpressionLSBEffectControl1LSBEffectControl2LSBGeneralPurposeController1LSBGeneralPurposeController2LSBGeneralPurposeController3LSBGeneralPurposeController4LSBHold1PortamentoOnOffSostenutoSoftPedalLegatoFootswitchHold2SoundVariationHarmonicIntensityReleaseTimeAttackTimeBrightnessDecayTimeVibratoRateVibratoDepthVibratoDelayUndefinedSoundControllerGeneralPurposeController5GeneralPurposeController6GeneralPurposeController7GeneralPurposeController8PortamentoControlReverbSendLevelTremoloDepthChorusSendLevelCelesteDepthPhaserDepthDataIncrementDataDecrementNonRegisteredParameterNumberLSBNonRegisteredParameterNumberMSBRegisteredParameterNumberLSBRegisteredParameterNumberMSBAllSoundOffResetAllControllersLocalControlAllNotesOffOmniOffOmniOnMonoOnPolyOn"
 
-var (
-	_Control_index_0 = [...]uint8{0, 10, 20, 36}
-	_Control_index_1 = [...]uint8{0, 14, 28, 37, 47, 54}
-	_Control_index_2 = [...]uint8{0, 3, 13, 27, 41}
-	_Control_index_3 = [...]uint8{0, 25, 50, 75, 100}
-	_Control_index_4 = [...]uint8{0, 13, 26, 45}
-	_Control_index_5 = [...]uint8{0, 17, 34, 46, 59, 69}
-	_Control_index_6 = [...]uint8{0, 6, 19, 36, 53}
-	_Control_index_7 = [...]uint8{0, 28, 56, 84, 112}
-	_Control_index_8 = [...]uint16{0, 5, 20, 29, 38, 54, 59, 73, 90, 101, 111, 121, 130, 141, 153, 165, 189, 214, 239, 264, 289, 306}
-	_Control_index_9 = [...]uint8{0, 15, 27, 42, 54, 65, 78, 91, 122, 153, 181, 209}
-)
+var _Control_map = map[Control]string{
+	0:   _Control_name[0:10],
+	1:   _Control_name[10:20],
+	2:   _Control_name[20:36],
+	4:   _Control_name[36:50],
+	5:   _Control_name[50:64],
+	6:   _Control_name[64:73],
+	7:   _Control_name[73:83],
+	8:   _Control_name[83:90],
+	10:  _Control_name[90:93],
+	11:  _Control_name[93:103],
+	12:  _Control_name[103:117],
+	13:  _Control_name[117:131],
+	16:  _Control_name[131:156],
+	17:  _Control_name[156:181],
+	18:  _Control_name[181:206],
+	19:  _Control_name[206:231],
+	32:  _Control_name[231:244],
+	33:  _Control_name[244:257],
+	34:  _Control_name[257:276],
+	36:  _Control_name[276:293],
+	37:  _Control_name[293:310],
+	38:  _Control_name[310:322],
+	39:  _Control_name[322:335],
+	40:  _Control_name[335:345],
+	42:  _Control_name[345:351],
+	43:  _Control_name[351:364],
+	44:  _Control_name[364:381],
+	45:  _Control_name[381:398],
+	48:  _Control_name[398:426],
+	49:  _Control_name[426:454],
+	50:  _Control_name[454:482],
+	51:  _Control_name[482:510],
+	64:  _Control_name[510:515],
+	65:  _Control_name[515:530],
+	66:  _Control_name[530:539],
+	67:  _Control_name[539:548],
+	68:  _Control_name[548:564],
+	69:  _Control_name[564:569],
+	70:  _Control_name[569:583],
+	71:  _Control_name[583:600],
+	72:  _Control_name[600:611],
+	73:  _Control_name[611:621],
+	74:  _Control_name[621:631],
+	75:  _Control_name[631:640],
+	76:  _Control_name[640:651],
+	77:  _Control_name[651:663],
+	78:  _Control_name[663:675],
+	79:  _Control_name[675:699],
+	80:  _Control_name[699:724],
+	81:  _Control_name[724:749],
+	82:  _Control_name[749:774],
+	83:  _Control_name[774:799],
+	84:  _Control_name[799:816],
+	91:  _Control_name[816:831],
+	92:  _Control_name[831:843],
+	93:  _Control_name[843:858],
+	94:  _Control_name[858:870],
+	95:  _Control_name[870:881],
+	96:  _Control_name[881:894],
+	97:  _Control_name[894:907],
+	98:  _Control_name[907:938],
+	99:  _Control_name[938:969],
+	100: _Control_name[969:997],
+	101: _Control_name[997:1025],
+	120: _Control_name[1025:1036],
+	121: _Control_name[1036:1055],
+	122: _Control_name[1055:1067],
+	123: _Control_name[1067:1078],
+	124: _Control_name[1078:1085],
+	125: _Control_name[1085:1091],
+	126: _Control_name[1091:1097],
+	127: _Control_name[1097:1103],
+}
 
 func (i Control) String() string {
-	switch {
-	case 0 <= i && i <= 2:
-		return _Control_name_0[_Control_index_0[i]:_Control_index_0[i+1]]
-	case 4 <= i && i <= 8:
-		i -= 4
-		return _Control_name_1[_Control_index_1[i]:_Control_index_1[i+1]]
-	case 10 <= i && i <= 13:
-		i -= 10
-		return _Control_name_2[_Control_index_2[i]:_Control_index_2[i+1]]
-	case 16 <= i && i <= 19:
-		i -= 16
-		return _Control_name_3[_Control_index_3[i]:_Control_index_3[i+1]]
-	case 32 <= i && i <= 34:
-		i -= 32
-		return _Control_name_4[_Control_index_4[i]:_Control_index_4[i+1]]
-	case 36 <= i && i <= 40:
-		i -= 36
-		return _Control_name_5[_Control_index_5[i]:_Control_index_5[i+1]]
-	case 42 <= i && i <= 45:
-		i -= 42
-		return _Control_name_6[_Control_index_6[i]:_Control_index_6[i+1]]
-	case 48 <= i && i <= 51:
-		i -= 48
-		return _Control_name_7[_Control_index_7[i]:_Control_index_7[i+1]]
-	case 64 <= i && i <= 84:
-		i -= 64
-		return _Control_name_8[_Control_index_8[i]:_Control_index_8[i+1]]
-	case 91 <= i && i <= 101:
-		i -= 91
-		return _Control_name_9[_Control_index_9[i]:_Control_index_9[i+1]]
-	default:
-		return "Control(" + strconv.FormatInt(int64(i), 10) + ")"
+	if str, ok := _Control_map[i]; ok {
+		return str
 	}
+	return "Control(" + strconv.FormatInt(int64(i), 10) + ")"
 }
@@ -0,0 +1,54 @@
+package constant
+
+import "testing"
+
+func TestControl_LSB(t *testing.T) {
+	lsb, ok := MainVolume.LSB()
+	if !ok {
+		t.Fatalf("MainVolume must be paired with LSB")
+	}
+	if lsb != MainVolumeLSB {
+		t.Fatalf("expected: %v actual: %v", MainVolumeLSB, lsb)
+	}
+
+	_, ok = Hold1.LSB()
+	if ok {
+		t.Fatalf("Hold1 must not be paired with LSB")
+	}
+}
+
+func TestControl_MSB(t *testing.T) {
+	msb, ok := DataEntryLSB.MSB()
+	if !ok {
+		t.Fatalf("DataEntryLSB must be paired with MSB")
+	}
+	if msb != DataEntry {
+		t.Fatalf("expected: %v actual: %v", DataEntry, msb)
+	}
+
+	_, ok = DataEntry.MSB()
+	if ok {
+		t.Fatalf("DataEntry must not be paired with MSB")
+	}
+}
+
+func TestControl_IsChannelMode(t *testing.T) {
+	if PhaserDepth.IsChannelMode() || !AllSoundOff.IsChannelMode() || !PolyOn.IsChannelMode() {
+		t.Fatalf("only 120 to 127 must be channel mode messages")
+	}
+	if LocalControl.EndsNotes() || ResetAllControllers.EndsNotes() || !AllNotesOff.EndsNotes() || !OmniOn.EndsNotes() {
+		t.Fatalf("only all sound off, all notes off and the mode changes must end notes")
+	}
+	if AllNotesOff.String() != "AllNotesOff" {
+		t.Fatalf("expected: AllNotesOff actual: %v", AllNotesOff)
+	}
+}
+
+func TestControl_ResetValue(t *testing.T) {
+	if value, ok := Expression.ResetValue(); !ok || value != 0x7f {
+		t.Fatalf("expected: 127 actual: %v", value)
+	}
+	if _, ok := MainVolume.ResetValue(); ok {
+		t.Fatalf("MainVolume must not be reset")
+	}
+}
@@ -0,0 +1,45 @@
+package constant
+
+// FrameRate represents SMPTE frame rate. The value is the 2 bits code stored in the hour byte of SMPTE offset event
+// and MIDI time code.
+type FrameRate uint8
+
+const (
+	FPS24     FrameRate = 0x00
+	FPS25     FrameRate = 0x01
+	FPS30Drop FrameRate = 0x02
+	FPS30     FrameRate = 0x03
+)
+
+// FramesPerSecond returns the nominal number of frames per second, i.e. 30 for 29.97 drop frame.
+func (r FrameRate) FramesPerSecond() int {
+	switch r {
+	case FPS24:
+		return 24
+	case FPS25:
+		return 25
+	}
+
+	return 30
+}
+
+// DropFrame reports whether the frame rate is 29.97 drop frame.
+func (r FrameRate) DropFrame() bool {
+	return r == FPS30Drop
+}
+
+// String returns string representation of frame rate.
+func (r FrameRate) String() string {
+	switch r {
+	case FPS24:
+		return "24fps"
+	case FPS25:
+		return "25fps"
+	case FPS30Drop:
+		return "29.97fps drop frame"
+	case FPS30:
+		return "30fps"
+	}
+
+	return "unknown frame rate"
+}
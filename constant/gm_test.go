@@ -0,0 +1,26 @@
+package constant
+
+import "testing"
+
+func TestGM_Name(t *testing.T) {
+	expected := "Acoustic Guitar (nylon)"
+	actual := AcousticNylonGuitar.Name()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+
+	expected = "Gunshot"
+	actual = Gunshot.Name()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+
+	expected = "GM(128)"
+	actual = GM(128).Name()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
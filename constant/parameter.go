@@ -0,0 +1,16 @@
+//go:generate stringer -type=RegisteredParameter -output=registeredparameter_string.go
+
+package constant
+
+// RegisteredParameter represents registered parameter number (RPN).
+type RegisteredParameter uint16
+
+const (
+	PitchBendSensitivity RegisteredParameter = 0x0000
+	FineTuning           RegisteredParameter = 0x0001
+	CoarseTuning         RegisteredParameter = 0x0002
+	TuningProgramChange  RegisteredParameter = 0x0003
+	TuningBankSelect     RegisteredParameter = 0x0004
+	ModulationDepthRange RegisteredParameter = 0x0005
+	NullParameter        RegisteredParameter = 0x3fff
+)
@@ -0,0 +1,206 @@
+//go:generate stringer -type=Percussion -output=percussion_string.go
+
+package constant
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Percussion represents a key of the General MIDI percussion map.
+// The keys 35 to 81 are defined by GM, the keys 27 to 34 and 82 to 87 are added by GM2.
+type Percussion uint8
+
+const (
+	HighQ Percussion = iota + 27
+	Slap
+	ScratchPush
+	ScratchPull
+	Sticks
+	SquareClick
+	MetronomeClick
+	MetronomeBell
+	AcousticBassDrum
+	BassDrum1
+	SideStick
+	AcousticSnare
+	HandClap
+	ElectricSnare
+	LowFloorTom
+	ClosedHiHat
+	HighFloorTom
+	PedalHiHat
+	LowTom
+	OpenHiHat
+	LowMidTom
+	HiMidTom
+	CrashCymbal1
+	HighTom
+	RideCymbal1
+	ChineseCymbal
+	RideBell
+	Tambourine
+	SplashCymbal
+	Cowbell
+	CrashCymbal2
+	Vibraslap
+	RideCymbal2
+	HiBongo
+	LowBongo
+	MuteHiConga
+	OpenHiConga
+	LowConga
+	HighTimbale
+	LowTimbale
+	HighAgogo
+	LowAgogo
+	Cabasa
+	Maracas
+	ShortWhistle
+	LongWhistle
+	ShortGuiro
+	LongGuiro
+	Claves
+	HiWoodBlock
+	LowWoodBlock
+	MuteCuica
+	OpenCuica
+	MuteTriangle
+	OpenTriangle
+	Shaker
+	JingleBell
+	Belltree
+	Castanets
+	MuteSurdo
+	OpenSurdo
+)
+
+// PercussionChannel is the channel reserved for percussion by General MIDI.
+// It is displayed as channel 10 by most of sequencers.
+const PercussionChannel uint8 = 9
+
+var percussionNames = []string{
+	"High Q",
+	"Slap",
+	"Scratch Push",
+	"Scratch Pull",
+	"Sticks",
+	"Square Click",
+	"Metronome Click",
+	"Metronome Bell",
+	"Acoustic Bass Drum",
+	"Bass Drum 1",
+	"Side Stick",
+	"Acoustic Snare",
+	"Hand Clap",
+	"Electric Snare",
+	"Low Floor Tom",
+	"Closed Hi-Hat",
+	"High Floor Tom",
+	"Pedal Hi-Hat",
+	"Low Tom",
+	"Open Hi-Hat",
+	"Low-Mid Tom",
+	"Hi-Mid Tom",
+	"Crash Cymbal 1",
+	"High Tom",
+	"Ride Cymbal 1",
+	"Chinese Cymbal",
+	"Ride Bell",
+	"Tambourine",
+	"Splash Cymbal",
+	"Cowbell",
+	"Crash Cymbal 2",
+	"Vibraslap",
+	"Ride Cymbal 2",
+	"Hi Bongo",
+	"Low Bongo",
+	"Mute Hi Conga",
+	"Open Hi Conga",
+	"Low Conga",
+	"High Timbale",
+	"Low Timbale",
+	"High Agogo",
+	"Low Agogo",
+	"Cabasa",
+	"Maracas",
+	"Short Whistle",
+	"Long Whistle",
+	"Short Guiro",
+	"Long Guiro",
+	"Claves",
+	"Hi Wood Block",
+	"Low Wood Block",
+	"Mute Cuica",
+	"Open Cuica",
+	"Mute Triangle",
+	"Open Triangle",
+	"Shaker",
+	"Jingle Bell",
+	"Belltree",
+	"Castanets",
+	"Mute Surdo",
+	"Open Surdo",
+}
+
+// Valid reports whether the percussion is defined in the GM2 percussion map.
+func (p Percussion) Valid() bool {
+	return p >= HighQ && p <= OpenSurdo
+}
+
+// Name returns the name of percussion as written in the GM specification, e.g. "Bass Drum 1".
+func (p Percussion) Name() string {
+	if !p.Valid() {
+		return p.String()
+	}
+	return percussionNames[p-HighQ]
+}
+
+// Note returns the note which triggers the percussion.
+func (p Percussion) Note() Note {
+	return Note(p)
+}
+
+// PercussionFromNote returns the percussion triggered by the note.
+// The second return value is false when the note is not assigned in the percussion map.
+func PercussionFromNote(note Note) (Percussion, bool) {
+	p := Percussion(note)
+
+	return p, p.Valid()
+}
+
+// IsPercussionChannel reports whether the channel is reserved for percussion.
+func IsPercussionChannel(channel uint8) bool {
+	return channel == PercussionChannel
+}
+
+// ParsePercussion parses percussion name. It accepts a note number such as "36",
+// a name such as "Bass Drum 1" or an identifier such as "BassDrum1". The comparison is case insensitive.
+func ParsePercussion(s string) (Percussion, error) {
+	if i, err := strconv.Atoi(s); err == nil {
+		p := Percussion(i)
+		if i < 0 || !p.Valid() {
+			return 0, fmt.Errorf("midi: %v is not assigned in the percussion map", i)
+		}
+		return p, nil
+	}
+
+	key := normalizePercussionName(s)
+
+	for p := HighQ; p <= OpenSurdo; p++ {
+		if key == normalizePercussionName(p.Name()) {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("midi: unknown percussion %q", s)
+}
+
+func normalizePercussionName(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Replace(s, " ", "", -1)
+	s = strings.Replace(s, "-", "", -1)
+
+	return s
+}
@@ -0,0 +1,69 @@
+package constant
+
+import "testing"
+
+func TestPercussion_Name(t *testing.T) {
+	expected := "Bass Drum 1"
+	actual := BassDrum1.Name()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+
+	expected = "Percussion(20)"
+	actual = Percussion(20).Name()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestPercussionFromNote(t *testing.T) {
+	p, ok := PercussionFromNote(C1)
+	if !ok {
+		t.Fatalf("C1 must be assigned in the percussion map")
+	}
+	if p != BassDrum1 {
+		t.Fatalf("expected: %v actual: %v", BassDrum1, p)
+	}
+
+	_, ok = PercussionFromNote(C6)
+	if ok {
+		t.Fatalf("C6 must not be assigned in the percussion map")
+	}
+}
+
+func TestIsPercussionChannel(t *testing.T) {
+	if !IsPercussionChannel(9) {
+		t.Fatalf("channel 9 must be percussion channel")
+	}
+	if IsPercussionChannel(0) {
+		t.Fatalf("channel 0 must not be percussion channel")
+	}
+}
+
+func TestParsePercussion(t *testing.T) {
+	for _, s := range []string{"Bass Drum 1", "bassdrum1", "BassDrum1", "36"} {
+		actual, err := ParsePercussion(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual != BassDrum1 {
+			t.Fatalf("expected: %v actual: %v", BassDrum1, actual)
+		}
+	}
+
+	actual, err := ParsePercussion("closed hi-hat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != ClosedHiHat {
+		t.Fatalf("expected: %v actual: %v", ClosedHiHat, actual)
+	}
+
+	for _, s := range []string{"Cowbell 2", "20", "-1"} {
+		if _, err := ParsePercussion(s); err == nil {
+			t.Fatalf("err must not be nil (%v)", s)
+		}
+	}
+}
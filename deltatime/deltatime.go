@@ -3,28 +3,50 @@ package deltatime
 import "github.com/moutend/go-midi/quantity"
 
 // DeltaTime represents delta time .
+//
+// DeltaTime is a value type. The events hold their own copy of delta time, so that setting delta time of
+// an event never changes the others.
 type DeltaTime struct {
-	value *quantity.Quantity
+	value quantity.Quantity
 }
 
 // Quantity returns variable length quantity of delta time.
 func (d *DeltaTime) Quantity() *quantity.Quantity {
-	if d.value == nil {
-		d.value = &quantity.Quantity{}
-	}
+	return &d.value
+}
+
+// SetUint32 sets delta time in ticks.
+func (d *DeltaTime) SetUint32(value uint32) error {
+	return d.value.SetUint32(value)
+}
 
-	return d.value
+// Uint32 returns delta time in ticks.
+func (d *DeltaTime) Uint32() uint32 {
+	return d.value.Uint32()
 }
 
-// Parse parses data.
+// Parse parses data. The delta time is encoded in the minimal form.
 func Parse(data []byte) (*DeltaTime, error) {
-	q, err := quantity.Parse(data)
+	d, err := ParsePreserved(data)
+	if err != nil {
+		return nil, err
+	}
+
+	d.value.Normalize()
+
+	return d, nil
+}
+
+// ParsePreserved parses data. The encoding is kept if it's not minimal, so that the length of quantity is
+// the number of bytes read.
+func ParsePreserved(data []byte) (*DeltaTime, error) {
+	q, err := quantity.ParsePreserved(data)
 	if err != nil {
 		return nil, err
 	}
 
 	deltaTime := &DeltaTime{
-		value: q,
+		value: *q,
 	}
 	return deltaTime, nil
 }
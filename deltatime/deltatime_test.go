@@ -82,7 +82,7 @@ func TestParse(t *testing.T) {
 		},
 	}
 	for _, stream := range validStreams {
-		deltaTime, err := Parse(stream.value)
+		deltaTime, err := ParsePreserved(stream.value)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -97,4 +97,12 @@ func TestParse(t *testing.T) {
 			}
 		}
 	}
+
+	deltaTime, err := Parse([]byte{0x80, 0x80, 0x00})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deltaTime.Quantity().Minimal() {
+		t.Fatalf("non-minimal encoding must be normalized by default")
+	}
 }
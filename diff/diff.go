@@ -0,0 +1,332 @@
+//go:generate stringer -type=Kind -output=kind_string.go
+
+/*
+Package diff compares two MIDI data event by event and reports inserted, removed and modified events.
+*/
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Kind represents kind of change.
+type Kind uint8
+
+const (
+	Inserted Kind = iota
+	Removed
+	Modified
+)
+
+// Differ compares MIDI data.
+type Differ struct {
+	ignoreRunningStatus   bool
+	ignoreTrackOrder      bool
+	ignoreNoteOffEncoding bool
+}
+
+// SetIgnoreRunningStatus sets whether the difference of running status is ignored. The default is true.
+func (d *Differ) SetIgnoreRunningStatus(ignore bool) *Differ {
+	d.ignoreRunningStatus = ignore
+
+	return d
+}
+
+// SetIgnoreTrackOrder sets whether the tracks are matched by their content instead of their position. The default is false.
+func (d *Differ) SetIgnoreTrackOrder(ignore bool) *Differ {
+	d.ignoreTrackOrder = ignore
+
+	return d
+}
+
+// SetIgnoreNoteOffEncoding sets whether a note on event with velocity 0 is treated as same as a note off event.
+// The velocity of note off events is ignored as well. The default is false.
+func (d *Differ) SetIgnoreNoteOffEncoding(ignore bool) *Differ {
+	d.ignoreNoteOffEncoding = ignore
+
+	return d
+}
+
+// Diff compares MIDI data a (old) with b (new) and returns the report.
+// The events are aligned by track and absolute tick. An event which moved to another tick is reported
+// as removed and inserted.
+func (d *Differ) Diff(a, b *midi.MIDI) *Report {
+	r := &Report{
+		Changes: []Change{},
+		Header:  []Field{},
+	}
+
+	if a.FormatType() != b.FormatType() {
+		r.Header = append(r.Header, Field{Name: "formatType", Old: fmt.Sprint(a.FormatType()), New: fmt.Sprint(b.FormatType())})
+	}
+	if !bytes.Equal(a.TimeDivision().Serialize(), b.TimeDivision().Serialize()) {
+		r.Header = append(r.Header, Field{Name: "timeDivision", Old: a.TimeDivision().String(), New: b.TimeDivision().String()})
+	}
+
+	for _, pair := range d.pairTracks(a, b) {
+		var before, after []midi.TimedEvent
+
+		if pair.old >= 0 {
+			before = a.Tracks[pair.old].TimedEvents()
+		}
+		if pair.new >= 0 {
+			after = b.Tracks[pair.new].TimedEvents()
+		}
+
+		track := pair.old
+		if track < 0 {
+			track = pair.new
+		}
+
+		for _, c := range d.diffTrack(before, after) {
+			c.Track = track
+
+			m := a
+			if c.Kind == Inserted {
+				m = b
+			}
+			if p, err := m.Position(c.Tick); err == nil {
+				c.Position = &p
+			}
+
+			r.Changes = append(r.Changes, c)
+		}
+	}
+
+	return r
+}
+
+type trackPair struct {
+	old int
+	new int
+}
+
+// pairTracks returns the pairs of track index. The index is -1 if the track exists only in the other.
+func (d *Differ) pairTracks(a, b *midi.MIDI) []trackPair {
+	pairs := []trackPair{}
+
+	if !d.ignoreTrackOrder {
+		for i := 0; i < len(a.Tracks) || i < len(b.Tracks); i++ {
+			pair := trackPair{old: i, new: i}
+			if i >= len(a.Tracks) {
+				pair.old = -1
+			}
+			if i >= len(b.Tracks) {
+				pair.new = -1
+			}
+			pairs = append(pairs, pair)
+		}
+		return pairs
+	}
+
+	used := map[int]bool{}
+
+	for i, ta := range a.Tracks {
+		best, bestScore := -1, -1
+		name := trackName(ta)
+
+		for j, tb := range b.Tracks {
+			if used[j] {
+				continue
+			}
+
+			score := d.similarity(ta, tb)
+			if name != "" && name == trackName(tb) {
+				score += len(ta.Events) + len(tb.Events)
+			}
+			if score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+		}
+
+		pairs = append(pairs, trackPair{old: i, new: best})
+	}
+	for j := range b.Tracks {
+		if !used[j] {
+			pairs = append(pairs, trackPair{old: -1, new: j})
+		}
+	}
+
+	return pairs
+}
+
+func trackName(t *midi.Track) string {
+	for _, e := range t.Events {
+		if v, ok := e.(*event.SequenceOrTrackNameEvent); ok {
+			return string(v.Text())
+		}
+	}
+
+	return ""
+}
+
+// similarity returns the number of events which are equal in both tracks.
+func (d *Differ) similarity(a, b *midi.Track) int {
+	type key struct {
+		tick  uint32
+		bytes string
+	}
+
+	counts := map[key]int{}
+
+	for _, te := range a.TimedEvents() {
+		counts[key{te.Tick, string(d.encode(te.Event))}]++
+	}
+
+	score := 0
+
+	for _, te := range b.TimedEvents() {
+		k := key{te.Tick, string(d.encode(te.Event))}
+		if counts[k] > 0 {
+			counts[k]--
+			score++
+		}
+	}
+
+	return score
+}
+
+// encode returns the bytes which identify the event for comparison.
+func (d *Differ) encode(e event.Event) []byte {
+	bs := e.Serialize()
+
+	if d.ignoreNoteOffEncoding {
+		if _, ok := e.(*event.NoteOnEvent); ok && bs[2] == 0 {
+			bs = []byte{constant.NoteOff | bs[0]&0x0f, bs[1], 0}
+		}
+		if _, ok := e.(*event.NoteOffEvent); ok {
+			bs = []byte{bs[0], bs[1], 0}
+		}
+	}
+	if !d.ignoreRunningStatus && e.RunningStatus() {
+		bs = append([]byte{0x00}, bs...)
+	}
+
+	return bs
+}
+
+// slot returns the key which identifies what the event controls, e.g. the note of channel.
+// The events at the same tick with the same slot are reported as modified instead of removed and inserted.
+func (d *Differ) slot(e event.Event) string {
+	name := reflect.TypeOf(e).String()
+
+	switch v := e.(type) {
+	case *event.NoteOnEvent:
+		if d.ignoreNoteOffEncoding && v.Velocity() == 0 {
+			name = reflect.TypeOf(&event.NoteOffEvent{}).String()
+		}
+		return fmt.Sprintf("%v/%v/%v", name, v.Channel(), v.Note())
+	case *event.NoteOffEvent:
+		return fmt.Sprintf("%v/%v/%v", name, v.Channel(), v.Note())
+	case *event.NoteAfterTouchEvent:
+		return fmt.Sprintf("%v/%v/%v", name, v.Channel(), v.Note())
+	case *event.ControllerEvent:
+		return fmt.Sprintf("%v/%v/%v", name, v.Channel(), v.Control())
+	case *event.AlienEvent:
+		return fmt.Sprintf("%v/%v", name, v.MetaEventType())
+	case event.ChannelEvent:
+		return fmt.Sprintf("%v/%v", name, v.Channel())
+	}
+
+	return name
+}
+
+func (d *Differ) diffTrack(before, after []midi.TimedEvent) []Change {
+	changes := []Change{}
+	i, j := 0, 0
+
+	for i < len(before) || j < len(after) {
+		var tick uint32
+
+		switch {
+		case i >= len(before):
+			tick = after[j].Tick
+		case j >= len(after):
+			tick = before[i].Tick
+		case before[i].Tick < after[j].Tick:
+			tick = before[i].Tick
+		default:
+			tick = after[j].Tick
+		}
+
+		olds := []event.Event{}
+		for ; i < len(before) && before[i].Tick == tick; i++ {
+			olds = append(olds, before[i].Event)
+		}
+
+		news := []event.Event{}
+		for ; j < len(after) && after[j].Tick == tick; j++ {
+			news = append(news, after[j].Event)
+		}
+
+		changes = append(changes, d.diffTick(tick, olds, news)...)
+	}
+
+	return changes
+}
+
+// diffTick compares the events at the same tick. The equal events are matched first regardless of their order,
+// then the remaining events with the same slot are reported as modified.
+func (d *Differ) diffTick(tick uint32, olds, news []event.Event) []Change {
+	matched := make([]bool, len(news))
+	remaining := []event.Event{}
+
+	for _, o := range olds {
+		found := false
+		for k, n := range news {
+			if !matched[k] && bytes.Equal(d.encode(o), d.encode(n)) {
+				matched[k] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, o)
+		}
+	}
+
+	changes := []Change{}
+
+	for _, o := range remaining {
+		found := false
+		for k, n := range news {
+			if !matched[k] && d.slot(o) == d.slot(n) {
+				matched[k] = true
+				found = true
+				changes = append(changes, Change{Kind: Modified, Tick: tick, Old: o, New: n, Fields: fields(o, n)})
+				break
+			}
+		}
+		if !found {
+			changes = append(changes, Change{Kind: Removed, Tick: tick, Old: o})
+		}
+	}
+	for k, n := range news {
+		if !matched[k] {
+			changes = append(changes, Change{Kind: Inserted, Tick: tick, New: n})
+		}
+	}
+
+	return changes
+}
+
+// NewDiffer returns Differ.
+func NewDiffer() *Differ {
+	return &Differ{
+		ignoreRunningStatus: true,
+	}
+}
+
+// Diff compares MIDI data a (old) with b (new) with the default Differ.
+func Diff(a, b *midi.MIDI) *Report {
+	return NewDiffer().Diff(a, b)
+}
@@ -0,0 +1,143 @@
+package diff
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestMIDI(t *testing.T, tracks ...[]midi.TimedEvent) *midi.MIDI {
+	m := &midi.MIDI{}
+	m.SetFormatType(1)
+	m.TimeDivision().SetBPM(480)
+
+	for _, tes := range tracks {
+		eot, _ := event.NewEndOfTrackEvent(nil)
+		m.Tracks = append(m.Tracks, midi.NewTrackFromTimedEvents(append(tes, midi.TimedEvent{Tick: 9600, Event: eot})))
+	}
+
+	return m
+}
+
+func at(t *testing.T, tick uint32) func(event.Event, error) midi.TimedEvent {
+	return func(e event.Event, err error) midi.TimedEvent {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return midi.TimedEvent{Tick: tick, Event: e}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := newTestMIDI(t, []midi.TimedEvent{
+		at(t, 0)(event.NewNoteOnEvent(nil, 0, constant.C3, 100)),
+		at(t, 7680)(event.NewNoteOnEvent(nil, 0, constant.E4, 100)),
+		at(t, 7680)(event.NewNoteOnEvent(nil, 0, constant.G4, 100)),
+		at(t, 8000)(event.NewNoteOffEvent(nil, 0, constant.E4, 0)),
+	})
+	b := newTestMIDI(t, []midi.TimedEvent{
+		at(t, 0)(event.NewNoteOnEvent(nil, 0, constant.C3, 100)),
+		at(t, 7680)(event.NewNoteOnEvent(nil, 0, constant.G4, 100)),
+		at(t, 7680)(event.NewNoteOnEvent(nil, 0, constant.E4, 110)),
+		at(t, 8000)(event.NewNoteOnEvent(nil, 0, constant.E4, 0)),
+		at(t, 8000)(event.NewControllerEvent(nil, 0, constant.Hold1, 127)),
+	})
+
+	r := Diff(a, b)
+
+	if r.Equal() {
+		t.Fatalf("report must not be equal")
+	}
+
+	expected := []struct {
+		kind Kind
+		tick uint32
+	}{
+		{Modified, 7680},
+		{Removed, 8000},
+		{Inserted, 8000},
+		{Inserted, 8000},
+	}
+
+	if len(expected) != len(r.Changes) {
+		t.Fatalf("expected: %v changes actual: %v changes\n%v", len(expected), len(r.Changes), r)
+	}
+	for i, c := range r.Changes {
+		if expected[i].kind != c.Kind || expected[i].tick != c.Tick {
+			t.Fatalf("expected[%v] = %v at %v actual[%v] = %v at %v", i, expected[i].kind, expected[i].tick, i, c.Kind, c.Tick)
+		}
+	}
+
+	fields := r.Changes[0].Fields
+	if len(fields) != 1 || fields[0] != (Field{Name: "velocity", Old: "100", New: "110"}) {
+		t.Fatalf("unexpected fields: %v", fields)
+	}
+	if r.Changes[0].Position == nil || r.Changes[0].Position.Bar != 5 {
+		t.Fatalf("expected: bar 5 actual: %v", r.Changes[0].Position)
+	}
+
+	r = NewDiffer().SetIgnoreNoteOffEncoding(true).Diff(a, b)
+
+	if len(r.Changes) != 2 {
+		t.Fatalf("expected: 2 changes actual: %v changes\n%v", len(r.Changes), r)
+	}
+	if !Diff(a, a).Equal() {
+		t.Fatalf("report must be equal")
+	}
+}
+
+func TestDiffer_SetIgnoreTrackOrder(t *testing.T) {
+	name1, _ := event.NewSequenceOrTrackNameEvent(nil, []byte("Piano"))
+	name2, _ := event.NewSequenceOrTrackNameEvent(nil, []byte("Bass"))
+
+	piano := []midi.TimedEvent{{Tick: 0, Event: name1}, at(t, 0)(event.NewNoteOnEvent(nil, 0, constant.C3, 100))}
+	bass := []midi.TimedEvent{{Tick: 0, Event: name2}, at(t, 0)(event.NewNoteOnEvent(nil, 1, constant.C1, 100))}
+
+	a := newTestMIDI(t, piano, bass)
+	b := newTestMIDI(t, bass, piano)
+
+	if Diff(a, b).Equal() {
+		t.Fatalf("report must not be equal")
+	}
+	if r := NewDiffer().SetIgnoreTrackOrder(true).Diff(a, b); !r.Equal() {
+		t.Fatalf("report must be equal\n%v", r)
+	}
+
+	b = newTestMIDI(t, bass, piano, nil)
+
+	r := NewDiffer().SetIgnoreTrackOrder(true).Diff(a, b)
+	if len(r.Changes) != 1 || r.Changes[0].Kind != Inserted || r.Changes[0].Track != 2 {
+		t.Fatalf("unexpected report\n%v", r)
+	}
+}
+
+func TestDiffer_SetIgnoreRunningStatus(t *testing.T) {
+	e1, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 100)
+	e2, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 100)
+	e2.SetRunningStatus(true)
+
+	a := newTestMIDI(t, []midi.TimedEvent{{Tick: 0, Event: e1}})
+	b := newTestMIDI(t, []midi.TimedEvent{{Tick: 0, Event: e2}})
+
+	if !Diff(a, b).Equal() {
+		t.Fatalf("report must be equal")
+	}
+
+	r := NewDiffer().SetIgnoreRunningStatus(false).Diff(a, b)
+	if len(r.Changes) != 1 || r.Changes[0].Fields[0].Name != "runningStatus" {
+		t.Fatalf("unexpected report\n%v", r)
+	}
+}
+
+func TestDiff_header(t *testing.T) {
+	a := newTestMIDI(t)
+	b := newTestMIDI(t)
+	b.TimeDivision().SetBPM(960)
+
+	r := Diff(a, b)
+	if len(r.Header) != 1 || r.Header[0].Name != "timeDivision" {
+		t.Fatalf("unexpected report\n%v", r)
+	}
+}
@@ -0,0 +1,188 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/event"
+)
+
+// Field represents a changed field of event or header.
+type Field struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Change represents a change of event.
+type Change struct {
+	Kind Kind
+
+	// Track is the index of track in the old MIDI data, or in the new one if the track exists only in the new one.
+	Track int
+	Tick  uint32
+
+	// Position is the musical position of tick. It's nil if the time division is not based on quarter note.
+	Position *midi.Position
+
+	// Old is nil for Inserted and New is nil for Removed.
+	Old event.Event
+	New event.Event
+
+	// Fields holds the changed fields for Modified.
+	Fields []Field
+}
+
+// String returns string representation of change, e.g. "~ track 1 at 5:1:0 (tick 7680): &NoteOnEvent{...} velocity: 100 -> 110".
+func (c Change) String() string {
+	at := fmt.Sprintf("track %v at tick %v", c.Track, c.Tick)
+	if c.Position != nil {
+		at = fmt.Sprintf("track %v at %v (tick %v)", c.Track, c.Position, c.Tick)
+	}
+
+	switch c.Kind {
+	case Inserted:
+		return fmt.Sprintf("+ %v: %v", at, c.New)
+	case Removed:
+		return fmt.Sprintf("- %v: %v", at, c.Old)
+	}
+
+	fs := make([]string, len(c.Fields))
+	for i, f := range c.Fields {
+		fs[i] = fmt.Sprintf("%v: %v -> %v", f.Name, f.Old, f.New)
+	}
+
+	return fmt.Sprintf("~ %v: %v %v", at, c.Old, strings.Join(fs, ", "))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Change) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Kind     string  `json:"kind"`
+		Track    int     `json:"track"`
+		Tick     uint32  `json:"tick"`
+		Position string  `json:"position,omitempty"`
+		Old      string  `json:"old,omitempty"`
+		New      string  `json:"new,omitempty"`
+		Fields   []Field `json:"fields,omitempty"`
+	}{
+		Kind:   strings.ToLower(c.Kind.String()),
+		Track:  c.Track,
+		Tick:   c.Tick,
+		Fields: c.Fields,
+	}
+
+	if c.Position != nil {
+		v.Position = c.Position.String()
+	}
+	if c.Old != nil {
+		v.Old = fmt.Sprint(c.Old)
+	}
+	if c.New != nil {
+		v.New = fmt.Sprint(c.New)
+	}
+
+	return json.Marshal(v)
+}
+
+// Report represents the differences between two MIDI data.
+type Report struct {
+	// Header holds the changes of format type and time division.
+	Header  []Field  `json:"header"`
+	Changes []Change `json:"changes"`
+}
+
+// Equal reports whether there are no differences.
+func (r *Report) Equal() bool {
+	return len(r.Header) == 0 && len(r.Changes) == 0
+}
+
+// String returns human readable report. Each line represents a change.
+func (r *Report) String() string {
+	lines := []string{}
+
+	for _, f := range r.Header {
+		lines = append(lines, fmt.Sprintf("~ header: %v: %v -> %v", f.Name, f.Old, f.New))
+	}
+	for _, c := range r.Changes {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// JSON returns machine readable report.
+func (r *Report) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// eventFields returns the names and the values of the fields of event compared by Modified change.
+func eventFields(e event.Event) ([]string, map[string]string) {
+	names := []string{}
+	values := map[string]string{}
+	add := func(name string, value interface{}) {
+		names = append(names, name)
+		values[name] = fmt.Sprint(value)
+	}
+
+	if v, ok := e.(event.ChannelEvent); ok {
+		add("channel", v.Channel())
+	}
+
+	switch v := e.(type) {
+	case *event.NoteOnEvent:
+		add("note", v.Note())
+		add("velocity", v.Velocity())
+	case *event.NoteOffEvent:
+		add("note", v.Note())
+		add("velocity", v.Velocity())
+	case *event.NoteAfterTouchEvent:
+		add("note", v.Note())
+		add("velocity", v.Velocity())
+	case *event.ControllerEvent:
+		add("control", v.Control())
+		add("value", v.Value())
+	case *event.ProgramChangeEvent:
+		add("program", v.Program())
+	case *event.ChannelAfterTouchEvent:
+		add("velocity", v.Velocity())
+	case *event.PitchBendEvent:
+		add("pitch", v.Pitch())
+	case *event.SetTempoEvent:
+		add("tempo", v.Tempo())
+	case event.TextualEvent:
+		names = append(names, "text")
+		values["text"] = fmt.Sprintf("%q", v.Text())
+	case event.MetaEvent:
+		names = append(names, "data")
+		values["data"] = fmt.Sprintf("% x", v.Data())
+	case event.SysExEvent:
+		names = append(names, "data")
+		values["data"] = fmt.Sprintf("% x", v.Data())
+	}
+
+	return names, values
+}
+
+// fields returns the changed fields of events. The running status and the serialized bytes are compared if the fields look same.
+func fields(before, after event.Event) []Field {
+	names, befores := eventFields(before)
+	_, afters := eventFields(after)
+	fs := []Field{}
+
+	for _, name := range names {
+		if befores[name] != afters[name] {
+			fs = append(fs, Field{Name: name, Old: befores[name], New: afters[name]})
+		}
+	}
+	if len(fs) == 0 && before.RunningStatus() != after.RunningStatus() {
+		fs = append(fs, Field{Name: "runningStatus", Old: fmt.Sprint(before.RunningStatus()), New: fmt.Sprint(after.RunningStatus())})
+	}
+	if len(fs) == 0 {
+		fs = append(fs, Field{Name: "bytes", Old: fmt.Sprintf("% x", before.Serialize()), New: fmt.Sprintf("% x", after.Serialize())})
+	}
+
+	return fs
+}
@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestReport(t *testing.T) *Report {
+	a := newTestMIDI(t, []midi.TimedEvent{
+		at(t, 7680)(event.NewNoteOnEvent(nil, 0, constant.E4, 100)),
+	})
+	b := newTestMIDI(t, []midi.TimedEvent{
+		at(t, 7680)(event.NewNoteOnEvent(nil, 0, constant.E4, 110)),
+		at(t, 7800)(event.NewSetTempoEvent(nil, 400000)),
+	})
+
+	return Diff(a, b)
+}
+
+func TestReport_String(t *testing.T) {
+	expected := "~ track 0 at 5:1:0 (tick 7680): &NoteOnEvent{channel: 0, note: E4, velocity: 100} velocity: 100 -> 110\n" +
+		"+ track 0 at 5:1:120 (tick 7800): &SetTempoEvent{tempo: 400000}"
+
+	if actual := newTestReport(t).String(); expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestReport_JSON(t *testing.T) {
+	data, err := newTestReport(t).JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		Changes []struct {
+			Kind     string  `json:"kind"`
+			Position string  `json:"position"`
+			Old      string  `json:"old"`
+			New      string  `json:"new"`
+			Fields   []Field `json:"fields"`
+		} `json:"changes"`
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Changes) != 2 {
+		t.Fatalf("expected: 2 changes actual: %v changes", len(v.Changes))
+	}
+	if c := v.Changes[0]; c.Kind != "modified" || c.Position != "5:1:0" || c.Fields[0].New != "110" {
+		t.Fatalf("unexpected change: %+v", c)
+	}
+	if c := v.Changes[1]; c.Kind != "inserted" || c.Old != "" || c.New != "&SetTempoEvent{tempo: 400000}" {
+		t.Fatalf("unexpected change: %+v", c)
+	}
+}
+
+func TestFields_text(t *testing.T) {
+	before, _ := event.NewMarkerEvent(nil, []byte("verse: 1, chorus: 2"))
+	after, _ := event.NewMarkerEvent(nil, []byte("verse: 1, chorus: 3"))
+
+	fs := fields(before, after)
+	if len(fs) != 1 || fs[0] != (Field{Name: "text", Old: `"verse: 1, chorus: 2"`, New: `"verse: 1, chorus: 3"`}) {
+		t.Fatalf("unexpected fields: %v", fs)
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Package drum implements drum patterns, a grid of steps per percussion instrument.
+*/
+package drum
+
+import (
+	"fmt"
+	"sort"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Lane represents a row of the pattern which belongs to single instrument.
+type Lane struct {
+	Instrument constant.Percussion
+
+	// Velocities holds the velocity of each step. The velocity 0 means rest.
+	Velocities []uint8
+}
+
+// Pattern represents a drum pattern.
+type Pattern struct {
+	steps     int
+	stepTicks uint32
+	channel   uint8
+	lanes     []*Lane
+}
+
+// Steps returns number of steps.
+func (p *Pattern) Steps() int {
+	return p.steps
+}
+
+// StepTicks returns length of a step in ticks.
+func (p *Pattern) StepTicks() uint32 {
+	return p.stepTicks
+}
+
+// SetChannel sets channel.
+func (p *Pattern) SetChannel(channel uint8) error {
+	if channel > 0x0f {
+		return fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+	}
+	p.channel = channel
+
+	return nil
+}
+
+// Channel returns channel. The default is constant.PercussionChannel.
+func (p *Pattern) Channel() uint8 {
+	return p.channel
+}
+
+// Lanes returns lanes sorted by instrument in descending order.
+// It means that cymbals come first and bass drums come last like a drum tab.
+func (p *Pattern) Lanes() []*Lane {
+	lanes := make([]*Lane, len(p.lanes))
+	copy(lanes, p.lanes)
+
+	sort.Slice(lanes, func(i, j int) bool {
+		return lanes[i].Instrument > lanes[j].Instrument
+	})
+
+	return lanes
+}
+
+// Lane returns the lane of instrument. It returns nil if the pattern doesn't use the instrument.
+func (p *Pattern) Lane(instrument constant.Percussion) *Lane {
+	for _, lane := range p.lanes {
+		if lane.Instrument == instrument {
+			return lane
+		}
+	}
+
+	return nil
+}
+
+// SetHit sets velocity of the instrument at the step. The velocity 0 removes the hit.
+func (p *Pattern) SetHit(instrument constant.Percussion, step int, velocity uint8) error {
+	if step < 0 || step >= p.steps {
+		return fmt.Errorf("midi: step must be 0 to %v", p.steps-1)
+	}
+	if instrument > 0x7f {
+		return fmt.Errorf("midi: maximum value of instrument is 127 (0x7f)")
+	}
+	if velocity > 0x7f {
+		return fmt.Errorf("midi: maximum value of velocity is 127 (0x7f)")
+	}
+
+	lane := p.Lane(instrument)
+	if lane == nil {
+		lane = &Lane{
+			Instrument: instrument,
+			Velocities: make([]uint8, p.steps),
+		}
+		p.lanes = append(p.lanes, lane)
+	}
+
+	lane.Velocities[step] = velocity
+
+	return nil
+}
+
+// Hit returns velocity of the instrument at the step. It returns 0 if there is no hit.
+func (p *Pattern) Hit(instrument constant.Percussion, step int) uint8 {
+	lane := p.Lane(instrument)
+	if lane == nil || step < 0 || step >= p.steps {
+		return 0
+	}
+
+	return lane.Velocities[step]
+}
+
+// Track converts the pattern to a track. Each hit becomes a note on event
+// followed by a note off event at the middle of the step.
+func (p *Pattern) Track() (*midi.Track, error) {
+	gate := p.stepTicks / 2
+	if gate == 0 {
+		gate = 1
+	}
+
+	tes := []midi.TimedEvent{}
+
+	for _, lane := range p.lanes {
+		for step, velocity := range lane.Velocities {
+			if velocity == 0 {
+				continue
+			}
+
+			tick := uint32(step) * p.stepTicks
+
+			noteOn, err := event.NewNoteOnEvent(nil, p.channel, lane.Instrument.Note(), velocity)
+			if err != nil {
+				return nil, err
+			}
+			noteOff, err := event.NewNoteOffEvent(nil, p.channel, lane.Instrument.Note(), 0)
+			if err != nil {
+				return nil, err
+			}
+
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: noteOn})
+			tes = append(tes, midi.TimedEvent{Tick: tick + gate, Event: noteOff})
+		}
+	}
+
+	endOfTrack, err := event.NewEndOfTrackEvent(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tes = append(tes, midi.TimedEvent{Tick: uint32(p.steps) * p.stepTicks, Event: endOfTrack})
+
+	return midi.NewTrackFromTimedEvents(tes), nil
+}
+
+// NewPattern returns Pattern with the given number of steps.
+// The stepTicks is the length of a step, e.g. 120 for sixteenth notes when the time division is 480.
+func NewPattern(steps int, stepTicks uint32) (*Pattern, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("midi: number of steps must be greater than 0")
+	}
+	if stepTicks == 0 {
+		return nil, fmt.Errorf("midi: length of step must be greater than 0")
+	}
+
+	p := &Pattern{
+		steps:     steps,
+		stepTicks: stepTicks,
+		channel:   constant.PercussionChannel,
+	}
+
+	return p, nil
+}
+
+// NewPatternFromTrack returns Pattern which contains the note on events of the track on the given channel.
+// The note on events are quantized to the nearest step. If two hits fall into the same step, the louder one wins.
+func NewPatternFromTrack(track *midi.Track, channel uint8, stepTicks uint32) (*Pattern, error) {
+	if stepTicks == 0 {
+		return nil, fmt.Errorf("midi: length of step must be greater than 0")
+	}
+
+	tes := track.TimedEvents()
+
+	var length uint32
+
+	if len(tes) > 0 {
+		length = tes[len(tes)-1].Tick
+	}
+
+	steps := int((length + stepTicks - 1) / stepTicks)
+	if steps == 0 {
+		steps = 1
+	}
+
+	type hit struct {
+		step       int
+		instrument constant.Percussion
+		velocity   uint8
+	}
+
+	hits := []hit{}
+
+	for _, te := range tes {
+		noteOn, ok := te.Event.(*event.NoteOnEvent)
+		if !ok || noteOn.Channel() != channel || noteOn.Velocity() == 0 {
+			continue
+		}
+
+		step := int((te.Tick + stepTicks/2) / stepTicks)
+		if step >= steps {
+			steps = step + 1
+		}
+
+		hits = append(hits, hit{
+			step:       step,
+			instrument: constant.Percussion(noteOn.Note()),
+			velocity:   noteOn.Velocity(),
+		})
+	}
+
+	p, err := NewPattern(steps, stepTicks)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.SetChannel(channel); err != nil {
+		return nil, err
+	}
+	for _, h := range hits {
+		if h.velocity <= p.Hit(h.instrument, h.step) {
+			continue
+		}
+		if err := p.SetHit(h.instrument, h.step, h.velocity); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
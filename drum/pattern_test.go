@@ -0,0 +1,114 @@
+package drum
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestNewPattern(t *testing.T) {
+	if _, err := NewPattern(0, 120); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewPattern(16, 0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+
+	p, err := NewPattern(16, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Channel() != constant.PercussionChannel {
+		t.Fatalf("expected: %v actual: %v", constant.PercussionChannel, p.Channel())
+	}
+}
+
+func TestPattern_SetHit(t *testing.T) {
+	p, _ := NewPattern(16, 120)
+
+	if err := p.SetHit(constant.BassDrum1, 16, 100); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := p.SetHit(constant.BassDrum1, 0, 0x80); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := p.SetHit(constant.BassDrum1, 4, 100); err != nil {
+		t.Fatal(err)
+	}
+	if actual := p.Hit(constant.BassDrum1, 4); actual != 100 {
+		t.Fatalf("expected: 100 actual: %v", actual)
+	}
+	if actual := p.Hit(constant.AcousticSnare, 4); actual != 0 {
+		t.Fatalf("expected: 0 actual: %v", actual)
+	}
+}
+
+func TestPattern_Track(t *testing.T) {
+	p, _ := NewPattern(4, 120)
+	p.SetHit(constant.BassDrum1, 0, 100)
+	p.SetHit(constant.AcousticSnare, 2, 90)
+
+	track, err := p.Track()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tes := track.TimedEvents()
+
+	expectedTicks := []uint32{0, 60, 240, 300, 480}
+
+	if len(expectedTicks) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v events", len(expectedTicks), len(tes))
+	}
+	for i, e := range expectedTicks {
+		if a := tes[i].Tick; e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+
+	noteOn, ok := tes[2].Event.(*event.NoteOnEvent)
+	if !ok {
+		t.Fatalf("expected: *event.NoteOnEvent actual: %T", tes[2].Event)
+	}
+	if noteOn.Note() != constant.AcousticSnare.Note() || noteOn.Channel() != 9 || noteOn.Velocity() != 90 {
+		t.Fatalf("unexpected event %v", noteOn)
+	}
+	if _, ok := tes[4].Event.(*event.EndOfTrackEvent); !ok {
+		t.Fatalf("expected: *event.EndOfTrackEvent actual: %T", tes[4].Event)
+	}
+}
+
+func TestNewPatternFromTrack(t *testing.T) {
+	bassDrum, _ := event.NewNoteOnEvent(nil, 9, constant.BassDrum1.Note(), 100)
+	snare, _ := event.NewNoteOnEvent(nil, 9, constant.AcousticSnare.Note(), 90)
+	ghost, _ := event.NewNoteOnEvent(nil, 9, constant.AcousticSnare.Note(), 30)
+	piano, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 100)
+	endOfTrack, _ := event.NewEndOfTrackEvent(nil)
+
+	track := midi.NewTrackFromTimedEvents([]midi.TimedEvent{
+		{Tick: 0, Event: bassDrum},
+		{Tick: 250, Event: snare},
+		{Tick: 245, Event: ghost},
+		{Tick: 120, Event: piano},
+		{Tick: 480, Event: endOfTrack},
+	})
+
+	p, err := NewPatternFromTrack(track, 9, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Steps() != 4 {
+		t.Fatalf("expected: 4 actual: %v", p.Steps())
+	}
+	if actual := p.Hit(constant.BassDrum1, 0); actual != 100 {
+		t.Fatalf("expected: 100 actual: %v", actual)
+	}
+	if actual := p.Hit(constant.AcousticSnare, 2); actual != 90 {
+		t.Fatalf("expected: 90 actual: %v", actual)
+	}
+	if len(p.Lanes()) != 2 {
+		t.Fatalf("expected: 2 lanes actual: %v lanes", len(p.Lanes()))
+	}
+}
@@ -0,0 +1,96 @@
+package drum
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+// AccentVelocity is the minimum velocity written as an accent in drum tab.
+const AccentVelocity = 100
+
+var abbreviations = map[constant.Percussion]string{
+	constant.AcousticBassDrum: "BD",
+	constant.BassDrum1:        "BD",
+	constant.SideStick:        "SS",
+	constant.AcousticSnare:    "SD",
+	constant.HandClap:         "CP",
+	constant.ElectricSnare:    "SD",
+	constant.LowFloorTom:      "FT",
+	constant.ClosedHiHat:      "HH",
+	constant.HighFloorTom:     "FT",
+	constant.PedalHiHat:       "PH",
+	constant.LowTom:           "LT",
+	constant.OpenHiHat:        "OH",
+	constant.LowMidTom:        "MT",
+	constant.HiMidTom:         "MT",
+	constant.CrashCymbal1:     "CC",
+	constant.HighTom:          "HT",
+	constant.RideCymbal1:      "RC",
+	constant.ChineseCymbal:    "CH",
+	constant.RideBell:         "RB",
+	constant.Tambourine:       "TB",
+	constant.SplashCymbal:     "SC",
+	constant.Cowbell:          "CB",
+	constant.CrashCymbal2:     "CC",
+	constant.RideCymbal2:      "RC",
+}
+
+// Abbreviation returns the short name of percussion used in drum tab, e.g. "BD" for bass drum.
+// The note number is returned for the percussion which doesn't have well known abbreviation.
+func Abbreviation(p constant.Percussion) string {
+	if s, ok := abbreviations[p]; ok {
+		return s
+	}
+
+	return fmt.Sprint(uint8(p))
+}
+
+// Tab returns the pattern as ASCII drum tab. Each lane is written as a line,
+// 'X' is an accent, 'x' is a hit and '-' is a rest. The bars are separated by '|'
+// every stepsPerBar steps. The stepsPerBar 0 means that the whole pattern is a bar.
+//
+//	HH |x-x-x-x-x-x-x-x-|
+//	SD |----X-------X---|
+//	BD |X-------X-------|
+func (p *Pattern) Tab(stepsPerBar int) string {
+	if stepsPerBar <= 0 {
+		stepsPerBar = p.steps
+	}
+
+	lanes := p.Lanes()
+	labels := make([]string, len(lanes))
+	width := 0
+
+	for i, lane := range lanes {
+		labels[i] = Abbreviation(lane.Instrument)
+		if len(labels[i]) > width {
+			width = len(labels[i])
+		}
+	}
+
+	var b strings.Builder
+
+	for i, lane := range lanes {
+		fmt.Fprintf(&b, "%-*s |", width, labels[i])
+
+		for step, velocity := range lane.Velocities {
+			switch {
+			case velocity >= AccentVelocity:
+				b.WriteByte('X')
+			case velocity > 0:
+				b.WriteByte('x')
+			default:
+				b.WriteByte('-')
+			}
+			if (step+1)%stepsPerBar == 0 || step == len(lane.Velocities)-1 {
+				b.WriteByte('|')
+			}
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package drum
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+func TestAbbreviation(t *testing.T) {
+	if actual := Abbreviation(constant.ClosedHiHat); actual != "HH" {
+		t.Fatalf("expected: HH actual: %v", actual)
+	}
+	if actual := Abbreviation(constant.Claves); actual != "75" {
+		t.Fatalf("expected: 75 actual: %v", actual)
+	}
+}
+
+func TestPattern_Tab(t *testing.T) {
+	p, _ := NewPattern(8, 120)
+
+	for step := 0; step < 8; step += 2 {
+		p.SetHit(constant.ClosedHiHat, step, 80)
+	}
+	p.SetHit(constant.AcousticSnare, 2, 110)
+	p.SetHit(constant.AcousticSnare, 6, 110)
+	p.SetHit(constant.BassDrum1, 0, 120)
+	p.SetHit(constant.BassDrum1, 4, 90)
+
+	expected := "HH |x-x-|x-x-|\nSD |--X-|--X-|\nBD |X---|x---|\n"
+	actual := p.Tab(4)
+
+	if expected != actual {
+		t.Fatalf("expected: %q actual: %q", expected, actual)
+	}
+
+	expected = "HH |x-x-x-x-|\nSD |--X---X-|\nBD |X---x---|\n"
+	actual = p.Tab(0)
+
+	if expected != actual {
+		t.Fatalf("expected: %q actual: %q", expected, actual)
+	}
+}
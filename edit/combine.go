@@ -0,0 +1,408 @@
+package edit
+
+import (
+	"fmt"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// DefaultTempo is the tempo in microseconds per quarter note assumed until a set tempo event appears.
+const DefaultTempo = midi.DefaultTempo
+
+// Combiner combines multiple MIDI data into one.
+// The result is always format 1 and its first track is the conductor track which holds the tempo map.
+type Combiner struct {
+	gap        uint32
+	resolution uint16
+	remap      bool
+}
+
+// SetGap sets the silence in ticks inserted between the sources appended sequentially. The default is 0.
+func (c *Combiner) SetGap(gap uint32) *Combiner {
+	c.gap = gap
+
+	return c
+}
+
+// SetResolution sets the ticks per quarter note of the result.
+// The default is 0, which means the highest resolution of the sources.
+func (c *Combiner) SetResolution(ticksPerQuarterNote uint16) *Combiner {
+	c.resolution = ticksPerQuarterNote
+
+	return c
+}
+
+// SetRemapChannels sets whether the colliding channels of layered sources are moved to the unused channels. The default is true.
+func (c *Combiner) SetRemapChannels(remap bool) *Combiner {
+	c.remap = remap
+
+	return c
+}
+
+type source struct {
+	conductor []midi.TimedEvent
+	tracks    [][]midi.TimedEvent
+	length    uint32
+	channels  [16]bool
+}
+
+// conductorType returns the kind of event which is moved to the conductor track.
+func conductorType(e event.Event) (uint8, bool) {
+	switch e.(type) {
+	case *event.SetTempoEvent:
+		return constant.SetTempo, true
+	case *event.TimeSignatureEvent:
+		return constant.TimeSignature, true
+	case *event.KeySignatureEvent:
+		return constant.KeySignature, true
+	case *event.SMPTEOffsetEvent:
+		return constant.SMPTEOffset, true
+	}
+
+	return 0, false
+}
+
+// load copies the events of sources, rescales them to the resolution and splits them into the conductor and the other tracks.
+func (c *Combiner) load(ms []*midi.MIDI) (uint16, []source, error) {
+	if len(ms) == 0 {
+		return 0, nil, fmt.Errorf("midi: no MIDI data to combine")
+	}
+
+	resolution := c.resolution
+
+	for _, m := range ms {
+		ticksPerQuarterNote, err := m.TimeDivision().BPM()
+		if err != nil {
+			return 0, nil, err
+		}
+		if c.resolution == 0 && ticksPerQuarterNote > resolution {
+			resolution = ticksPerQuarterNote
+		}
+	}
+
+	sources := make([]source, len(ms))
+
+	for i, m := range ms {
+		ticksPerQuarterNote, _ := m.TimeDivision().BPM()
+		rescale := func(tick uint32) uint32 {
+			return uint32((uint64(tick)*uint64(resolution) + uint64(ticksPerQuarterNote)/2) / uint64(ticksPerQuarterNote))
+		}
+
+		s := &sources[i]
+
+		for _, track := range m.Tracks {
+			tes := []midi.TimedEvent{}
+
+			for _, te := range track.TimedEvents() {
+				tick := rescale(te.Tick)
+				if tick > s.length {
+					s.length = tick
+				}
+				if _, ok := te.Event.(*event.EndOfTrackEvent); ok {
+					break
+				}
+				tes = append(tes, midi.TimedEvent{Tick: tick, Event: te.Event})
+			}
+
+			copied := cloneEvents(tes)
+
+			others := []midi.TimedEvent{}
+
+			for _, te := range copied {
+				if _, ok := conductorType(te.Event); ok {
+					s.conductor = append(s.conductor, te)
+					continue
+				}
+				if te.Event.Kind().IsChannel() {
+					s.channels[te.Event.(event.ChannelEvent).Channel()] = true
+				}
+				others = append(others, te)
+			}
+			if len(others) > 0 {
+				s.tracks = append(s.tracks, others)
+			}
+		}
+	}
+
+	return resolution, sources, nil
+}
+
+// newResult returns format 1 MIDI which consists of the conductor track and the tracks. All tracks end at length.
+func newResult(resolution uint16, conductor []midi.TimedEvent, tracks [][]midi.TimedEvent, length uint32) (*midi.MIDI, error) {
+	result := &midi.MIDI{}
+
+	if err := result.SetFormatType(1); err != nil {
+		return nil, err
+	}
+	if err := result.TimeDivision().SetBPM(int(resolution)); err != nil {
+		return nil, err
+	}
+
+	for _, tes := range append([][]midi.TimedEvent{conductor}, tracks...) {
+		eot, err := event.NewEndOfTrackEvent(nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Tracks = append(result.Tracks, midi.NewTrackFromTimedEvents(append(tes, midi.TimedEvent{Tick: length, Event: eot})))
+	}
+
+	return result, nil
+}
+
+func shift(tes []midi.TimedEvent, offset uint32) []midi.TimedEvent {
+	result := make([]midi.TimedEvent, len(tes))
+
+	for i, te := range tes {
+		result[i] = midi.TimedEvent{Tick: te.Tick + offset, Event: te.Event}
+	}
+
+	return result
+}
+
+// Append returns MIDI data which plays the sources one after another.
+//
+// The tracks of sources are merged by position, e.g. the first non-conductor track of each source
+// goes to the second track of the result. When a source doesn't set tempo or time signature at its beginning,
+// the default 120 BPM and 4/4 are restored, and when a source plays a channel without setting its program,
+// the program is reset to 0, so that the setup of previous source doesn't leak.
+func (c *Combiner) Append(ms ...*midi.MIDI) (*midi.MIDI, error) {
+	resolution, sources, err := c.load(ms)
+	if err != nil {
+		return nil, err
+	}
+
+	conductor := []midi.TimedEvent{}
+	tracks := [][]midi.TimedEvent{}
+	tempo := uint32(DefaultTempo)
+	numerator, denominator := uint8(4), uint8(2)
+	programSet := [16]bool{}
+
+	var offset uint32
+
+	for i, s := range sources {
+		if i > 0 {
+			offset += c.gap
+
+			set := map[uint8]bool{}
+			for _, te := range s.conductor {
+				if key, ok := conductorType(te.Event); ok && te.Tick == 0 {
+					set[key] = true
+				}
+			}
+			if !set[constant.SetTempo] && tempo != DefaultTempo {
+				e, err := event.NewSetTempoEvent(nil, DefaultTempo)
+				if err != nil {
+					return nil, err
+				}
+				conductor = append(conductor, midi.TimedEvent{Tick: offset, Event: e})
+				tempo = DefaultTempo
+			}
+			if !set[constant.TimeSignature] && (numerator != 4 || denominator != 2) {
+				e, err := event.NewTimeSignatureEvent(nil, 4, 2, 24, 8)
+				if err != nil {
+					return nil, err
+				}
+				conductor = append(conductor, midi.TimedEvent{Tick: offset, Event: e})
+				numerator, denominator = 4, 2
+			}
+		}
+
+		for _, te := range s.conductor {
+			switch v := te.Event.(type) {
+			case *event.SMPTEOffsetEvent:
+				// SMPTE offset is meaningful only at the beginning of song.
+				if i > 0 {
+					continue
+				}
+			case *event.SetTempoEvent:
+				tempo = v.Tempo()
+			case *event.TimeSignatureEvent:
+				numerator, denominator = v.Numerator(), v.Denominator()
+			}
+			conductor = append(conductor, midi.TimedEvent{Tick: te.Tick + offset, Event: te.Event})
+		}
+
+		resets, err := programResets(&s, programSet)
+		if err != nil {
+			return nil, err
+		}
+
+		for j, tes := range s.tracks {
+			if j+1 > len(tracks) {
+				tracks = append(tracks, []midi.TimedEvent{})
+			}
+			tracks[j] = append(tracks[j], shift(append(resets[j], tes...), offset)...)
+		}
+
+		for _, tes := range s.tracks {
+			for _, te := range tes {
+				if v, ok := te.Event.(*event.ProgramChangeEvent); ok {
+					programSet[v.Channel()] = true
+				}
+			}
+		}
+
+		offset += s.length
+	}
+
+	return newResult(resolution, conductor, tracks, offset)
+}
+
+// programResets returns the program changes to 0 which are put at the beginning of each track of source.
+// A channel is reset when the previous sources set its program and the source plays the channel before setting the program.
+func programResets(s *source, programSet [16]bool) ([][]midi.TimedEvent, error) {
+	resets := make([][]midi.TimedEvent, len(s.tracks))
+	decided := [16]bool{}
+
+	for j, tes := range s.tracks {
+		for _, te := range tes {
+			if !te.Event.Kind().IsChannel() {
+				continue
+			}
+
+			channel := te.Event.(event.ChannelEvent).Channel()
+
+			if decided[channel] {
+				continue
+			}
+
+			decided[channel] = true
+
+			if _, ok := te.Event.(*event.ProgramChangeEvent); ok || !programSet[channel] || constant.IsPercussionChannel(channel) {
+				continue
+			}
+
+			e, err := event.NewProgramChangeEvent(nil, channel, 0)
+			if err != nil {
+				return nil, err
+			}
+			resets[j] = append(resets[j], midi.TimedEvent{Tick: 0, Event: e})
+		}
+	}
+
+	return resets, nil
+}
+
+// Layer returns MIDI data which plays the sources at the same time.
+//
+// The tempo map is merged into the conductor track. When the sources have the same kind of event
+// such as tempo at the same tick, the event of the earlier source wins.
+// If the channel remapping is enabled, the channels of a source which collide with the earlier sources
+// are moved to the lowest unused channels. The percussion channel is never moved nor allocated.
+func (c *Combiner) Layer(ms ...*midi.MIDI) (*midi.MIDI, error) {
+	resolution, sources, err := c.load(ms)
+	if err != nil {
+		return nil, err
+	}
+
+	conductor := []midi.TimedEvent{}
+	tracks := [][]midi.TimedEvent{}
+	type slot struct {
+		tick uint32
+		kind uint8
+	}
+
+	taken := map[slot]bool{}
+	allocated := [16]bool{}
+
+	var length uint32
+
+	for _, s := range sources {
+		mapping, err := c.channelMapping(&s, allocated)
+		if err != nil {
+			return nil, err
+		}
+
+		for channel, used := range s.channels {
+			if used {
+				allocated[mapping[channel]] = true
+			}
+		}
+
+		for _, te := range s.conductor {
+			kind, _ := conductorType(te.Event)
+
+			if taken[slot{te.Tick, kind}] {
+				continue
+			}
+
+			taken[slot{te.Tick, kind}] = true
+			conductor = append(conductor, te)
+		}
+
+		for _, tes := range s.tracks {
+			for _, te := range tes {
+				if v, ok := te.Event.(event.ChannelEvent); ok {
+					if err := v.SetChannel(mapping[v.Channel()]); err != nil {
+						return nil, err
+					}
+				}
+			}
+			tracks = append(tracks, tes)
+		}
+
+		if s.length > length {
+			length = s.length
+		}
+	}
+
+	return newResult(resolution, conductor, tracks, length)
+}
+
+// channelMapping returns the mapping from the channels of source to the channels of result.
+func (c *Combiner) channelMapping(s *source, allocated [16]bool) ([16]uint8, error) {
+	mapping := [16]uint8{}
+	reserved := allocated
+
+	for channel := range mapping {
+		mapping[channel] = uint8(channel)
+
+		if s.channels[channel] {
+			reserved[channel] = true
+		}
+	}
+	if !c.remap {
+		return mapping, nil
+	}
+
+	for channel, used := range s.channels {
+		if !used || !allocated[channel] || constant.IsPercussionChannel(uint8(channel)) {
+			continue
+		}
+
+		free := -1
+		for candidate := range reserved {
+			if !reserved[candidate] && !constant.IsPercussionChannel(uint8(candidate)) {
+				free = candidate
+				break
+			}
+		}
+		if free < 0 {
+			return mapping, fmt.Errorf("midi: no unused channel for channel %v", channel)
+		}
+
+		reserved[free] = true
+		mapping[channel] = uint8(free)
+	}
+
+	return mapping, nil
+}
+
+// NewCombiner returns Combiner.
+func NewCombiner() *Combiner {
+	return &Combiner{
+		remap: true,
+	}
+}
+
+// Append returns the sources played one after another with the default Combiner.
+func Append(ms ...*midi.MIDI) (*midi.MIDI, error) {
+	return NewCombiner().Append(ms...)
+}
+
+// Layer returns the sources played at the same time with the default Combiner.
+func Layer(ms ...*midi.MIDI) (*midi.MIDI, error) {
+	return NewCombiner().Layer(ms...)
+}
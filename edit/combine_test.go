@@ -0,0 +1,136 @@
+package edit
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestSource(t *testing.T, resolution int, tempo uint32, program bool, note constant.Note, length uint32) *midi.MIDI {
+	conductor := []midi.TimedEvent{}
+	tes := []midi.TimedEvent{}
+	at := func(tes *[]midi.TimedEvent, tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			*tes = append(*tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	if tempo != 0 {
+		at(&conductor, 0)(event.NewSetTempoEvent(nil, tempo))
+	}
+	at(&conductor, length)(event.NewEndOfTrackEvent(nil))
+
+	if program {
+		at(&tes, 0)(event.NewProgramChangeEvent(nil, 0, constant.Violin))
+	}
+	at(&tes, 0)(event.NewNoteOnEvent(nil, 0, note, 100))
+	at(&tes, length/2)(event.NewNoteOffEvent(nil, 0, note, 0))
+	at(&tes, length)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(conductor), midi.NewTrackFromTimedEvents(tes)}}
+	m.SetFormatType(1)
+	m.TimeDivision().SetBPM(resolution)
+
+	return m
+}
+
+func TestAppend(t *testing.T) {
+	a := newTestSource(t, 480, 600000, true, constant.C3, 960)
+	b := newTestSource(t, 240, 0, false, constant.E3, 480)
+	source := a.Serialize()
+
+	result, err := Append(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(source) != string(a.Serialize()) {
+		t.Fatalf("source must not be modified")
+	}
+	if len(result.Tracks) != 2 {
+		t.Fatalf("expected: 2 tracks actual: %v tracks", len(result.Tracks))
+	}
+	if actual, _ := result.TimeDivision().BPM(); actual != 480 {
+		t.Fatalf("expected: 480 actual: %v", actual)
+	}
+
+	assertTrack(t, result.Tracks[0], []uint32{0, 960, 1920}, [][]byte{
+		{0xff, 0x51, 0x03, 0x09, 0x27, 0xc0},
+		{0xff, 0x51, 0x03, 0x07, 0xa1, 0x20},
+		{0xff, 0x2f, 0x00},
+	})
+	assertTrack(t, result.Tracks[1], []uint32{0, 0, 480, 960, 960, 1440, 1920}, [][]byte{
+		{0xc0, byte(constant.Violin)},
+		{0x90, byte(constant.C3), 100},
+		{0x80, byte(constant.C3), 0},
+		{0xc0, 0x00},
+		{0x90, byte(constant.E3), 100},
+		{0x80, byte(constant.E3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+}
+
+func TestCombiner_SetGap(t *testing.T) {
+	a := newTestSource(t, 480, 0, false, constant.C3, 960)
+	b := newTestSource(t, 480, 0, false, constant.E3, 960)
+
+	result, err := NewCombiner().SetGap(480).SetResolution(960).Append(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTrack(t, result.Tracks[1], []uint32{0, 960, 2400, 3360, 4320}, [][]byte{
+		{0x90, byte(constant.C3), 100},
+		{0x80, byte(constant.C3), 0},
+		{0x90, byte(constant.E3), 100},
+		{0x80, byte(constant.E3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+}
+
+func TestLayer(t *testing.T) {
+	a := newTestSource(t, 480, 600000, true, constant.C3, 960)
+	b := newTestSource(t, 240, 400000, true, constant.E3, 960)
+
+	result, err := Layer(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks) != 3 {
+		t.Fatalf("expected: 3 tracks actual: %v tracks", len(result.Tracks))
+	}
+
+	assertTrack(t, result.Tracks[0], []uint32{0, 1920}, [][]byte{
+		{0xff, 0x51, 0x03, 0x09, 0x27, 0xc0},
+		{0xff, 0x2f, 0x00},
+	})
+	assertTrack(t, result.Tracks[2], []uint32{0, 0, 960, 1920}, [][]byte{
+		{0xc1, byte(constant.Violin)},
+		{0x91, byte(constant.E3), 100},
+		{0x81, byte(constant.E3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+
+	result, err = NewCombiner().SetRemapChannels(false).Layer(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := result.Tracks[2].Events[0].(*event.ProgramChangeEvent); e.Channel() != 0 {
+		t.Fatalf("expected: 0 actual: %v", e.Channel())
+	}
+}
+
+func TestLayer_noUnusedChannel(t *testing.T) {
+	ms := []*midi.MIDI{}
+	for i := 0; i < 16; i++ {
+		ms = append(ms, newTestSource(t, 480, 0, false, constant.C3, 960))
+	}
+
+	if _, err := Layer(ms...); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
@@ -0,0 +1,17 @@
+package edit
+
+import midi "github.com/moutend/go-midi"
+
+// cloneEvents returns the copies of events, so that the delta times of the result can be changed without touching the source.
+// The running status is cleared because the preceding event may be different in the result.
+func cloneEvents(tes []midi.TimedEvent) []midi.TimedEvent {
+	result := make([]midi.TimedEvent, len(tes))
+
+	for i, te := range tes {
+		e := te.Event.Clone()
+		e.SetRunningStatus(false)
+		result[i] = midi.TimedEvent{Tick: te.Tick, Event: e}
+	}
+
+	return result
+}
@@ -0,0 +1,274 @@
+package edit
+
+import (
+	"fmt"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/event"
+)
+
+// Filter extracts or mutes tracks, channels and events, and remaps channels and ports.
+//
+// The conductor events such as tempo and time signature are always kept. In format 1, the first track
+// is the conductor track and is always kept, and the conductor events of removed tracks are moved to it.
+// The zero value keeps everything.
+type Filter struct {
+	tracks      map[int]bool
+	mutedTracks map[int]bool
+	channels    *[16]bool
+	muted       [16]bool
+	events      func(event.Event) bool
+	channelMap  map[uint8]uint8
+	portMap     map[uint8]uint8
+	err         error
+}
+
+// SetTracks selects the tracks to keep by index. All tracks are kept by default.
+func (f *Filter) SetTracks(indices ...int) *Filter {
+	f.tracks = map[int]bool{}
+
+	for _, index := range indices {
+		f.tracks[index] = true
+	}
+
+	return f
+}
+
+// MuteTracks removes the tracks by index.
+func (f *Filter) MuteTracks(indices ...int) *Filter {
+	if f.mutedTracks == nil {
+		f.mutedTracks = map[int]bool{}
+	}
+	for _, index := range indices {
+		f.mutedTracks[index] = true
+	}
+
+	return f
+}
+
+// SetChannels selects the channels to keep. All channels are kept by default.
+func (f *Filter) SetChannels(channels ...uint8) *Filter {
+	f.channels = &[16]bool{}
+
+	for _, channel := range channels {
+		if channel > 0x0f {
+			f.err = fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+			continue
+		}
+		f.channels[channel] = true
+	}
+
+	return f
+}
+
+// MuteChannels removes the events of channels.
+func (f *Filter) MuteChannels(channels ...uint8) *Filter {
+	for _, channel := range channels {
+		if channel > 0x0f {
+			f.err = fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+			continue
+		}
+		f.muted[channel] = true
+	}
+
+	return f
+}
+
+// SetEventFilter sets the function which reports whether the event is kept, e.g. to extract only note events.
+// The conductor events and the end of track events are not passed to the function.
+func (f *Filter) SetEventFilter(keep func(event.Event) bool) *Filter {
+	f.events = keep
+
+	return f
+}
+
+// RemapChannel moves the events of channel from to channel to. The channels are remapped after filtering.
+func (f *Filter) RemapChannel(from, to uint8) *Filter {
+	if from > 0x0f || to > 0x0f {
+		f.err = fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+		return f
+	}
+
+	if f.channelMap == nil {
+		f.channelMap = map[uint8]uint8{}
+	}
+
+	f.channelMap[from] = to
+
+	return f
+}
+
+// RemapPort changes the port of MIDI port prefix events from from to to.
+func (f *Filter) RemapPort(from, to uint8) *Filter {
+	if f.portMap == nil {
+		f.portMap = map[uint8]uint8{}
+	}
+
+	f.portMap[from] = to
+
+	return f
+}
+
+func (f *Filter) keepTrack(m *midi.MIDI, index int) bool {
+	if index == 0 && m.FormatType() == 1 {
+		return true
+	}
+	if f.mutedTracks[index] {
+		return false
+	}
+
+	return f.tracks == nil || f.tracks[index]
+}
+
+func (f *Filter) keepChannel(channel uint8) bool {
+	if f.muted[channel] {
+		return false
+	}
+
+	return f.channels == nil || f.channels[channel]
+}
+
+// Apply returns the filtered copy of MIDI data.
+func (f *Filter) Apply(m *midi.MIDI) (*midi.MIDI, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	result := &midi.MIDI{}
+	timeDivision := *m.TimeDivision()
+
+	result.SetTimeDivision(&timeDivision)
+
+	if err := result.SetFormatType(m.FormatType()); err != nil {
+		return nil, err
+	}
+
+	kept := [][]midi.TimedEvent{}
+	orphans := []midi.TimedEvent{}
+
+	for i, track := range m.Tracks {
+		tes := track.TimedEvents()
+
+		if !f.keepTrack(m, i) {
+			for _, te := range tes {
+				if _, ok := conductorType(te.Event); ok {
+					orphans = append(orphans, te)
+				}
+			}
+			continue
+		}
+
+		kept = append(kept, f.filterTrack(tes))
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("midi: no track is kept")
+	}
+
+	kept[0] = append(orphans, kept[0]...)
+
+	for _, tes := range kept {
+		var end uint32
+		events := []midi.TimedEvent{}
+
+		for _, te := range tes {
+			if te.Tick > end {
+				end = te.Tick
+			}
+			if _, ok := te.Event.(*event.EndOfTrackEvent); !ok {
+				events = append(events, te)
+			}
+		}
+
+		copied := cloneEvents(events)
+
+		for _, te := range copied {
+			switch v := te.Event.(type) {
+			case *event.MIDIPortPrefixEvent:
+				if port, ok := f.portMap[v.Port()]; ok {
+					v.SetPort(port)
+				}
+			case event.ChannelEvent:
+				if channel, ok := f.channelMap[v.Channel()]; ok {
+					if err := v.SetChannel(channel); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		eot, err := event.NewEndOfTrackEvent(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Tracks = append(result.Tracks, midi.NewTrackFromTimedEvents(append(copied, midi.TimedEvent{Tick: end, Event: eot})))
+	}
+
+	return result, nil
+}
+
+// filterTrack returns the events to keep. The meta events bound to a removed MIDI channel prefix are removed together.
+func (f *Filter) filterTrack(tes []midi.TimedEvent) []midi.TimedEvent {
+	result := []midi.TimedEvent{}
+	unbound := false
+
+	for _, te := range tes {
+		e := te.Event
+
+		if _, ok := conductorType(e); ok {
+			result = append(result, te)
+			continue
+		}
+		if _, ok := e.(*event.EndOfTrackEvent); ok {
+			result = append(result, te)
+			continue
+		}
+
+		switch v := e.(type) {
+		case *event.MIDIChannelPrefixEvent:
+			unbound = !f.keepChannel(v.Channel())
+			if unbound {
+				continue
+			}
+		case event.ChannelEvent:
+			unbound = false
+			if !f.keepChannel(v.Channel()) {
+				continue
+			}
+		case *event.SystemExclusiveEvent, *event.DividedSystemExclusiveEvent:
+			unbound = false
+		default:
+			if unbound {
+				continue
+			}
+		}
+
+		if f.events != nil && !f.events(e) {
+			continue
+		}
+
+		result = append(result, te)
+	}
+
+	return result
+}
+
+// NewFilter returns Filter which keeps everything.
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// ExtractTracks returns MIDI data which contains only the tracks and the conductor track.
+func ExtractTracks(m *midi.MIDI, indices ...int) (*midi.MIDI, error) {
+	return NewFilter().SetTracks(indices...).Apply(m)
+}
+
+// ExtractChannels returns MIDI data which contains only the events of channels and the other events.
+func ExtractChannels(m *midi.MIDI, channels ...uint8) (*midi.MIDI, error) {
+	return NewFilter().SetChannels(channels...).Apply(m)
+}
+
+// RemapChannel returns MIDI data in which the events of channel from are moved to channel to.
+func RemapChannel(m *midi.MIDI, from, to uint8) (*midi.MIDI, error) {
+	return NewFilter().RemapChannel(from, to).Apply(m)
+}
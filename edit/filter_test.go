@@ -0,0 +1,148 @@
+package edit
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestStems(t *testing.T) *midi.MIDI {
+	tracks := [][]midi.TimedEvent{{}, {}, {}}
+	at := func(track int, tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracks[track] = append(tracks[track], midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0, 0)(event.NewSetTempoEvent(nil, 500000))
+	at(0, 1920)(event.NewEndOfTrackEvent(nil))
+
+	at(1, 0)(event.NewMIDIPortPrefixEvent(nil, 0))
+	at(1, 0)(event.NewMIDIChannelPrefixEvent(nil, 2))
+	at(1, 0)(event.NewInstrumentNameEvent(nil, []byte("Bass")))
+	at(1, 0)(event.NewNoteOnEvent(nil, 2, constant.C3, 100))
+	at(1, 0)(event.NewNoteOnEvent(nil, 3, constant.E3, 100))
+	at(1, 480)(event.NewNoteOffEvent(nil, 2, constant.C3, 0))
+	at(1, 960)(event.NewNoteOffEvent(nil, 3, constant.E3, 0))
+	at(1, 1920)(event.NewEndOfTrackEvent(nil))
+
+	at(2, 0)(event.NewNoteOnEvent(nil, 9, constant.C1, 100))
+	at(2, 240)(event.NewNoteOffEvent(nil, 9, constant.C1, 0))
+	at(2, 960)(event.NewSetTempoEvent(nil, 400000))
+	at(2, 1920)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{}
+	m.SetFormatType(1)
+	m.TimeDivision().SetBPM(480)
+
+	for _, tes := range tracks {
+		m.Tracks = append(m.Tracks, midi.NewTrackFromTimedEvents(tes))
+	}
+
+	return m
+}
+
+func TestExtractChannels(t *testing.T) {
+	m := newTestStems(t)
+
+	result, err := ExtractChannels(m, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks) != 3 {
+		t.Fatalf("expected: 3 tracks actual: %v tracks", len(result.Tracks))
+	}
+
+	assertTrack(t, result.Tracks[1], []uint32{0, 0, 960, 1920}, [][]byte{
+		{0xff, 0x21, 0x01, 0x00},
+		{0x93, byte(constant.E3), 100},
+		{0x83, byte(constant.E3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+	assertTrack(t, result.Tracks[2], []uint32{960, 1920}, [][]byte{
+		{0xff, 0x51, 0x03, 0x06, 0x1a, 0x80},
+		{0xff, 0x2f, 0x00},
+	})
+	if len(m.Tracks[1].Events) != 8 {
+		t.Fatalf("source must not be modified")
+	}
+}
+
+func TestExtractTracks(t *testing.T) {
+	result, err := ExtractTracks(newTestStems(t), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks) != 2 {
+		t.Fatalf("expected: 2 tracks actual: %v tracks", len(result.Tracks))
+	}
+
+	assertTrack(t, result.Tracks[1], []uint32{0, 240, 960, 1920}, [][]byte{
+		{0x99, byte(constant.C1), 100},
+		{0x89, byte(constant.C1), 0},
+		{0xff, 0x51, 0x03, 0x06, 0x1a, 0x80},
+		{0xff, 0x2f, 0x00},
+	})
+
+	// The conductor events of removed tracks are moved to the conductor track.
+	result, err = NewFilter().MuteTracks(2).Apply(newTestStems(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks) != 2 || len(result.Tracks[0].Events) != 3 {
+		t.Fatalf("tempo of removed track must be moved to the conductor track")
+	}
+}
+
+func TestFilter_Remap(t *testing.T) {
+	f := NewFilter().RemapChannel(2, 7).RemapPort(0, 1).MuteChannels(3, 9).SetEventFilter(func(e event.Event) bool {
+		_, ok := e.(*event.InstrumentNameEvent)
+		return !ok
+	})
+
+	result, err := f.Apply(newTestStems(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTrack(t, result.Tracks[1], []uint32{0, 0, 0, 480, 1920}, [][]byte{
+		{0xff, 0x21, 0x01, 0x01},
+		{0xff, 0x20, 0x01, 0x07},
+		{0x97, byte(constant.C3), 100},
+		{0x87, byte(constant.C3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+
+	if _, err := NewFilter().RemapChannel(2, 16).Apply(newTestStems(t)); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
+
+func TestFilter_zeroValue(t *testing.T) {
+	f := &Filter{}
+
+	result, err := f.MuteTracks(2).RemapPort(0, 1).RemapChannel(3, 4).Apply(newTestStems(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks) != 2 {
+		t.Fatalf("expected: 2 tracks actual: %v tracks", len(result.Tracks))
+	}
+
+	// The channels which aren't remapped stay as they are.
+	assertTrack(t, result.Tracks[1], []uint32{0, 0, 0, 0, 0, 480, 960, 1920}, [][]byte{
+		{0xff, 0x21, 0x01, 0x01},
+		{0xff, 0x20, 0x01, 0x02},
+		{0xff, 0x04, 0x04, 'B', 'a', 's', 's'},
+		{0x92, byte(constant.C3), 100},
+		{0x94, byte(constant.E3), 100},
+		{0x82, byte(constant.C3), 0},
+		{0x84, byte(constant.E3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+}
@@ -0,0 +1,411 @@
+package edit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Stats represents the size reduction of optimization.
+type Stats struct {
+	EventsBefore int
+	EventsAfter  int
+	BytesBefore  int
+	BytesAfter   int
+}
+
+// String returns string representation of stats.
+func (s Stats) String() string {
+	percent := 0.0
+	if s.BytesBefore > 0 {
+		percent = float64(s.BytesBefore-s.BytesAfter) * 100 / float64(s.BytesBefore)
+	}
+
+	return fmt.Sprintf("removed %v of %v events, %v of %v bytes (%.1f%%)", s.EventsBefore-s.EventsAfter, s.EventsBefore, s.BytesBefore-s.BytesAfter, s.BytesBefore, percent)
+}
+
+// Optimizer removes the events which don't change the state of channels and thins out continuous controller and pitch bend data.
+type Optimizer struct {
+	tolerance      float64
+	pitchTolerance float64
+	gap            uint32
+}
+
+// SetTolerance sets the maximum error in controller value (0 to 127) allowed by thinning.
+// The default is 0, which disables thinning of controllers.
+func (o *Optimizer) SetTolerance(tolerance float64) *Optimizer {
+	o.tolerance = tolerance
+
+	return o
+}
+
+// SetPitchBendTolerance sets the maximum error in 14-bit pitch (0 to 16383) allowed by thinning.
+// The default is 0, which disables thinning of pitch bends.
+func (o *Optimizer) SetPitchBendTolerance(tolerance float64) *Optimizer {
+	o.pitchTolerance = tolerance
+
+	return o
+}
+
+// SetGap sets the interval in ticks which splits a stream of controller into separate curves.
+// The first and last events of each curve are always kept. The default is 0, which means a quarter note.
+func (o *Optimizer) SetGap(gap uint32) *Optimizer {
+	o.gap = gap
+
+	return o
+}
+
+type item struct {
+	track int
+	tick  uint32
+	event event.Event
+	drop  bool
+}
+
+type streamKey struct {
+	channel uint8
+	control int
+}
+
+// pitchBendStream is the control of stream key for pitch bend.
+const pitchBendStream = -1
+
+// isContinuous reports whether the controller is thinned out. The switches, the LSBs of 14-bit controllers,
+// bank select, data entry, parameter numbers and the channel mode messages are never thinned out.
+// The MSBs whose LSBs are used are not thinned out either by thin, so that the pairs of 14-bit value are kept.
+func isContinuous(control constant.Control) bool {
+	switch {
+	case control == constant.BankSelect || control == constant.DataEntry:
+		return false
+	case control < 0x20:
+		return true
+	case control >= 0x46 && control < 0x60:
+		return true
+	}
+
+	return false
+}
+
+// Optimize returns the optimized copy of MIDI data and the stats.
+//
+// The redundant controller, program change, pitch bend and channel after touch events, which set the same value
+// as the current one, are removed. The program change after bank select is always kept.
+// Then the continuous controllers and pitch bends are thinned out with Ramer-Douglas-Peucker algorithm
+// on value over time if the tolerance is set.
+func (o *Optimizer) Optimize(m *midi.MIDI) (*midi.MIDI, Stats, error) {
+	items := []*item{}
+
+	for i, track := range m.Tracks {
+		for _, te := range track.TimedEvents() {
+			items = append(items, &item{track: i, tick: te.Tick, event: te.Event})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].tick < items[j].tick
+	})
+
+	removeRedundant(items)
+
+	gap := o.gap
+	if gap == 0 {
+		ticksPerQuarterNote, err := m.TimeDivision().BPM()
+		if err != nil {
+			return nil, Stats{}, err
+		}
+		gap = uint32(ticksPerQuarterNote)
+	}
+
+	o.thin(items, gap)
+
+	stats := Stats{}
+	tracks := make([][]midi.TimedEvent, len(m.Tracks))
+	ends := make([]midi.TimedEvent, len(m.Tracks))
+
+	for _, it := range items {
+		stats.EventsBefore++
+
+		if it.drop {
+			continue
+		}
+
+		stats.EventsAfter++
+
+		if _, ok := it.event.(*event.EndOfTrackEvent); ok {
+			ends[it.track] = midi.TimedEvent{Tick: it.tick, Event: it.event}
+			continue
+		}
+
+		tracks[it.track] = append(tracks[it.track], midi.TimedEvent{Tick: it.tick, Event: it.event})
+	}
+
+	result := &midi.MIDI{}
+	timeDivision := *m.TimeDivision()
+
+	result.SetTimeDivision(&timeDivision)
+
+	if err := result.SetFormatType(m.FormatType()); err != nil {
+		return nil, Stats{}, err
+	}
+
+	for i, tes := range tracks {
+		copied := cloneEvents(tes)
+		if ends[i].Event != nil {
+			eot, err := event.NewEndOfTrackEvent(nil)
+			if err != nil {
+				return nil, Stats{}, err
+			}
+			copied = append(copied, midi.TimedEvent{Tick: ends[i].Tick, Event: eot})
+		}
+
+		result.Tracks = append(result.Tracks, midi.NewTrackFromTimedEvents(copied))
+	}
+
+	// The sizes are of the serialized tracks, which include the delta times and running status.
+	var buf []byte
+
+	for _, track := range m.Tracks {
+		buf = track.AppendTo(buf[:0])
+		stats.BytesBefore += len(buf)
+	}
+	for _, track := range result.Tracks {
+		buf = track.AppendTo(buf[:0])
+		stats.BytesAfter += len(buf)
+	}
+
+	return result, stats, nil
+}
+
+// removeRedundant marks the events which don't change the state of channels.
+func removeRedundant(items []*item) {
+	type channelState struct {
+		controllers map[constant.Control]uint8
+		program     int
+		bank        bool
+		pitch       int
+		pressure    int
+	}
+
+	channels := [16]channelState{}
+
+	for i := range channels {
+		channels[i] = channelState{controllers: map[constant.Control]uint8{}, program: -1, pitch: -1, pressure: -1}
+	}
+
+	for _, it := range items {
+		switch v := it.event.(type) {
+		case *event.ControllerEvent:
+			c := &channels[v.Channel()]
+			control := v.Control()
+
+			switch {
+			case control == constant.DataEntry || control == constant.DataEntryLSB:
+				continue
+			case control >= constant.DataIncrement && control <= constant.RegisteredParameterNumberMSB:
+				continue
+			case control == constant.ResetAllControllers:
+				// The controllers are reset to their default values, which are not tracked.
+				for control := range c.controllers {
+					if _, ok := control.ResetValue(); ok {
+						delete(c.controllers, control)
+					}
+				}
+				c.pitch = -1
+				c.pressure = -1
+				continue
+			case control.IsChannelMode():
+				continue
+			}
+			if control == constant.BankSelect || control == constant.BankSelectLSB {
+				c.bank = true
+			}
+
+			value, ok := c.controllers[control]
+
+			// The MSB of 14-bit controller resets the LSB, so that it's not redundant if the LSB is not 0.
+			if lsb, isMSB := control.LSB(); isMSB && c.controllers[lsb] != 0 {
+				ok = false
+				c.controllers[lsb] = 0
+			}
+			if ok && value == v.Value() {
+				it.drop = true
+				continue
+			}
+
+			c.controllers[control] = v.Value()
+		case *event.ProgramChangeEvent:
+			c := &channels[v.Channel()]
+
+			if c.program == int(v.Program()) && !c.bank {
+				it.drop = true
+				continue
+			}
+
+			c.program = int(v.Program())
+			c.bank = false
+		case *event.PitchBendEvent:
+			c := &channels[v.Channel()]
+
+			if c.pitch == int(v.Pitch()) {
+				it.drop = true
+				continue
+			}
+
+			c.pitch = int(v.Pitch())
+		case *event.ChannelAfterTouchEvent:
+			c := &channels[v.Channel()]
+
+			if c.pressure == int(v.Velocity()) {
+				it.drop = true
+				continue
+			}
+
+			c.pressure = int(v.Velocity())
+		}
+	}
+}
+
+// thin marks the events of continuous controllers and pitch bends which are not needed to keep the curve within the tolerance.
+func (o *Optimizer) thin(items []*item, gap uint32) {
+	streams := map[streamKey][]*item{}
+	keys := []streamKey{}
+
+	// The LSBs used on each channel. Thinning the MSB alone pairs the kept LSB with a wrong MSB.
+	lsbs := map[streamKey]bool{}
+
+	for _, it := range items {
+		if v, ok := it.event.(*event.ControllerEvent); ok && v.Control().IsLSB() {
+			lsbs[streamKey{v.Channel(), int(v.Control())}] = true
+		}
+	}
+	for _, it := range items {
+		if it.drop {
+			continue
+		}
+
+		var key streamKey
+
+		switch v := it.event.(type) {
+		case *event.ControllerEvent:
+			if o.tolerance <= 0 || !isContinuous(v.Control()) {
+				continue
+			}
+			if lsb, ok := v.Control().LSB(); ok && lsbs[streamKey{v.Channel(), int(lsb)}] {
+				continue
+			}
+			key = streamKey{v.Channel(), int(v.Control())}
+		case *event.PitchBendEvent:
+			if o.pitchTolerance <= 0 {
+				continue
+			}
+			key = streamKey{v.Channel(), pitchBendStream}
+		default:
+			continue
+		}
+
+		if _, ok := streams[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		streams[key] = append(streams[key], it)
+	}
+
+	for _, key := range keys {
+		stream := streams[key]
+		tolerance := o.tolerance
+
+		if key.control == pitchBendStream {
+			tolerance = o.pitchTolerance
+		}
+
+		start := 0
+
+		for i := 1; i <= len(stream); i++ {
+			if i < len(stream) && stream[i].tick-stream[i-1].tick <= gap {
+				continue
+			}
+
+			curve := stream[start:i]
+			keep := decimate(curve, tolerance)
+
+			for j, it := range curve {
+				it.drop = !keep[j]
+			}
+
+			start = i
+		}
+	}
+}
+
+func streamValue(e event.Event) float64 {
+	switch v := e.(type) {
+	case *event.ControllerEvent:
+		return float64(v.Value())
+	case *event.PitchBendEvent:
+		return float64(v.Pitch())
+	}
+
+	return 0
+}
+
+// decimate returns which points of curve are kept by Ramer-Douglas-Peucker algorithm.
+// The error is measured as the difference of value from the line at the same tick. The endpoints are always kept.
+func decimate(curve []*item, tolerance float64) []bool {
+	keep := make([]bool, len(curve))
+
+	if len(curve) == 0 {
+		return keep
+	}
+
+	keep[0] = true
+	keep[len(curve)-1] = true
+
+	var simplify func(first, last int)
+
+	simplify = func(first, last int) {
+		if last-first < 2 {
+			return
+		}
+
+		x1, y1 := float64(curve[first].tick), streamValue(curve[first].event)
+		x2, y2 := float64(curve[last].tick), streamValue(curve[last].event)
+
+		index, maxError := -1, 0.0
+
+		for i := first + 1; i < last; i++ {
+			x, y := float64(curve[i].tick), streamValue(curve[i].event)
+			expected := y1
+			if x2 != x1 {
+				expected = y1 + (y2-y1)*(x-x1)/(x2-x1)
+			}
+			if e := math.Abs(y - expected); e > maxError {
+				index, maxError = i, e
+			}
+		}
+		if index < 0 || maxError <= tolerance {
+			return
+		}
+
+		keep[index] = true
+		simplify(first, index)
+		simplify(index, last)
+	}
+
+	simplify(0, len(curve)-1)
+
+	return keep
+}
+
+// NewOptimizer returns Optimizer which removes only the redundant events.
+func NewOptimizer() *Optimizer {
+	return &Optimizer{}
+}
+
+// Optimize returns the copy of MIDI data without the redundant events with the default Optimizer.
+func Optimize(m *midi.MIDI) (*midi.MIDI, Stats, error) {
+	return NewOptimizer().Optimize(m)
+}
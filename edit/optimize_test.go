@@ -0,0 +1,187 @@
+package edit
+
+import (
+	"strings"
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestRecording(t *testing.T) *midi.MIDI {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0)(event.NewControllerEvent(nil, 0, constant.MainVolume, 100))
+	at(0)(event.NewProgramChangeEvent(nil, 0, constant.Violin))
+	at(10)(event.NewControllerEvent(nil, 0, constant.MainVolume, 100))
+	at(20)(event.NewProgramChangeEvent(nil, 0, constant.Violin))
+	at(30)(event.NewControllerEvent(nil, 0, constant.BankSelect, 0))
+	at(31)(event.NewProgramChangeEvent(nil, 0, constant.Violin))
+	at(40)(event.NewPitchBendEvent(nil, 0, 0x2000))
+	at(50)(event.NewPitchBendEvent(nil, 0, 0x2000))
+	at(60)(event.NewControllerEvent(nil, 1, constant.MainVolume, 100))
+
+	for i := 0; i < 128; i++ {
+		at(uint32(100 + i))(event.NewControllerEvent(nil, 0, constant.Modulation, uint8(i)))
+	}
+	for i := 0; i < 64; i++ {
+		at(uint32(300 + i))(event.NewPitchBendEvent(nil, 0, uint16(0x2000+i*64)))
+	}
+
+	at(1920)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+	m.TimeDivision().SetBPM(480)
+
+	return m
+}
+
+func TestOptimize(t *testing.T) {
+	m := newTestRecording(t)
+	before := len(m.Tracks[0].Events)
+
+	result, stats, err := Optimize(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tracks[0].Events) != before {
+		t.Fatalf("source must not be modified")
+	}
+
+	expected := before - 4
+
+	if len(result.Tracks[0].Events) != expected {
+		t.Fatalf("expected: %v events actual: %v events", expected, len(result.Tracks[0].Events))
+	}
+	// The removed events and their delta times.
+	if stats.EventsBefore != before || stats.EventsAfter != expected || stats.BytesBefore-stats.BytesAfter != 3+2+3+3+4 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if stats.BytesBefore != len(m.Tracks[0].Serialize()) || stats.BytesAfter != len(result.Tracks[0].Serialize()) {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if !strings.HasPrefix(stats.String(), "removed 4 of 202 events, 15 of") {
+		t.Fatalf("unexpected stats: %v", stats)
+	}
+}
+
+func TestOptimize_resetAllControllers(t *testing.T) {
+	es := []event.Event{}
+	add := func(e event.Event, err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		es = append(es, e)
+	}
+
+	add(event.NewControllerEvent(nil, 0, constant.Expression, 80))
+	add(event.NewChannelModeEvent(nil, 0, constant.ResetAllControllers, 0))
+	add(event.NewControllerEvent(nil, 0, constant.Expression, 80))
+	add(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrack(es...)}}
+	m.TimeDivision().SetBPM(480)
+
+	_, stats, err := Optimize(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.EventsAfter != stats.EventsBefore {
+		t.Fatalf("the expression after reset all controllers must be kept: %v", stats)
+	}
+}
+
+func TestOptimizer_SetTolerance(t *testing.T) {
+	result, _, err := NewOptimizer().SetTolerance(1).SetPitchBendTolerance(64).Optimize(newTestRecording(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modulations := []uint32{}
+	pitches := []uint32{}
+
+	for _, te := range result.Tracks[0].TimedEvents() {
+		switch v := te.Event.(type) {
+		case *event.ControllerEvent:
+			if v.Control() == constant.Modulation {
+				modulations = append(modulations, te.Tick)
+			}
+		case *event.PitchBendEvent:
+			pitches = append(pitches, te.Tick)
+		}
+	}
+
+	if len(modulations) != 2 || modulations[0] != 100 || modulations[1] != 227 {
+		t.Fatalf("unexpected modulations: %v", modulations)
+	}
+	if len(pitches) != 3 || pitches[0] != 40 || pitches[1] != 301 || pitches[2] != 363 {
+		t.Fatalf("unexpected pitch bends: %v", pitches)
+	}
+}
+
+func TestOptimizer_SetTolerance_14bit(t *testing.T) {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	// The 14-bit modulation ramp, whose MSB alone is thinned out within the tolerance.
+	for i := 0; i < 64; i++ {
+		value := i * 200
+		at(uint32(i * 2))(event.NewControllerEvent(nil, 0, constant.Modulation, uint8(value>>7)))
+		at(uint32(i*2 + 1))(event.NewControllerEvent(nil, 0, constant.ModulationLSB, uint8(value&0x7f)))
+	}
+
+	at(1920)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+	m.TimeDivision().SetBPM(480)
+
+	result, _, err := NewOptimizer().SetTolerance(1).Optimize(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msbs := 0
+
+	for _, e := range result.Tracks[0].Events {
+		if v, ok := e.(*event.ControllerEvent); ok && v.Control() == constant.Modulation {
+			msbs++
+		}
+	}
+	if msbs != 64 {
+		t.Fatalf("the MSBs paired with LSBs must be kept: expected: 64 actual: %v", msbs)
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	curve := []*item{}
+
+	for i, value := range []uint8{0, 10, 20, 30, 40, 0, 0} {
+		e, _ := event.NewControllerEvent(nil, 0, constant.Expression, value)
+		curve = append(curve, &item{tick: uint32(i * 10), event: e})
+	}
+
+	expected := []bool{true, false, false, false, true, true, true}
+	actual := decimate(curve, 0.5)
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected: %v actual: %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+/*
+Package edit provides operations which transform MIDI data, such as slicing a range of song.
+The source MIDI data is never modified by the operations.
+*/
+package edit
+
+import (
+	"fmt"
+	"sort"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/chase"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// NoteMode represents how to handle the notes which cross the boundaries of range.
+type NoteMode uint8
+
+const (
+	// Truncate cuts the notes at the boundaries. The notes which started before the range
+	// are restarted at the beginning, and the notes which don't end in the range are stopped at the end.
+	Truncate NoteMode = iota
+
+	// Drop removes the notes which cross the boundaries.
+	Drop
+)
+
+// Slicer cuts a range of song. The zero value is ready to use.
+type Slicer struct {
+	notes   NoteMode
+	noChase bool
+}
+
+// SetNoteMode sets how to handle the notes which cross the boundaries. The default is Truncate.
+func (s *Slicer) SetNoteMode(mode NoteMode) *Slicer {
+	s.notes = mode
+
+	return s
+}
+
+// SetChase sets whether the state in effect at the beginning of range is injected. The default is true.
+func (s *Slicer) SetChase(chase bool) *Slicer {
+	s.noChase = !chase
+
+	return s
+}
+
+// Slice returns the events from start (inclusive) to end (exclusive) of all tracks as a standalone MIDI.
+// The tempo, time signature, key signature, track name, port, device name and the state of channels in effect at start
+// are injected at the beginning of each track, and each track is terminated with an end of track event at end.
+func (s *Slicer) Slice(m *midi.MIDI, start, end uint32) (*midi.MIDI, error) {
+	if start >= end {
+		return nil, fmt.Errorf("midi: start must be less than end")
+	}
+
+	result := &midi.MIDI{}
+	timeDivision := *m.TimeDivision()
+
+	result.SetTimeDivision(&timeDivision)
+
+	if err := result.SetFormatType(m.FormatType()); err != nil {
+		return nil, err
+	}
+
+	for _, track := range m.Tracks {
+		t, err := s.sliceTrack(track, start, end)
+		if err != nil {
+			return nil, err
+		}
+		result.Tracks = append(result.Tracks, t)
+	}
+
+	return result, nil
+}
+
+// SliceBars returns the bars from first to last (inclusive) as a standalone MIDI. The bar starts from 1.
+func (s *Slicer) SliceBars(m *midi.MIDI, first, last int) (*midi.MIDI, error) {
+	if first > last {
+		return nil, fmt.Errorf("midi: first bar must not be greater than last bar")
+	}
+
+	start, err := m.BarTick(first)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := m.BarTick(last + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Slice(m, start, end)
+}
+
+type noteKey struct {
+	channel uint8
+	note    constant.Note
+}
+
+// setupType returns the key which identifies the kind of meta event injected at the beginning of range.
+func setupType(e event.Event) (uint8, bool) {
+	switch e.(type) {
+	case *event.SetTempoEvent:
+		return constant.SetTempo, true
+	case *event.TimeSignatureEvent:
+		return constant.TimeSignature, true
+	case *event.KeySignatureEvent:
+		return constant.KeySignature, true
+	case *event.SequenceOrTrackNameEvent:
+		return constant.SequenceOrTrackName, true
+	case *event.InstrumentNameEvent:
+		return constant.InstrumentName, true
+	case *event.MIDIPortPrefixEvent:
+		return constant.MIDIPortPrefix, true
+	case *event.DeviceNameEvent:
+		return constant.DeviceName, true
+	}
+
+	return 0, false
+}
+
+func noteOff(e event.Event) (noteKey, bool) {
+	switch v := e.(type) {
+	case *event.NoteOffEvent:
+		return noteKey{v.Channel(), v.Note()}, true
+	case *event.NoteOnEvent:
+		if v.Velocity() == 0 {
+			return noteKey{v.Channel(), v.Note()}, true
+		}
+	}
+
+	return noteKey{}, false
+}
+
+func (s *Slicer) sliceTrack(track *midi.Track, start, end uint32) (*midi.Track, error) {
+	tracker := chase.NewTracker()
+	setups := map[uint8]midi.TimedEvent{}
+	setupOrder := []uint8{}
+	inRange := []midi.TimedEvent{}
+
+	for _, te := range track.TimedEvents() {
+		if _, ok := te.Event.(*event.EndOfTrackEvent); ok {
+			break
+		}
+		if te.Tick < start {
+			tracker.Apply(te.Tick, te.Event)
+
+			if key, ok := setupType(te.Event); ok {
+				if _, ok := setups[key]; !ok {
+					setupOrder = append(setupOrder, key)
+				}
+				setups[key] = te
+			}
+			continue
+		}
+		if te.Tick < end {
+			inRange = append(inRange, midi.TimedEvent{Tick: te.Tick - start, Event: te.Event})
+			continue
+		}
+
+		if te.Tick > end {
+			break
+		}
+
+		// The note offs at end are kept, so that the notes which end exactly at end are not treated as crossing.
+		if _, ok := noteOff(te.Event); ok {
+			inRange = append(inRange, midi.TimedEvent{Tick: end - start, Event: te.Event})
+		}
+	}
+
+	tes := []midi.TimedEvent{}
+
+	if !s.noChase {
+		overridden := map[uint8]bool{}
+		for _, te := range inRange {
+			if te.Tick > 0 {
+				break
+			}
+			if key, ok := setupType(te.Event); ok {
+				overridden[key] = true
+			}
+		}
+		for _, key := range setupOrder {
+			if !overridden[key] {
+				tes = append(tes, midi.TimedEvent{Tick: 0, Event: setups[key].Event})
+			}
+		}
+	}
+
+	copied := cloneEvents(append(tes, inRange...))
+
+	tes, inRange = append([]midi.TimedEvent{}, copied[:len(tes)]...), copied[len(tes):]
+
+	snapshot := tracker.Snapshot()
+
+	if !s.noChase {
+		es, err := snapshot.Events()
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			tes = append(tes, midi.TimedEvent{Tick: 0, Event: e})
+		}
+	}
+
+	// The number of notes which started before the range and are still sounding.
+	pending := map[noteKey]int{}
+
+	for channel := range snapshot.Channels {
+		for _, n := range snapshot.Channels[channel].Notes {
+			pending[noteKey{uint8(channel), n.Note}]++
+		}
+	}
+	if s.notes == Truncate {
+		es, err := snapshot.NoteEvents()
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range es {
+			tes = append(tes, midi.TimedEvent{Tick: 0, Event: e})
+		}
+	}
+
+	offset := len(tes)
+	dropped := map[int]bool{}
+	active := map[noteKey][]int{}
+
+	for i, te := range inRange {
+		if key, ok := noteOff(te.Event); ok {
+			if pending[key] > 0 {
+				pending[key]--
+				if s.notes == Drop {
+					dropped[offset+i] = true
+				}
+			} else if n := len(active[key]); n > 0 {
+				active[key] = active[key][1:]
+			} else if te.Tick == end-start {
+				dropped[offset+i] = true
+			}
+		} else if v, ok := te.Event.(*event.NoteOnEvent); ok {
+			key := noteKey{v.Channel(), v.Note()}
+			active[key] = append(active[key], offset+i)
+		} else if v, ok := te.Event.(*event.ControllerEvent); ok && v.Control().EndsNotes() {
+			// All notes off ends the notes of channel, including the ones which started before the range.
+			for key := range active {
+				if key.channel == v.Channel() {
+					delete(active, key)
+				}
+			}
+			for key := range pending {
+				if key.channel == v.Channel() {
+					delete(pending, key)
+				}
+			}
+		}
+
+		tes = append(tes, te)
+	}
+
+	crossing := []int{}
+	for _, indices := range active {
+		crossing = append(crossing, indices...)
+	}
+	sort.Ints(crossing)
+
+	for _, index := range crossing {
+		if s.notes == Drop {
+			dropped[index] = true
+			continue
+		}
+
+		v := tes[index].Event.(*event.NoteOnEvent)
+		e, err := event.NewNoteOffEvent(nil, v.Channel(), v.Note(), 0)
+		if err != nil {
+			return nil, err
+		}
+		tes = append(tes, midi.TimedEvent{Tick: end - start, Event: e})
+	}
+
+	// The notes which started before the range are restarted at the beginning in Truncate mode, so that the ones
+	// still sounding at end are stopped there too.
+	if s.notes == Truncate {
+		keys := []noteKey{}
+		for key, count := range pending {
+			if count > 0 {
+				keys = append(keys, key)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].channel != keys[j].channel {
+				return keys[i].channel < keys[j].channel
+			}
+			return keys[i].note < keys[j].note
+		})
+
+		for _, key := range keys {
+			for i := 0; i < pending[key]; i++ {
+				e, err := event.NewNoteOffEvent(nil, key.channel, key.note, 0)
+				if err != nil {
+					return nil, err
+				}
+				tes = append(tes, midi.TimedEvent{Tick: end - start, Event: e})
+			}
+		}
+	}
+
+	result := []midi.TimedEvent{}
+
+	for i, te := range tes {
+		if !dropped[i] {
+			result = append(result, te)
+		}
+	}
+
+	eot, err := event.NewEndOfTrackEvent(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result = append(result, midi.TimedEvent{Tick: end - start, Event: eot})
+
+	return midi.NewTrackFromTimedEvents(result), nil
+}
+
+// NewSlicer returns Slicer.
+func NewSlicer() *Slicer {
+	return &Slicer{
+		notes: Truncate,
+	}
+}
+
+// Slice returns the range of song from start to end with the default Slicer.
+func Slice(m *midi.MIDI, start, end uint32) (*midi.MIDI, error) {
+	return NewSlicer().Slice(m, start, end)
+}
+
+// SliceBars returns the bars from first to last with the default Slicer.
+func SliceBars(m *midi.MIDI, first, last int) (*midi.MIDI, error) {
+	return NewSlicer().SliceBars(m, first, last)
+}
@@ -0,0 +1,185 @@
+package edit
+
+import (
+	"reflect"
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newTestMIDI(t *testing.T) *midi.MIDI {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0)(event.NewSetTempoEvent(nil, 500000))
+	at(0)(event.NewProgramChangeEvent(nil, 0, constant.Violin))
+	at(1800)(event.NewNoteOnEvent(nil, 0, constant.C3, 100))
+	at(2000)(event.NewNoteOffEvent(nil, 0, constant.C3, 0))
+	at(2400)(event.NewNoteOnEvent(nil, 0, constant.E3, 100))
+	at(3000)(event.NewNoteOnEvent(nil, 0, constant.G3, 100))
+	at(3840)(event.NewNoteOnEvent(nil, 0, constant.E3, 0))
+	at(4000)(event.NewNoteOffEvent(nil, 0, constant.G3, 0))
+	at(4000)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+	m.TimeDivision().SetBPM(480)
+
+	return m
+}
+
+func assertTrack(t *testing.T, track *midi.Track, expectedTicks []uint32, expected [][]byte) {
+	tes := track.TimedEvents()
+
+	if len(expected) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(tes))
+	}
+	for i, te := range tes {
+		if expectedTicks[i] != te.Tick {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expectedTicks[i], i, te.Tick)
+		}
+		if actual := te.Event.Serialize(); !reflect.DeepEqual(expected[i], actual) {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected[i], i, actual)
+		}
+	}
+}
+
+func TestSlice(t *testing.T) {
+	m := newTestMIDI(t)
+	source := m.Serialize()
+
+	result, err := Slice(m, 1920, 3840)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(source, m.Serialize()) {
+		t.Fatalf("source must not be modified")
+	}
+
+	assertTrack(t, result.Tracks[0], []uint32{0, 0, 0, 80, 480, 1080, 1920, 1920, 1920}, [][]byte{
+		{0xff, 0x51, 0x03, 0x07, 0xa1, 0x20},
+		{0xc0, byte(constant.Violin)},
+		{0x90, byte(constant.C3), 100},
+		{0x80, byte(constant.C3), 0},
+		{0x90, byte(constant.E3), 100},
+		{0x90, byte(constant.G3), 100},
+		{0x90, byte(constant.E3), 0},
+		{0x80, byte(constant.G3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+
+	if bpm, _ := result.TimeDivision().BPM(); bpm != 480 {
+		t.Fatalf("expected: 480 actual: %v", bpm)
+	}
+	if _, err := Slice(m, 100, 100); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
+
+func TestSlicer_SetNoteMode(t *testing.T) {
+	m := newTestMIDI(t)
+
+	// The zero value chases the state as well as NewSlicer.
+	for _, s := range []*Slicer{NewSlicer(), {}} {
+		result, err := s.SetNoteMode(Drop).SliceBars(m, 2, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assertTrack(t, result.Tracks[0], []uint32{0, 0, 480, 1920, 1920}, [][]byte{
+			{0xff, 0x51, 0x03, 0x07, 0xa1, 0x20},
+			{0xc0, byte(constant.Violin)},
+			{0x90, byte(constant.E3), 100},
+			{0x90, byte(constant.E3), 0},
+			{0xff, 0x2f, 0x00},
+		})
+	}
+}
+
+func TestSlicer_SetChase(t *testing.T) {
+	m := newTestMIDI(t)
+
+	result, err := NewSlicer().SetChase(false).SetNoteMode(Drop).Slice(m, 1920, 3840)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertTrack(t, result.Tracks[0], []uint32{480, 1920, 1920}, [][]byte{
+		{0x90, byte(constant.E3), 100},
+		{0x90, byte(constant.E3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+}
+
+func TestSlice_heldNote(t *testing.T) {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0)(event.NewNoteOnEvent(nil, 0, constant.C3, 100))
+	at(4000)(event.NewNoteOffEvent(nil, 0, constant.C3, 0))
+	at(4000)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+	m.TimeDivision().SetBPM(480)
+
+	result, err := Slice(m, 1920, 3840)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The note which spans the whole range is restarted at the beginning and stopped at the end.
+	assertTrack(t, result.Tracks[0], []uint32{0, 1920, 1920}, [][]byte{
+		{0x90, byte(constant.C3), 100},
+		{0x80, byte(constant.C3), 0},
+		{0xff, 0x2f, 0x00},
+	})
+}
+
+func TestSlice_allNotesOff(t *testing.T) {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0)(event.NewNoteOnEvent(nil, 0, constant.C3, 100))
+	at(2400)(event.NewNoteOnEvent(nil, 0, constant.G3, 100))
+	at(3000)(event.NewChannelModeEvent(nil, 0, constant.AllNotesOff, 0))
+	at(4000)(event.NewNoteOffEvent(nil, 0, constant.C3, 0))
+	at(4000)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+	m.TimeDivision().SetBPM(480)
+
+	result, err := Slice(m, 1920, 3840)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// No note off is added at the end because all notes off ends both of the notes.
+	assertTrack(t, result.Tracks[0], []uint32{0, 480, 1080, 1920}, [][]byte{
+		{0x90, byte(constant.C3), 100},
+		{0x90, byte(constant.G3), 100},
+		{0xb0, byte(constant.AllNotesOff), 0},
+		{0xff, 0x2f, 0x00},
+	})
+}
@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// ActiveSensingEvent corresponds to active sensing message, which is system real-time message sent only on the wire.
+type ActiveSensingEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of active sensing event.
+func (e *ActiveSensingEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns ActiveSensing.
+func (e *ActiveSensingEvent) Kind() Kind {
+	return ActiveSensing
+}
+
+// AppendTo appends serialized active sensing event to dst and returns the extended buffer.
+func (e *ActiveSensingEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.ActiveSensing)
+}
+
+// Serialize serializes active sensing event.
+func (e *ActiveSensingEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized active sensing event.
+func (e *ActiveSensingEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the active sensing event has no data bytes.
+func (e *ActiveSensingEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the active sensing event has no data bytes.
+func (e *ActiveSensingEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of active sensing event.
+func (e *ActiveSensingEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same active sensing event. The encoding of delta time is ignored.
+func (e *ActiveSensingEvent) Equal(other Event) bool {
+	v, ok := other.(*ActiveSensingEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of active sensing event.
+func (e *ActiveSensingEvent) String() string {
+	return "&ActiveSensingEvent{}"
+}
+
+// NewActiveSensingEvent returns ActiveSensingEvent with the given parameter.
+func NewActiveSensingEvent(deltaTime *deltatime.DeltaTime) (*ActiveSensingEvent, error) {
+	event := &ActiveSensingEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
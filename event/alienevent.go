@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,7 +11,7 @@ import (
 
 // AlienEvent represents unknown meta event.
 type AlienEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	metaEventType uint8
 	data          []byte
@@ -18,23 +19,31 @@ type AlienEvent struct {
 
 // deltatime.DeltaTime returns delta time.
 func (e *AlienEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns Alien.
+func (e *AlienEvent) Kind() Kind {
+	return Alien
+}
+
+// AppendTo appends serialized alien event to dst and returns the extended buffer.
+func (e *AlienEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, e.metaEventType)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.data)))
+	dst = append(dst, e.data...)
+
+	return dst
 }
 
 // Serialize serializes alien event.
 func (e *AlienEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, e.metaEventType)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Data())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Data()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized alien event.
+func (e *AlienEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.data))) + len(e.data)
 }
 
 // SetRunningStatus sets running status.
@@ -77,6 +86,24 @@ func (e *AlienEvent) Data() []byte {
 	return e.data
 }
 
+// Clone returns a deep copy of alien event.
+func (e *AlienEvent) Clone() Event {
+	clone := *e
+	clone.data = cloneBytes(e.data)
+
+	return &clone
+}
+
+// Equal reports whether other is the same alien event. The running status and the encoding of delta time are ignored.
+func (e *AlienEvent) Equal(other Event) bool {
+	v, ok := other.(*AlienEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.metaEventType == v.metaEventType && bytes.Equal(e.data, v.data)
+}
+
 // String returns string representation of alien event.
 func (e *AlienEvent) String() string {
 	return fmt.Sprintf("&AlienEvent{metaEventType: 0x%x, data: %v bytes}", e.metaEventType, len(e.Data()))
@@ -85,7 +112,9 @@ func (e *AlienEvent) String() string {
 // NewAlienEvent returns AlienEvent with the given parameter.
 func NewAlienEvent(deltaTime *deltatime.DeltaTime, metaEventType uint8, data []byte) (*AlienEvent, error) {
 	event := &AlienEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 	event.metaEventType = metaEventType
 
 	err := event.SetData(data)
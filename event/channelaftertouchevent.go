@@ -9,7 +9,7 @@ import (
 
 // ChannelAfterTouchEvent corresponds to channel after touch event.
 type ChannelAfterTouchEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	velocity      uint8
@@ -17,19 +17,30 @@ type ChannelAfterTouchEvent struct {
 
 // deltatime.DeltaTime returns delta time of channel after touch event.
 func (e *ChannelAfterTouchEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns ChannelAfterTouch.
+func (e *ChannelAfterTouchEvent) Kind() Kind {
+	return ChannelAfterTouch
+}
+
+// AppendTo appends serialized channel after touch event to dst and returns the extended buffer.
+func (e *ChannelAfterTouchEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.ChannelAfterTouch+e.channel)
+	dst = append(dst, e.velocity)
+
+	return dst
 }
 
 // Serialize serializes channel after touch event.
 func (e *ChannelAfterTouchEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.ChannelAfterTouch+e.channel)
-	bs = append(bs, e.velocity)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized channel after touch event.
+func (e *ChannelAfterTouchEvent) Len() int {
+	return 2
 }
 
 // SetRunningStatus sets running status.
@@ -72,6 +83,23 @@ func (e *ChannelAfterTouchEvent) Velocity() uint8 {
 	return e.velocity
 }
 
+// Clone returns a deep copy of channel after touch event.
+func (e *ChannelAfterTouchEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same channel after touch event. The running status and the encoding of delta time are ignored.
+func (e *ChannelAfterTouchEvent) Equal(other Event) bool {
+	v, ok := other.(*ChannelAfterTouchEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.velocity == v.velocity
+}
+
 // String returns string representation of channel after touch event.
 func (e *ChannelAfterTouchEvent) String() string {
 	return fmt.Sprintf("&ChannelAfterTouchEvent{channel: %v, velocity: %v}", e.channel, e.velocity)
@@ -82,7 +110,9 @@ func NewChannelAfterTouchEvent(deltaTime *deltatime.DeltaTime, channel, velocity
 	var err error
 
 	event := &ChannelAfterTouchEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
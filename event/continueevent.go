@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// ContinueEvent corresponds to continue message, which is system real-time message sent only on the wire.
+type ContinueEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of continue event.
+func (e *ContinueEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns Continue.
+func (e *ContinueEvent) Kind() Kind {
+	return Continue
+}
+
+// AppendTo appends serialized continue event to dst and returns the extended buffer.
+func (e *ContinueEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.Continue)
+}
+
+// Serialize serializes continue event.
+func (e *ContinueEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized continue event.
+func (e *ContinueEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the continue event has no data bytes.
+func (e *ContinueEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the continue event has no data bytes.
+func (e *ContinueEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of continue event.
+func (e *ContinueEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same continue event. The encoding of delta time is ignored.
+func (e *ContinueEvent) Equal(other Event) bool {
+	v, ok := other.(*ContinueEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of continue event.
+func (e *ContinueEvent) String() string {
+	return "&ContinueEvent{}"
+}
+
+// NewContinueEvent returns ContinueEvent with the given parameter.
+func NewContinueEvent(deltaTime *deltatime.DeltaTime) (*ContinueEvent, error) {
+	event := &ContinueEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
@@ -9,7 +9,7 @@ import (
 
 // ControllerEvent corresponds to controller event.
 type ControllerEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	control       constant.Control
@@ -18,19 +18,30 @@ type ControllerEvent struct {
 
 // deltatime.DeltaTime returns delta time of controller event.
 func (e *ControllerEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns Controller.
+func (e *ControllerEvent) Kind() Kind {
+	return Controller
+}
+
+// AppendTo appends serialized controller event to dst and returns the extended buffer.
+func (e *ControllerEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Controller+e.channel)
+	dst = append(dst, byte(e.control), e.value)
+
+	return dst
 }
 
 // Serialize serializes controller event.
 func (e *ControllerEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Controller+e.channel)
-	bs = append(bs, byte(e.control), e.value)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized controller event.
+func (e *ControllerEvent) Len() int {
+	return 3
 }
 
 // SetRunningStatus sets running status.
@@ -88,6 +99,47 @@ func (e *ControllerEvent) Value() uint8 {
 	return e.value
 }
 
+// IsChannelMode reports whether the event is channel mode message, e.g. all notes off.
+func (e *ControllerEvent) IsChannelMode() bool {
+	return e.control.IsChannelMode()
+}
+
+// LocalControl returns whether local control is turned on. It returns false as the second value if the event is not local control.
+func (e *ControllerEvent) LocalControl() (on bool, ok bool) {
+	if e.control != constant.LocalControl {
+		return false, false
+	}
+
+	return e.value >= 0x40, true
+}
+
+// MonoChannels returns the number of channels of mono on message. 0 means the number of voices of the receiver.
+// It returns false as the second value if the event is not mono on.
+func (e *ControllerEvent) MonoChannels() (uint8, bool) {
+	if e.control != constant.MonoOn {
+		return 0, false
+	}
+
+	return e.value, true
+}
+
+// Clone returns a deep copy of controller event.
+func (e *ControllerEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same controller event. The running status and the encoding of delta time are ignored.
+func (e *ControllerEvent) Equal(other Event) bool {
+	v, ok := other.(*ControllerEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.control == v.control && e.value == v.value
+}
+
 // String returns string representation of controller event.
 func (e *ControllerEvent) String() string {
 	return fmt.Sprintf("&ControllerEvent{channel: %v, control: %v, value: %v}", e.channel, e.control, e.value)
@@ -98,7 +150,9 @@ func NewControllerEvent(deltaTime *deltatime.DeltaTime, channel uint8, control c
 	var err error
 
 	event := &ControllerEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
@@ -114,3 +168,52 @@ func NewControllerEvent(deltaTime *deltatime.DeltaTime, channel uint8, control c
 	}
 	return event, nil
 }
+
+// validateChannelMode returns error if the value is not allowed for the channel mode message.
+func validateChannelMode(control constant.Control, value uint8) error {
+	switch control {
+	case constant.LocalControl:
+		if value != 0x00 && value != 0x7f {
+			return fmt.Errorf("midi: value of local control must be 0 (off) or 127 (on)")
+		}
+	case constant.MonoOn:
+		if value > 0x10 {
+			return fmt.Errorf("midi: number of channels of mono on must be 0 to 16")
+		}
+	default:
+		if !control.IsChannelMode() {
+			return fmt.Errorf("midi: %v is not channel mode message", control)
+		}
+		if value != 0x00 {
+			return fmt.Errorf("midi: value of %v must be 0", control)
+		}
+	}
+
+	return nil
+}
+
+// NewChannelModeEvent returns ControllerEvent which represents channel mode message.
+// The value is validated for the control, e.g. the value of all notes off must be 0
+// and the number of channels of mono on must be 0 to 16.
+func NewChannelModeEvent(deltaTime *deltatime.DeltaTime, channel uint8, control constant.Control, value uint8) (*ControllerEvent, error) {
+	if err := validateChannelMode(control, value); err != nil {
+		return nil, err
+	}
+
+	return NewControllerEvent(deltaTime, channel, control, value)
+}
+
+// NewLocalControlEvent returns ControllerEvent which turns local control on or off.
+func NewLocalControlEvent(deltaTime *deltatime.DeltaTime, channel uint8, on bool) (*ControllerEvent, error) {
+	value := uint8(0x00)
+	if on {
+		value = 0x7f
+	}
+
+	return NewChannelModeEvent(deltaTime, channel, constant.LocalControl, value)
+}
+
+// NewMonoOnEvent returns ControllerEvent which turns mono mode on with the number of channels.
+func NewMonoOnEvent(deltaTime *deltatime.DeltaTime, channel uint8, channels uint8) (*ControllerEvent, error) {
+	return NewChannelModeEvent(deltaTime, channel, constant.MonoOn, channels)
+}
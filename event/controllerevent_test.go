@@ -2,6 +2,8 @@ package event
 
 import (
 	"testing"
+
+	"github.com/moutend/go-midi/constant"
 )
 
 func TestControllerEventDeltaTime(t *testing.T) {
@@ -144,3 +146,41 @@ func TestNewControllerEvent(t *testing.T) {
 		t.Fatalf("expected: 127 actual: %v", event.control)
 	}
 }
+
+func TestNewChannelModeEvent(t *testing.T) {
+	for _, c := range []struct {
+		control constant.Control
+		value   uint8
+	}{
+		{constant.MainVolume, 0},
+		{constant.AllNotesOff, 1},
+		{constant.LocalControl, 64},
+		{constant.MonoOn, 17},
+	} {
+		if _, err := NewChannelModeEvent(nil, 0, c.control, c.value); err == nil {
+			t.Fatalf("err must not be nil (control: %v value: %v)", c.control, c.value)
+		}
+	}
+
+	event, err := NewLocalControlEvent(nil, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if on, ok := event.LocalControl(); !ok || !on {
+		t.Fatalf("local control must be on")
+	}
+	if _, ok := event.MonoChannels(); ok {
+		t.Fatalf("local control must not be mono on")
+	}
+
+	event, err = NewMonoOnEvent(nil, 0, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if channels, ok := event.MonoChannels(); !ok || channels != 4 {
+		t.Fatalf("expected: 4 actual: %v", channels)
+	}
+	if !event.IsChannelMode() {
+		t.Fatalf("mono on must be channel mode message")
+	}
+}
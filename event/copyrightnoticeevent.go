@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // CopyrightNoticeEvent corresponds to copyright notice event.
 type CopyrightNoticeEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of copyright notice event.
 func (e *CopyrightNoticeEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns CopyrightNotice.
+func (e *CopyrightNoticeEvent) Kind() Kind {
+	return CopyrightNotice
+}
+
+// MetaEventType returns meta event type of copyright notice event.
+func (e *CopyrightNoticeEvent) MetaEventType() uint8 {
+	return constant.CopyrightNotice
+}
+
+// Data returns text as data of meta event.
+func (e *CopyrightNoticeEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized copyright notice event to dst and returns the extended buffer.
+func (e *CopyrightNoticeEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.CopyrightNotice)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes copyright notice event.
 func (e *CopyrightNoticeEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.CopyrightNotice)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized copyright notice event.
+func (e *CopyrightNoticeEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *CopyrightNoticeEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of copyright notice event.
+func (e *CopyrightNoticeEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same copyright notice event. The running status and the encoding of delta time are ignored.
+func (e *CopyrightNoticeEvent) Equal(other Event) bool {
+	v, ok := other.(*CopyrightNoticeEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of copyright notice event.
 func (e *CopyrightNoticeEvent) String() string {
 	return fmt.Sprintf("&CopyrightNoticeEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewCopyrightNoticeEvent(deltaTime *deltatime.DeltaTime, text []byte) (*Copy
 	var err error
 
 	event := &CopyrightNoticeEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // CuePointEvent corresponds to cue point event.
 type CuePointEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of cue point event.
 func (e *CuePointEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns CuePoint.
+func (e *CuePointEvent) Kind() Kind {
+	return CuePoint
+}
+
+// MetaEventType returns meta event type of cue point event.
+func (e *CuePointEvent) MetaEventType() uint8 {
+	return constant.CuePoint
+}
+
+// Data returns text as data of meta event.
+func (e *CuePointEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized cue point event to dst and returns the extended buffer.
+func (e *CuePointEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.CuePoint)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes cue point event.
 func (e *CuePointEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.CuePoint)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized cue point event.
+func (e *CuePointEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *CuePointEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of cue point event.
+func (e *CuePointEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same cue point event. The running status and the encoding of delta time are ignored.
+func (e *CuePointEvent) Equal(other Event) bool {
+	v, ok := other.(*CuePointEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of cue point event.
 func (e *CuePointEvent) String() string {
 	return fmt.Sprintf("&CuePointEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewCuePointEvent(deltaTime *deltatime.DeltaTime, text []byte) (*CuePointEve
 	var err error
 
 	event := &CuePointEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
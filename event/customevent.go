@@ -0,0 +1,172 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+	"github.com/moutend/go-midi/quantity"
+)
+
+// Codec decodes the data of meta event into a value of application and encodes the value back.
+// Encode must return the same bytes for the value returned by Decode, so that the event is serialized as it was read.
+type Codec interface {
+	Decode(data []byte) (interface{}, error)
+	Encode(value interface{}) ([]byte, error)
+}
+
+// CustomEvent represents meta event or sequencer specific event decoded by Codec registered to Registry.
+type CustomEvent struct {
+	deltaTime      deltatime.DeltaTime
+	runningStatus  bool
+	metaEventType  uint8
+	manufacturerID []byte
+	codec          Codec
+	value          interface{}
+	data           []byte
+}
+
+// deltatime.DeltaTime returns delta time.
+func (e *CustomEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns Custom.
+func (e *CustomEvent) Kind() Kind {
+	return Custom
+}
+
+// AppendTo appends serialized custom event to dst and returns the extended buffer.
+func (e *CustomEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, e.metaEventType)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.manufacturerID)+len(e.data)))
+	dst = append(dst, e.manufacturerID...)
+	dst = append(dst, e.data...)
+
+	return dst
+}
+
+// Serialize serializes custom event.
+func (e *CustomEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized custom event.
+func (e *CustomEvent) Len() int {
+	n := len(e.manufacturerID) + len(e.data)
+
+	return 2 + quantity.Len(uint32(n)) + n
+}
+
+// SetRunningStatus sets running status.
+func (e *CustomEvent) SetRunningStatus(status bool) {
+	e.runningStatus = status
+}
+
+// RunningStatus returns running status.
+func (e *CustomEvent) RunningStatus() bool {
+	return e.runningStatus
+}
+
+// MetaEventType returns meta event type.
+func (e *CustomEvent) MetaEventType() uint8 {
+	return e.metaEventType
+}
+
+// ManufacturerID returns manufacturer ID of sequencer specific event. It's empty for the other meta events.
+func (e *CustomEvent) ManufacturerID() []byte {
+	if e.manufacturerID == nil {
+		e.manufacturerID = []byte{}
+	}
+	return e.manufacturerID
+}
+
+// Data returns data of meta event, i.e. manufacturer ID followed by the encoded value.
+func (e *CustomEvent) Data() []byte {
+	return append(append([]byte{}, e.manufacturerID...), e.data...)
+}
+
+// SetValue encodes and sets value. Call SetValue again after modifying the value which is referred by pointer.
+func (e *CustomEvent) SetValue(value interface{}) error {
+	data, err := e.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+	if len(e.manufacturerID)+len(data) > 0xfffffff {
+		return fmt.Errorf("midi: maximum length of data is 256 MB")
+	}
+
+	e.value = value
+	e.data = data
+
+	return nil
+}
+
+// Value returns value decoded by codec.
+func (e *CustomEvent) Value() interface{} {
+	return e.value
+}
+
+// Clone returns a deep copy of custom event. The value is copied by decoding the encoded data again.
+func (e *CustomEvent) Clone() Event {
+	clone := *e
+	clone.manufacturerID = cloneBytes(e.manufacturerID)
+	clone.data = cloneBytes(e.data)
+
+	if value, err := e.codec.Decode(clone.data); err == nil {
+		clone.value = value
+	}
+
+	return &clone
+}
+
+// Equal reports whether other is the same custom event. The running status and the encoding of delta time are ignored.
+func (e *CustomEvent) Equal(other Event) bool {
+	v, ok := other.(*CustomEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.metaEventType == v.metaEventType && bytes.Equal(e.manufacturerID, v.manufacturerID) && bytes.Equal(e.data, v.data)
+}
+
+// String returns string representation of custom event.
+func (e *CustomEvent) String() string {
+	if e.metaEventType == constant.SequencerSpecific {
+		return fmt.Sprintf("&CustomEvent{metaEventType: 0x%x, manufacturerID: % x, value: %v}", e.metaEventType, e.manufacturerID, e.value)
+	}
+
+	return fmt.Sprintf("&CustomEvent{metaEventType: 0x%x, value: %v}", e.metaEventType, e.value)
+}
+
+// NewCustomEvent returns CustomEvent with the given parameter.
+// The manufacturerID must be empty unless metaEventType is sequencer specific (0x7f).
+func NewCustomEvent(deltaTime *deltatime.DeltaTime, metaEventType uint8, manufacturerID []byte, codec Codec, value interface{}) (*CustomEvent, error) {
+	if codec == nil {
+		return nil, fmt.Errorf("midi: codec must not be nil")
+	}
+	if metaEventType == constant.SequencerSpecific {
+		if err := validateManufacturerID(manufacturerID); err != nil {
+			return nil, err
+		}
+	} else if len(manufacturerID) > 0 {
+		return nil, fmt.Errorf("midi: manufacturer ID is allowed only for sequencer specific event")
+	}
+
+	event := &CustomEvent{
+		metaEventType:  metaEventType,
+		manufacturerID: cloneBytes(manufacturerID),
+		codec:          codec,
+	}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err := event.SetValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCustomEvent_Serialize(t *testing.T) {
+	event, err := NewCustomEvent(nil, 0x7f, []byte{0x41}, pairCodec{}, &pair{0x01, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xff, 0x7f, 0x03, 0x41, 0x01, 0x02}
+	actual := event.Serialize()
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+
+	if err := event.SetValue(&pair{0x03, 0x04}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []byte{0xff, 0x7f, 0x03, 0x41, 0x03, 0x04}
+	actual = event.Serialize()
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+}
+
+func TestCustomEvent_Clone(t *testing.T) {
+	event, err := NewCustomEvent(nil, 0x70, nil, pairCodec{}, &pair{0x01, 0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := event.Clone().(*CustomEvent)
+
+	if !event.Equal(clone) {
+		t.Fatalf("clone must be equal to the original")
+	}
+
+	clone.Value().(*pair).a = 0x03
+
+	if event.Value().(*pair).a != 0x01 {
+		t.Fatalf("expected: 1 actual: %v", event.Value().(*pair).a)
+	}
+}
+
+func TestNewCustomEvent(t *testing.T) {
+	if _, err := NewCustomEvent(nil, 0x70, nil, nil, &pair{}); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewCustomEvent(nil, 0x70, []byte{0x41}, pairCodec{}, &pair{}); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewCustomEvent(nil, 0x7f, []byte{0x00}, pairCodec{}, &pair{}); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewCustomEvent(nil, 0x70, nil, pairCodec{}, "pair"); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
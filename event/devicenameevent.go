@@ -0,0 +1,128 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+	"github.com/moutend/go-midi/quantity"
+)
+
+// DeviceNameEvent corresponds to device name event, also known as port name event.
+// It names the device or port which the events of track are sent to.
+type DeviceNameEvent struct {
+	deltaTime     deltatime.DeltaTime
+	runningStatus bool
+	text          []byte
+}
+
+// deltatime.DeltaTime returns delta time of device name event.
+func (e *DeviceNameEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns DeviceName.
+func (e *DeviceNameEvent) Kind() Kind {
+	return DeviceName
+}
+
+// MetaEventType returns meta event type of device name event.
+func (e *DeviceNameEvent) MetaEventType() uint8 {
+	return constant.DeviceName
+}
+
+// Data returns text as data of meta event.
+func (e *DeviceNameEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized device name event to dst and returns the extended buffer.
+func (e *DeviceNameEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.DeviceName)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
+}
+
+// Serialize serializes device name event.
+func (e *DeviceNameEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized device name event.
+func (e *DeviceNameEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
+}
+
+// SetRunningStatus sets running status.
+func (e *DeviceNameEvent) SetRunningStatus(status bool) {
+	e.runningStatus = status
+}
+
+// RunningStatus returns running status.
+func (e *DeviceNameEvent) RunningStatus() bool {
+	return e.runningStatus
+}
+
+// SetText sets text.
+func (e *DeviceNameEvent) SetText(text []byte) error {
+	if len(text) > 0xfffffff {
+		return fmt.Errorf("midi: maximum size of text is 256 MB")
+	}
+	e.text = text
+
+	return nil
+}
+
+// Text returns text.
+func (e *DeviceNameEvent) Text() []byte {
+	if e.text == nil {
+		e.text = []byte{}
+	}
+
+	text := make([]byte, len(e.text))
+	copy(text, e.text)
+
+	return text
+}
+
+// Clone returns a deep copy of device name event.
+func (e *DeviceNameEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same device name event. The running status and the encoding of delta time are ignored.
+func (e *DeviceNameEvent) Equal(other Event) bool {
+	v, ok := other.(*DeviceNameEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
+// String returns string representation of device name event.
+func (e *DeviceNameEvent) String() string {
+	return fmt.Sprintf("&DeviceNameEvent{text: \"%v\"}", string(e.Text()))
+}
+
+// NewDeviceNameEvent returns DeviceNameEvent with the given parameter.
+func NewDeviceNameEvent(deltaTime *deltatime.DeltaTime, text []byte) (*DeviceNameEvent, error) {
+	var err error
+
+	event := &DeviceNameEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err = event.SetText(text)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
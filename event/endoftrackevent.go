@@ -7,20 +7,42 @@ import (
 
 // EndOfTrackEvent corresponds to end of track event.
 type EndOfTrackEvent struct {
-	deltaTime *deltatime.DeltaTime
+	deltaTime deltatime.DeltaTime
 }
 
 // deltatime.DeltaTime returns delta time of end of track event.
 func (e *EndOfTrackEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns EndOfTrack.
+func (e *EndOfTrackEvent) Kind() Kind {
+	return EndOfTrack
+}
+
+// MetaEventType returns meta event type of end of track event.
+func (e *EndOfTrackEvent) MetaEventType() uint8 {
+	return constant.EndOfTrack
+}
+
+// Data returns data of end of track event, which is empty.
+func (e *EndOfTrackEvent) Data() []byte {
+	return []byte{}
+}
+
+// AppendTo appends serialized end of track event to dst and returns the extended buffer.
+func (e *EndOfTrackEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.Meta, constant.EndOfTrack, 0)
 }
 
 // Serialize serializes end of track event.
 func (e *EndOfTrackEvent) Serialize() []byte {
-	return []byte{constant.Meta, constant.EndOfTrack, 0}
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized end of track event.
+func (e *EndOfTrackEvent) Len() int {
+	return 3
 }
 
 // RunningStatus is fake method.
@@ -35,6 +57,23 @@ func (e *EndOfTrackEvent) SetRunningStatus(status bool) {
 	return
 }
 
+// Clone returns a deep copy of end of track event.
+func (e *EndOfTrackEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same end of track event. The running status and the encoding of delta time are ignored.
+func (e *EndOfTrackEvent) Equal(other Event) bool {
+	v, ok := other.(*EndOfTrackEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
 // String returns string representation of end of track event.
 func (e *EndOfTrackEvent) String() string {
 	return "&EndOfTrackEvent{}"
@@ -43,7 +82,9 @@ func (e *EndOfTrackEvent) String() string {
 // NewEndOfTrackEvent returns EndOfTrackEvent with the given parameter.
 func NewEndOfTrackEvent(deltaTime *deltatime.DeltaTime) (*EndOfTrackEvent, error) {
 	event := &EndOfTrackEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	return event, nil
 }
@@ -1,12 +1,89 @@
 package event
 
-import "github.com/moutend/go-midi/deltatime"
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
 
 // Event represents any MIDI events, including meta and system exclusive.
 type Event interface {
+	// Kind returns kind of event, which identifies the concrete type.
+	Kind() Kind
+
 	DeltaTime() *deltatime.DeltaTime
 	Serialize() []byte
 
+	// AppendTo appends serialized event to dst and returns the extended buffer like append.
+	AppendTo(dst []byte) []byte
+
+	// Len returns the length of serialized event, so that the buffer can be allocated before AppendTo.
+	Len() int
+
 	SetRunningStatus(bool)
 	RunningStatus() bool
+
+	// Clone returns a deep copy of event which shares no memory with the original.
+	Clone() Event
+
+	// Equal reports whether other is the same type of event with the same delta time and content.
+	Equal(other Event) bool
+}
+
+// ChannelEvent is implemented by the channel voice messages, e.g. note on and controller events.
+// The MIDI channel prefix event implements it as well because it binds the following meta events to the channel.
+// Use Kind().IsChannel() to tell them apart.
+type ChannelEvent interface {
+	Event
+
+	Channel() uint8
+	SetChannel(channel uint8) error
+}
+
+// MetaEvent is implemented by the meta events, including AlienEvent which represents unknown meta event.
+type MetaEvent interface {
+	Event
+
+	MetaEventType() uint8
+
+	// Data returns the data of meta event, which follows the type and length.
+	Data() []byte
+}
+
+// TextualEvent is implemented by the meta events which hold text, e.g. text, marker and lyrics events.
+type TextualEvent interface {
+	MetaEvent
+
+	Text() []byte
+	SetText(text []byte) error
+}
+
+// SysExEvent is implemented by the system exclusive and the divided system exclusive events.
+type SysExEvent interface {
+	Event
+
+	// SystemExclusiveType returns 0xf0 or 0xf7.
+	SystemExclusiveType() uint8
+
+	Data() []byte
+	SetData(data []byte) error
+}
+
+// cloneBytes returns a copy of b. The result is nil if b is nil.
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	return append([]byte{}, b...)
+}
+
+// noteName returns the name of note. The note on the percussion channel is named after the percussion map.
+func noteName(channel uint8, note constant.Note) string {
+	if constant.IsPercussionChannel(channel) {
+		if p, ok := constant.PercussionFromNote(note); ok {
+			return p.Name()
+		}
+	}
+
+	return note.String()
 }
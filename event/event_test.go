@@ -0,0 +1,127 @@
+package event
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+func TestEvent_Clone(t *testing.T) {
+	deltaTime, _ := deltatime.New(480)
+	must := func(e Event, err error) Event {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	setText := func(e Event) {
+		e.(TextualEvent).SetText([]byte("modified"))
+	}
+	modifyData := func(e Event) {
+		// The data is modified in place, so that the shared memory is detected.
+		e.(interface{ Data() []byte }).Data()[0] ^= 0x7f
+	}
+
+	kinds := map[Kind]bool{}
+
+	for _, c := range []struct {
+		event  Event
+		modify func(Event)
+	}{
+		{must(NewNoteOffEvent(deltaTime, 1, constant.C3, 0x40)), func(e Event) { e.(*NoteOffEvent).SetNote(constant.D3) }},
+		{must(NewNoteOnEvent(deltaTime, 1, constant.C3, 0x40)), func(e Event) { e.(*NoteOnEvent).SetVelocity(0x7f) }},
+		{must(NewNoteAfterTouchEvent(deltaTime, 1, constant.C3, 0x40)), func(e Event) { e.(*NoteAfterTouchEvent).SetVelocity(0x7f) }},
+		{must(NewControllerEvent(deltaTime, 1, constant.Modulation, 0x40)), func(e Event) { e.(*ControllerEvent).SetValue(0x7f) }},
+		{must(NewProgramChangeEvent(deltaTime, 1, constant.Violin)), func(e Event) { e.(*ProgramChangeEvent).SetProgram(constant.Viola) }},
+		{must(NewChannelAfterTouchEvent(deltaTime, 1, 0x40)), func(e Event) { e.(*ChannelAfterTouchEvent).SetVelocity(0x7f) }},
+		{must(NewPitchBendEvent(deltaTime, 1, 0x2000)), func(e Event) { e.(*PitchBendEvent).SetPitch(0x3fff) }},
+		{must(NewSystemExclusiveEvent(deltaTime, []byte{0x7e, 0x7f, 0x09, 0x01, 0xf7})), modifyData},
+		{must(NewDividedSystemExclusiveEvent(deltaTime, []byte{0x43, 0x12, 0x00, 0xf7})), modifyData},
+		{must(NewSequenceNumberEvent(deltaTime, 0x1234)), func(e Event) { e.(*SequenceNumberEvent).SetNumber(0x4321) }},
+		{must(NewTextEvent(deltaTime, []byte("text"))), setText},
+		{must(NewCopyrightNoticeEvent(deltaTime, []byte("copyright"))), setText},
+		{must(NewSequenceOrTrackNameEvent(deltaTime, []byte("track"))), setText},
+		{must(NewInstrumentNameEvent(deltaTime, []byte("instrument"))), setText},
+		{must(NewLyricsEvent(deltaTime, []byte("lyrics"))), setText},
+		{must(NewMarkerEvent(deltaTime, []byte("marker"))), setText},
+		{must(NewCuePointEvent(deltaTime, []byte("cue point"))), setText},
+		{must(NewProgramNameEvent(deltaTime, []byte("program"))), setText},
+		{must(NewDeviceNameEvent(deltaTime, []byte("device"))), setText},
+		{must(NewMIDIChannelPrefixEvent(deltaTime, 3)), func(e Event) { e.(*MIDIChannelPrefixEvent).SetChannel(4) }},
+		{must(NewMIDIPortPrefixEvent(deltaTime, 1)), func(e Event) { e.(*MIDIPortPrefixEvent).SetPort(2) }},
+		{must(NewEndOfTrackEvent(deltaTime)), nil},
+		{must(NewSetTempoEvent(deltaTime, 500000)), func(e Event) { e.(*SetTempoEvent).SetTempo(400000) }},
+		{must(NewSMPTEOffsetEvent(deltaTime, 1, 2, 3, 4, 5)), func(e Event) { e.(*SMPTEOffsetEvent).SetMinute(6) }},
+		{must(NewTimeSignatureEvent(deltaTime, 3, 2, 24, 8)), func(e Event) { e.(*TimeSignatureEvent).SetNumerator(4) }},
+		{must(NewKeySignatureEvent(deltaTime, -3, 1)), func(e Event) { e.(*KeySignatureEvent).SetKey(2) }},
+		{must(NewXMFPatchTypePrefixEvent(deltaTime, 1)), func(e Event) { e.(*XMFPatchTypePrefixEvent).SetPatchType(2) }},
+		{must(NewSequencerSpecificEvent(deltaTime, []byte{0x00, 0x00, 0x41, 0x01})), modifyData},
+		{must(NewAlienEvent(deltaTime, 0x10, []byte{0x01, 0x02})), modifyData},
+		{must(NewCustomEvent(deltaTime, 0x70, nil, pairCodec{}, &pair{0x01, 0x02})), func(e Event) { e.(*CustomEvent).SetValue(&pair{0x03, 0x04}) }},
+		{must(NewCustomEvent(deltaTime, 0x7f, []byte{0x41}, pairCodec{}, &pair{0x01, 0x02})), func(e Event) { e.(*CustomEvent).ManufacturerID()[0] = 0x43 }},
+		{must(NewMTCQuarterFrameEvent(deltaTime, 1, 2)), func(e Event) { e.(*MTCQuarterFrameEvent).SetValue(3) }},
+		{must(NewSongPositionPointerEvent(deltaTime, 0x1234)), func(e Event) { e.(*SongPositionPointerEvent).SetPosition(0x0123) }},
+		{must(NewSongSelectEvent(deltaTime, 1)), func(e Event) { e.(*SongSelectEvent).SetSong(2) }},
+		{must(NewTuneRequestEvent(deltaTime)), nil},
+		{must(NewTimingClockEvent(deltaTime)), nil},
+		{must(NewStartEvent(deltaTime)), nil},
+		{must(NewContinueEvent(deltaTime)), nil},
+		{must(NewStopEvent(deltaTime)), nil},
+		{must(NewActiveSensingEvent(deltaTime)), nil},
+		{must(NewSystemResetEvent(deltaTime)), nil},
+	} {
+		original := c.event
+		serialized := original.Serialize()
+		clone := original.Clone()
+
+		kinds[original.Kind()] = true
+
+		if clone == original {
+			t.Fatalf("%v: clone must not be the original", original.Kind())
+		}
+		if !original.Equal(clone) || !clone.Equal(original) {
+			t.Fatalf("%v: clone must be equal to the original", original.Kind())
+		}
+
+		clone.DeltaTime().SetUint32(960)
+
+		if original.Equal(clone) || original.DeltaTime().Uint32() != 480 {
+			t.Fatalf("%v: delta time of clone must not be shared", original.Kind())
+		}
+
+		clone.DeltaTime().SetUint32(480)
+
+		if c.modify == nil {
+			continue
+		}
+
+		c.modify(clone)
+
+		if original.Equal(clone) || clone.Equal(original) {
+			t.Fatalf("%v: modified clone must not be equal to the original", original.Kind())
+		}
+		if !bytes.Equal(serialized, original.Serialize()) {
+			t.Fatalf("%v: expected: % x actual: % x", original.Kind(), serialized, original.Serialize())
+		}
+	}
+	if len(kinds) != len(allEvents) {
+		t.Fatalf("expected: %v kinds actual: %v kinds", len(allEvents), len(kinds))
+	}
+}
+
+func TestEvent_Equal(t *testing.T) {
+	noteOn, _ := NewNoteOnEvent(nil, 1, constant.C3, 0x40)
+	noteOff, _ := NewNoteOffEvent(nil, 1, constant.C3, 0x40)
+	text, _ := NewTextEvent(nil, []byte("text"))
+	marker, _ := NewMarkerEvent(nil, []byte("text"))
+	alien, _ := NewAlienEvent(nil, 0x01, []byte("text"))
+
+	// The events of different kinds are not equal even if the content is the same.
+	for _, c := range [][2]Event{{noteOn, noteOff}, {text, marker}, {text, alien}} {
+		if c[0].Equal(c[1]) || c[1].Equal(c[0]) {
+			t.Fatalf("%v must not be equal to %v", c[0].Kind(), c[1].Kind())
+		}
+	}
+}
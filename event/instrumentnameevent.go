@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // InstrumentNameEvent corresponds to instrument name event.
 type InstrumentNameEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of instrument name event.
 func (e *InstrumentNameEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns InstrumentName.
+func (e *InstrumentNameEvent) Kind() Kind {
+	return InstrumentName
+}
+
+// MetaEventType returns meta event type of instrument name event.
+func (e *InstrumentNameEvent) MetaEventType() uint8 {
+	return constant.InstrumentName
+}
+
+// Data returns text as data of meta event.
+func (e *InstrumentNameEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized instrument name event to dst and returns the extended buffer.
+func (e *InstrumentNameEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.InstrumentName)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes instrument name event.
 func (e *InstrumentNameEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.InstrumentName)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized instrument name event.
+func (e *InstrumentNameEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *InstrumentNameEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of instrument name event.
+func (e *InstrumentNameEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same instrument name event. The running status and the encoding of delta time are ignored.
+func (e *InstrumentNameEvent) Equal(other Event) bool {
+	v, ok := other.(*InstrumentNameEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of instrument name event.
 func (e *InstrumentNameEvent) String() string {
 	return fmt.Sprintf("&InstrumentNameEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewInstrumentNameEvent(deltaTime *deltatime.DeltaTime, text []byte) (*Instr
 	var err error
 
 	event := &InstrumentNameEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
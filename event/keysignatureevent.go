@@ -9,7 +9,7 @@ import (
 
 // KeySignatureEvent corresponds to key signature meta event.
 type KeySignatureEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	key           int8
 	scale         uint8
@@ -17,19 +17,45 @@ type KeySignatureEvent struct {
 
 // deltatime.DeltaTime returns delta time of key signature event.
 func (e *KeySignatureEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns KeySignature.
+func (e *KeySignatureEvent) Kind() Kind {
+	return KeySignature
+}
+
+// MetaEventType returns meta event type of key signature event.
+func (e *KeySignatureEvent) MetaEventType() uint8 {
+	return constant.KeySignature
+}
+
+// Data returns data of key signature event, i.e. the serialized event without its type and length.
+func (e *KeySignatureEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 2))
+}
+
+// appendData appends data of key signature event to dst.
+func (e *KeySignatureEvent) appendData(dst []byte) []byte {
+	return append(dst, byte(e.key), e.scale)
+}
+
+// AppendTo appends serialized key signature event to dst and returns the extended buffer.
+func (e *KeySignatureEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.KeySignature)
+	dst = append(dst, 0x02)
+
+	return e.appendData(dst)
 }
 
 // Serialize serializes key signature event.
 func (e *KeySignatureEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.KeySignature)
-	bs = append(bs, 0x02, byte(e.key), e.scale)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized key signature event.
+func (e *KeySignatureEvent) Len() int {
+	return 5
 }
 
 // SetRunningStatus sets running status.
@@ -72,6 +98,23 @@ func (e *KeySignatureEvent) Scale() uint8 {
 	return e.scale
 }
 
+// Clone returns a deep copy of key signature event.
+func (e *KeySignatureEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same key signature event. The running status and the encoding of delta time are ignored.
+func (e *KeySignatureEvent) Equal(other Event) bool {
+	v, ok := other.(*KeySignatureEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.key == v.key && e.scale == v.scale
+}
+
 // String returns string representation of key signature event.
 func (e *KeySignatureEvent) String() string {
 	return fmt.Sprintf("&KeySignatureEvent{key: %v, scale: %v}", e.key, e.scale)
@@ -82,7 +125,9 @@ func NewKeySignatureEvent(deltaTime *deltatime.DeltaTime, key int8, scale uint8)
 	var err error
 
 	event := &KeySignatureEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetKey(key)
 	if err != nil {
@@ -0,0 +1,81 @@
+//go:generate stringer -type=Kind -output=kind_string.go
+
+package event
+
+// Kind represents kind of event. Each kind corresponds to a concrete event type, e.g. NoteOn to *NoteOnEvent.
+// Custom is the kind of the events decoded by the codecs registered to Registry.
+// The system common and system real-time kinds are used only for the live stream, see the stream package.
+type Kind uint8
+
+const (
+	NoteOff Kind = iota
+	NoteOn
+	NoteAfterTouch
+	Controller
+	ProgramChange
+	ChannelAfterTouch
+	PitchBend
+	SystemExclusive
+	DividedSystemExclusive
+	SequenceNumber
+	Text
+	CopyrightNotice
+	SequenceOrTrackName
+	InstrumentName
+	Lyrics
+	Marker
+	CuePoint
+	ProgramName
+	DeviceName
+	MIDIChannelPrefix
+	MIDIPortPrefix
+	EndOfTrack
+	SetTempo
+	SMPTEOffset
+	TimeSignature
+	KeySignature
+	XMFPatchTypePrefix
+	SequencerSpecific
+	Alien
+	Custom
+	MTCQuarterFrame
+	SongPositionPointer
+	SongSelect
+	TuneRequest
+	TimingClock
+	Start
+	Continue
+	Stop
+	ActiveSensing
+	SystemReset
+)
+
+// IsChannel reports whether the kind is channel voice message, from note off to pitch bend.
+func (k Kind) IsChannel() bool {
+	return k <= PitchBend
+}
+
+// IsSystemExclusive reports whether the kind is system exclusive or divided system exclusive.
+func (k Kind) IsSystemExclusive() bool {
+	return k == SystemExclusive || k == DividedSystemExclusive
+}
+
+// IsMeta reports whether the kind is meta event, including the unknown and the custom ones.
+func (k Kind) IsMeta() bool {
+	return k >= SequenceNumber && k <= Custom
+}
+
+// IsText reports whether the kind is meta event which holds text.
+func (k Kind) IsText() bool {
+	return k >= Text && k <= DeviceName
+}
+
+// IsSystemCommon reports whether the kind is system common message, from MTC quarter frame to tune request.
+func (k Kind) IsSystemCommon() bool {
+	return k >= MTCQuarterFrame && k <= TuneRequest
+}
+
+// IsSystemRealTime reports whether the kind is system real-time message, from timing clock to system reset.
+func (k Kind) IsSystemRealTime() bool {
+	return k >= TimingClock && k <= SystemReset
+}
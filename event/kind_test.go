@@ -0,0 +1,128 @@
+package event
+
+import (
+	"bytes"
+	"testing"
+)
+
+var allEvents = []Event{
+	&NoteOffEvent{},
+	&NoteOnEvent{},
+	&NoteAfterTouchEvent{},
+	&ControllerEvent{},
+	&ProgramChangeEvent{},
+	&ChannelAfterTouchEvent{},
+	&PitchBendEvent{},
+	&SystemExclusiveEvent{},
+	&DividedSystemExclusiveEvent{},
+	&SequenceNumberEvent{},
+	&TextEvent{},
+	&CopyrightNoticeEvent{},
+	&SequenceOrTrackNameEvent{},
+	&InstrumentNameEvent{},
+	&LyricsEvent{},
+	&MarkerEvent{},
+	&CuePointEvent{},
+	&ProgramNameEvent{},
+	&DeviceNameEvent{},
+	&MIDIChannelPrefixEvent{},
+	&MIDIPortPrefixEvent{},
+	&EndOfTrackEvent{},
+	&SetTempoEvent{},
+	&SMPTEOffsetEvent{},
+	&TimeSignatureEvent{},
+	&KeySignatureEvent{},
+	&XMFPatchTypePrefixEvent{},
+	&SequencerSpecificEvent{},
+	&AlienEvent{},
+	&CustomEvent{},
+	&MTCQuarterFrameEvent{},
+	&SongPositionPointerEvent{},
+	&SongSelectEvent{},
+	&TuneRequestEvent{},
+	&TimingClockEvent{},
+	&StartEvent{},
+	&ContinueEvent{},
+	&StopEvent{},
+	&ActiveSensingEvent{},
+	&SystemResetEvent{},
+}
+
+func TestKind(t *testing.T) {
+	for i, e := range allEvents {
+		kind := e.Kind()
+
+		if kind != Kind(i) {
+			t.Fatalf("expected: %v actual: %v", Kind(i), kind)
+		}
+
+		_, isChannel := e.(ChannelEvent)
+		_, isMeta := e.(MetaEvent)
+		_, isText := e.(TextualEvent)
+		_, isSysEx := e.(SysExEvent)
+
+		if kind.IsChannel() && !isChannel {
+			t.Fatalf("%v must implement ChannelEvent", kind)
+		}
+		if kind.IsMeta() != isMeta {
+			t.Fatalf("expected: %v actual: %v (%v implements MetaEvent)", kind.IsMeta(), isMeta, kind)
+		}
+		if kind.IsText() != isText {
+			t.Fatalf("expected: %v actual: %v (%v implements TextualEvent)", kind.IsText(), isText, kind)
+		}
+		if kind.IsSystemExclusive() != isSysEx {
+			t.Fatalf("expected: %v actual: %v (%v implements SysExEvent)", kind.IsSystemExclusive(), isSysEx, kind)
+		}
+	}
+}
+
+func TestMetaEvent_Data(t *testing.T) {
+	tempo, _ := NewSetTempoEvent(nil, 500000)
+	text, _ := NewTextEvent(nil, []byte("text"))
+	sequenceNumber, _ := NewSequenceNumberEvent(nil, 0x1234)
+	channelPrefix, _ := NewMIDIChannelPrefixEvent(nil, 0x0c)
+	portPrefix, _ := NewMIDIPortPrefixEvent(nil, 0x03)
+	smpteOffset, _ := NewSMPTEOffsetEvent(nil, 1, 2, 3, 4, 5)
+	timeSignature, _ := NewTimeSignatureEvent(nil, 6, 3, 24, 8)
+	keySignature, _ := NewKeySignatureEvent(nil, -3, 1)
+	patchType, _ := NewXMFPatchTypePrefixEvent(nil, 2)
+
+	for _, c := range []struct {
+		event         MetaEvent
+		metaEventType uint8
+		data          []byte
+	}{
+		{tempo, 0x51, []byte{0x07, 0xa1, 0x20}},
+		{text, 0x01, []byte("text")},
+		{&EndOfTrackEvent{}, 0x2f, []byte{}},
+		{sequenceNumber, 0x00, []byte{0x12, 0x34}},
+		{channelPrefix, 0x20, []byte{0x0c}},
+		{portPrefix, 0x21, []byte{0x03}},
+		{smpteOffset, 0x54, []byte{0x01, 0x02, 0x03, 0x04, 0x05}},
+		{timeSignature, 0x58, []byte{0x06, 0x03, 0x18, 0x08}},
+		{keySignature, 0x59, []byte{0xfd, 0x01}},
+		{patchType, 0x60, []byte{0x02}},
+	} {
+		// The data is the serialized event without its type and length.
+		if serialized := c.event.Serialize(); !bytes.Equal(serialized[3:], c.event.Data()) {
+			t.Fatalf("expected: % x actual: % x", serialized[3:], c.event.Data())
+		}
+		if c.event.MetaEventType() != c.metaEventType {
+			t.Fatalf("expected: 0x%x actual: 0x%x", c.metaEventType, c.event.MetaEventType())
+		}
+		if !bytes.Equal(c.data, c.event.Data()) {
+			t.Fatalf("expected: % x actual: % x", c.data, c.event.Data())
+		}
+	}
+}
+
+func TestEvent_Len(t *testing.T) {
+	long, _ := NewTextEvent(nil, bytes.Repeat([]byte("a"), 200))
+	custom, _ := NewCustomEvent(nil, 0x7f, []byte{0x41}, pairCodec{}, &pair{0x01, 0x02})
+
+	for _, e := range append(allEvents, long, custom) {
+		if expected, actual := len(e.Serialize()), e.Len(); expected != actual {
+			t.Fatalf("%v: expected: %v actual: %v", e.Kind(), expected, actual)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // LyricsEvent corresponds to lyrics event.
 type LyricsEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of lyrics event.
 func (e *LyricsEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns Lyrics.
+func (e *LyricsEvent) Kind() Kind {
+	return Lyrics
+}
+
+// MetaEventType returns meta event type of lyrics event.
+func (e *LyricsEvent) MetaEventType() uint8 {
+	return constant.Lyrics
+}
+
+// Data returns text as data of meta event.
+func (e *LyricsEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized lyrics event to dst and returns the extended buffer.
+func (e *LyricsEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.Lyrics)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes lyrics event.
 func (e *LyricsEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.Lyrics)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized lyrics event.
+func (e *LyricsEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *LyricsEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of lyrics event.
+func (e *LyricsEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same lyrics event. The running status and the encoding of delta time are ignored.
+func (e *LyricsEvent) Equal(other Event) bool {
+	v, ok := other.(*LyricsEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of lyrics event.
 func (e *LyricsEvent) String() string {
 	return fmt.Sprintf("&LyricsEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewLyricsEvent(deltaTime *deltatime.DeltaTime, text []byte) (*LyricsEvent,
 	var err error
 
 	event := &LyricsEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // MarkerEvent corresponds to marker event.
 type MarkerEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of marker event.
 func (e *MarkerEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns Marker.
+func (e *MarkerEvent) Kind() Kind {
+	return Marker
+}
+
+// MetaEventType returns meta event type of marker event.
+func (e *MarkerEvent) MetaEventType() uint8 {
+	return constant.Marker
+}
+
+// Data returns text as data of meta event.
+func (e *MarkerEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized marker event to dst and returns the extended buffer.
+func (e *MarkerEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.Marker)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes marker event.
 func (e *MarkerEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.Marker)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized marker event.
+func (e *MarkerEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *MarkerEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of marker event.
+func (e *MarkerEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same marker event. The running status and the encoding of delta time are ignored.
+func (e *MarkerEvent) Equal(other Event) bool {
+	v, ok := other.(*MarkerEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of marker event.
 func (e *MarkerEvent) String() string {
 	return fmt.Sprintf("&MarkerEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewMarkerEvent(deltaTime *deltatime.DeltaTime, text []byte) (*MarkerEvent,
 	var err error
 
 	event := &MarkerEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
@@ -9,26 +9,52 @@ import (
 
 // MIDIChannelPrefix corresponds to MIDI channel prefix meta event.
 type MIDIChannelPrefixEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 }
 
 // deltatime.DeltaTime returns delta time of MIDI channel prefix event.
 func (e *MIDIChannelPrefixEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns MIDIChannelPrefix.
+func (e *MIDIChannelPrefixEvent) Kind() Kind {
+	return MIDIChannelPrefix
+}
+
+// MetaEventType returns meta event type of MIDI channel prefix meta event.
+func (e *MIDIChannelPrefixEvent) MetaEventType() uint8 {
+	return constant.MIDIChannelPrefix
+}
+
+// Data returns data of MIDI channel prefix meta event, i.e. the serialized event without its type and length.
+func (e *MIDIChannelPrefixEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 1))
+}
+
+// appendData appends data of MIDI channel prefix meta event to dst.
+func (e *MIDIChannelPrefixEvent) appendData(dst []byte) []byte {
+	return append(dst, e.channel)
+}
+
+// AppendTo appends serialized MIDI channel prefix event to dst and returns the extended buffer.
+func (e *MIDIChannelPrefixEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.MIDIChannelPrefix)
+	dst = append(dst, 0x01)
+
+	return e.appendData(dst)
 }
 
 // Serialize serializes MIDI channel prefix meta event.
 func (e *MIDIChannelPrefixEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.MIDIChannelPrefix)
-	bs = append(bs, 0x01, e.channel)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized MIDI channel prefix meta event.
+func (e *MIDIChannelPrefixEvent) Len() int {
+	return 4
 }
 
 // SetRunningStatus sets running status.
@@ -56,6 +82,23 @@ func (e *MIDIChannelPrefixEvent) Channel() uint8 {
 	return e.channel
 }
 
+// Clone returns a deep copy of MIDI channel prefix meta event.
+func (e *MIDIChannelPrefixEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same MIDI channel prefix meta event. The running status and the encoding of delta time are ignored.
+func (e *MIDIChannelPrefixEvent) Equal(other Event) bool {
+	v, ok := other.(*MIDIChannelPrefixEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel
+}
+
 // String returns string representation of MIDI channel prefix meta event.
 func (e *MIDIChannelPrefixEvent) String() string {
 	return fmt.Sprintf("&MIDIChannelPrefixEvent{channel: %v}", e.channel)
@@ -66,7 +109,9 @@ func NewMIDIChannelPrefixEvent(deltaTime *deltatime.DeltaTime, channel uint8) (*
 	var err error
 
 	event := &MIDIChannelPrefixEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
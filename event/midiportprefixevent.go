@@ -9,26 +9,52 @@ import (
 
 // MIDIPortPrefix corresponds to MIDI port prefix meta event.
 type MIDIPortPrefixEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	port          uint8
 }
 
 // deltatime.DeltaTime returns delta time of MIDI port prefix event.
 func (e *MIDIPortPrefixEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns MIDIPortPrefix.
+func (e *MIDIPortPrefixEvent) Kind() Kind {
+	return MIDIPortPrefix
+}
+
+// MetaEventType returns meta event type of MIDI port prefix meta event.
+func (e *MIDIPortPrefixEvent) MetaEventType() uint8 {
+	return constant.MIDIPortPrefix
+}
+
+// Data returns data of MIDI port prefix meta event, i.e. the serialized event without its type and length.
+func (e *MIDIPortPrefixEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 1))
+}
+
+// appendData appends data of MIDI port prefix meta event to dst.
+func (e *MIDIPortPrefixEvent) appendData(dst []byte) []byte {
+	return append(dst, e.port)
+}
+
+// AppendTo appends serialized MIDI port prefix event to dst and returns the extended buffer.
+func (e *MIDIPortPrefixEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.MIDIPortPrefix)
+	dst = append(dst, 0x01)
+
+	return e.appendData(dst)
 }
 
 // Serialize serializes MIDI port prefix meta event.
 func (e *MIDIPortPrefixEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.MIDIPortPrefix)
-	bs = append(bs, 0x01, e.port)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized MIDI port prefix meta event.
+func (e *MIDIPortPrefixEvent) Len() int {
+	return 4
 }
 
 // SetRunningStatus sets running status.
@@ -56,6 +82,23 @@ func (e *MIDIPortPrefixEvent) Port() uint8 {
 	return e.port
 }
 
+// Clone returns a deep copy of MIDI port prefix meta event.
+func (e *MIDIPortPrefixEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same MIDI port prefix meta event. The running status and the encoding of delta time are ignored.
+func (e *MIDIPortPrefixEvent) Equal(other Event) bool {
+	v, ok := other.(*MIDIPortPrefixEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.port == v.port
+}
+
 // String returns string representation of MIDI port prefix meta event.
 func (e *MIDIPortPrefixEvent) String() string {
 	return fmt.Sprintf("&MIDIPortPrefixEvent{port: %v}", e.port)
@@ -66,7 +109,9 @@ func NewMIDIPortPrefixEvent(deltaTime *deltatime.DeltaTime, port uint8) (*MIDIPo
 	var err error
 
 	event := &MIDIPortPrefixEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetPort(port)
 	if err != nil {
@@ -29,7 +29,7 @@ func TestMIDIPortPrefixEvent_Serialize(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	expected := []byte{0xff, 0x20, 0x01, 0x0c}
+	expected := []byte{0xff, 0x21, 0x01, 0x0c}
 	actual := event.Serialize()
 
 	if len(expected) != len(actual) {
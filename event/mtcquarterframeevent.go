@@ -0,0 +1,125 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// MTCQuarterFrameEvent corresponds to MIDI time code quarter frame message, which is system common message sent only on the wire.
+// Eight quarter frames, from message type 0 to 7, carry a time code in nibbles.
+type MTCQuarterFrameEvent struct {
+	deltaTime   deltatime.DeltaTime
+	messageType uint8
+	value       uint8
+}
+
+// deltatime.DeltaTime returns delta time of MTC quarter frame event.
+func (e *MTCQuarterFrameEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns MTCQuarterFrame.
+func (e *MTCQuarterFrameEvent) Kind() Kind {
+	return MTCQuarterFrame
+}
+
+// AppendTo appends serialized MTC quarter frame event to dst and returns the extended buffer.
+func (e *MTCQuarterFrameEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.MTCQuarterFrame, e.messageType<<4|e.value)
+}
+
+// Serialize serializes MTC quarter frame event.
+func (e *MTCQuarterFrameEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized MTC quarter frame event.
+func (e *MTCQuarterFrameEvent) Len() int {
+	return 2
+}
+
+// RunningStatus is fake method.
+// It returns always false because the system common messages cancel running status.
+func (e *MTCQuarterFrameEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the system common messages cancel running status.
+func (e *MTCQuarterFrameEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// SetMessageType sets message type, which tells the piece of time code carried by the value.
+func (e *MTCQuarterFrameEvent) SetMessageType(messageType uint8) error {
+	if messageType > 7 {
+		return fmt.Errorf("midi: maximum value of message type is 7")
+	}
+	e.messageType = messageType
+
+	return nil
+}
+
+// MessageType returns message type.
+func (e *MTCQuarterFrameEvent) MessageType() uint8 {
+	return e.messageType
+}
+
+// SetValue sets value, which is the nibble of time code.
+func (e *MTCQuarterFrameEvent) SetValue(value uint8) error {
+	if value > 0x0f {
+		return fmt.Errorf("midi: maximum value of quarter frame is 15 (0x0f)")
+	}
+	e.value = value
+
+	return nil
+}
+
+// Value returns value.
+func (e *MTCQuarterFrameEvent) Value() uint8 {
+	return e.value
+}
+
+// Clone returns a deep copy of MTC quarter frame event.
+func (e *MTCQuarterFrameEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same MTC quarter frame event. The encoding of delta time is ignored.
+func (e *MTCQuarterFrameEvent) Equal(other Event) bool {
+	v, ok := other.(*MTCQuarterFrameEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.messageType == v.messageType && e.value == v.value
+}
+
+// String returns string representation of MTC quarter frame event.
+func (e *MTCQuarterFrameEvent) String() string {
+	return fmt.Sprintf("&MTCQuarterFrameEvent{messageType: %v, value: %v}", e.messageType, e.value)
+}
+
+// NewMTCQuarterFrameEvent returns MTCQuarterFrameEvent with the given parameter.
+func NewMTCQuarterFrameEvent(deltaTime *deltatime.DeltaTime, messageType, value uint8) (*MTCQuarterFrameEvent, error) {
+	var err error
+
+	event := &MTCQuarterFrameEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err = event.SetMessageType(messageType)
+	if err != nil {
+		return nil, err
+	}
+	err = event.SetValue(value)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
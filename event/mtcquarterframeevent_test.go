@@ -0,0 +1,47 @@
+package event
+
+import "testing"
+
+func TestMTCQuarterFrameEvent_Serialize(t *testing.T) {
+	event, err := NewMTCQuarterFrameEvent(nil, 7, 0x06)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xf1, 0x76}
+	actual := event.Serialize()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		a := actual[i]
+		if e != a {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		}
+	}
+}
+
+func TestNewMTCQuarterFrameEvent(t *testing.T) {
+	_, err := NewMTCQuarterFrameEvent(nil, 8, 0)
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	_, err = NewMTCQuarterFrameEvent(nil, 0, 0x10)
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
+
+func TestMTCQuarterFrameEvent_String(t *testing.T) {
+	event, err := NewMTCQuarterFrameEvent(nil, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "&MTCQuarterFrameEvent{messageType: 1, value: 2}"
+	actual := event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
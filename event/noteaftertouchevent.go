@@ -9,7 +9,7 @@ import (
 
 // NoteAfterTouchEvent corresponds to note after touch event.
 type NoteAfterTouchEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	note          constant.Note
@@ -18,19 +18,30 @@ type NoteAfterTouchEvent struct {
 
 // deltatime.DeltaTime returns delta time of note after touch event.
 func (e *NoteAfterTouchEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns NoteAfterTouch.
+func (e *NoteAfterTouchEvent) Kind() Kind {
+	return NoteAfterTouch
+}
+
+// AppendTo appends serialized note after touch event to dst and returns the extended buffer.
+func (e *NoteAfterTouchEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.NoteAfterTouch+e.channel)
+	dst = append(dst, byte(e.note), e.velocity)
+
+	return dst
 }
 
 // Serialize serializes note after touch event.
 func (e *NoteAfterTouchEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.NoteAfterTouch+e.channel)
-	bs = append(bs, byte(e.note), e.velocity)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized note after touch event.
+func (e *NoteAfterTouchEvent) Len() int {
+	return 3
 }
 
 // SetRunningStatus sets running status.
@@ -88,9 +99,26 @@ func (e *NoteAfterTouchEvent) Velocity() uint8 {
 	return e.velocity
 }
 
+// Clone returns a deep copy of note after touch event.
+func (e *NoteAfterTouchEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same note after touch event. The running status and the encoding of delta time are ignored.
+func (e *NoteAfterTouchEvent) Equal(other Event) bool {
+	v, ok := other.(*NoteAfterTouchEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.note == v.note && e.velocity == v.velocity
+}
+
 // String returns string representation of note after touch event.
 func (e *NoteAfterTouchEvent) String() string {
-	return fmt.Sprintf("&NoteAfterTouchEvent{channel: %v, note: %v, velocity: %v}", e.channel, e.note, e.velocity)
+	return fmt.Sprintf("&NoteAfterTouchEvent{channel: %v, note: %v, velocity: %v}", e.channel, noteName(e.channel, e.note), e.velocity)
 }
 
 // NewNoteAfterTouchEvent returns NoteAfterTouchEvent with the given parameter.
@@ -98,7 +126,9 @@ func NewNoteAfterTouchEvent(deltaTime *deltatime.DeltaTime, channel uint8, note
 	var err error
 
 	event := &NoteAfterTouchEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
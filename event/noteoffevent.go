@@ -9,7 +9,7 @@ import (
 
 // NoteOffEvent corresponds to note off event.
 type NoteOffEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	note          constant.Note
@@ -18,19 +18,30 @@ type NoteOffEvent struct {
 
 // deltatime.DeltaTime returns delta time of note off event.
 func (e *NoteOffEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns NoteOff.
+func (e *NoteOffEvent) Kind() Kind {
+	return NoteOff
+}
+
+// AppendTo appends serialized note off event to dst and returns the extended buffer.
+func (e *NoteOffEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.NoteOff+e.channel)
+	dst = append(dst, byte(e.note), e.velocity)
+
+	return dst
 }
 
 // Serialize serializes note off event.
 func (e *NoteOffEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.NoteOff+e.channel)
-	bs = append(bs, byte(e.note), e.velocity)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized note off event.
+func (e *NoteOffEvent) Len() int {
+	return 3
 }
 
 // SetRunningStatus sets running status.
@@ -88,9 +99,26 @@ func (e *NoteOffEvent) Velocity() uint8 {
 	return e.velocity
 }
 
+// Clone returns a deep copy of note off event.
+func (e *NoteOffEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same note off event. The running status and the encoding of delta time are ignored.
+func (e *NoteOffEvent) Equal(other Event) bool {
+	v, ok := other.(*NoteOffEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.note == v.note && e.velocity == v.velocity
+}
+
 // String returns string representation of note off event.
 func (e *NoteOffEvent) String() string {
-	return fmt.Sprintf("&NoteOffEvent{channel: %v, note: %v, velocity: %v}", e.channel, e.note, e.velocity)
+	return fmt.Sprintf("&NoteOffEvent{channel: %v, note: %v, velocity: %v}", e.channel, noteName(e.channel, e.note), e.velocity)
 }
 
 // NewNoteOffEvent returns NoteOffEvent with the given parameter.
@@ -98,7 +126,9 @@ func NewNoteOffEvent(deltaTime *deltatime.DeltaTime, channel uint8, note constan
 	var err error
 
 	event := &NoteOffEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
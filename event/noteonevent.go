@@ -9,7 +9,7 @@ import (
 
 // NoteOnEvent corresponds to note on event.
 type NoteOnEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	note          constant.Note
@@ -18,19 +18,30 @@ type NoteOnEvent struct {
 
 // deltatime.DeltaTime returns delta time of note on event.
 func (e *NoteOnEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns NoteOn.
+func (e *NoteOnEvent) Kind() Kind {
+	return NoteOn
+}
+
+// AppendTo appends serialized note on event to dst and returns the extended buffer.
+func (e *NoteOnEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.NoteOn+e.channel)
+	dst = append(dst, byte(e.note), e.velocity)
+
+	return dst
 }
 
 // Serialize serializes note on event.
 func (e *NoteOnEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.NoteOn+e.channel)
-	bs = append(bs, byte(e.note), e.velocity)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized note on event.
+func (e *NoteOnEvent) Len() int {
+	return 3
 }
 
 // SetRunningStatus sets running status.
@@ -88,9 +99,26 @@ func (e *NoteOnEvent) Velocity() uint8 {
 	return e.velocity
 }
 
+// Clone returns a deep copy of note on event.
+func (e *NoteOnEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same note on event. The running status and the encoding of delta time are ignored.
+func (e *NoteOnEvent) Equal(other Event) bool {
+	v, ok := other.(*NoteOnEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.note == v.note && e.velocity == v.velocity
+}
+
 // String returns string representation of note on event.
 func (e *NoteOnEvent) String() string {
-	return fmt.Sprintf("&NoteOnEvent{channel: %v, note: %v, velocity: %v}", e.channel, e.note, e.velocity)
+	return fmt.Sprintf("&NoteOnEvent{channel: %v, note: %v, velocity: %v}", e.channel, noteName(e.channel, e.note), e.velocity)
 }
 
 // NewNoteOnEvent returns NoteOnEvent with the given parameter.
@@ -98,7 +126,9 @@ func NewNoteOnEvent(deltaTime *deltatime.DeltaTime, channel uint8, note constant
 	var err error
 
 	event := &NoteOnEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
 )
 
 func TestNoteOnEventDeltaTime(t *testing.T) {
@@ -150,3 +151,87 @@ func TestNewNoteOnEvent(t *testing.T) {
 	}
 
 }
+
+func TestNoteOnEvent_String_percussion(t *testing.T) {
+	event, err := NewNoteOnEvent(nil, 9, constant.C1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "&NoteOnEvent{channel: 9, note: Bass Drum 1, velocity: 50}"
+	actual := event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+
+	event, err = NewNoteOnEvent(nil, 9, constant.C6, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = "&NoteOnEvent{channel: 9, note: C6, velocity: 50}"
+	actual = event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestNoteOnEvent_AppendTo(t *testing.T) {
+	event, err := NewNoteOnEvent(nil, 1, constant.C3, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xff, 0x91, 0x3c, 0x64}
+	actual := event.AppendTo([]byte{0xff})
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		if e != actual[i] {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, actual[i])
+		}
+	}
+}
+
+func TestNewNoteOnEvent_sharedDeltaTime(t *testing.T) {
+	deltaTime, err := deltatime.New(960)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := NewNoteOnEvent(deltaTime, 0, constant.C4, 100)
+	b, _ := NewNoteOnEvent(deltaTime, 0, constant.E4, 100)
+
+	a.DeltaTime().SetUint32(0)
+
+	if b.DeltaTime().Uint32() != 960 {
+		t.Fatalf("expected: 960 actual: %v", b.DeltaTime().Uint32())
+	}
+	if deltaTime.Uint32() != 960 {
+		t.Fatalf("expected: 960 actual: %v", deltaTime.Uint32())
+	}
+}
+
+func TestNoteOnEvent_Equal(t *testing.T) {
+	deltaTime, _ := deltatime.New(10)
+
+	a, _ := NewNoteOnEvent(deltaTime, 0, constant.C4, 100)
+	b, _ := NewNoteOnEvent(deltaTime, 0, constant.C4, 100)
+	c, _ := NewNoteOnEvent(nil, 0, constant.C4, 100)
+	d, _ := NewNoteOffEvent(deltaTime, 0, constant.C4, 100)
+
+	if !a.Equal(b) {
+		t.Fatalf("events must be equal")
+	}
+	if a.Equal(c) {
+		t.Fatalf("events with different delta time must not be equal")
+	}
+	if a.Equal(d) {
+		t.Fatalf("events with different type must not be equal")
+	}
+	if clone := a.Clone(); !a.Equal(clone) || clone == Event(a) {
+		t.Fatalf("clone must be equal to but distinct from the original")
+	}
+}
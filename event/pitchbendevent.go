@@ -2,14 +2,18 @@ package event
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/moutend/go-midi/constant"
 	"github.com/moutend/go-midi/deltatime"
 )
 
+// PitchBendCenter is the pitch value which means no bend.
+const PitchBendCenter = 0x2000
+
 // PitchBendEvent corresponds to pitch bend event.
 type PitchBendEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	pitch         uint16
@@ -17,22 +21,34 @@ type PitchBendEvent struct {
 
 // deltatime.DeltaTime returns delta time of pitch bend event.
 func (e *PitchBendEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
 }
 
-// Serialize serializes pitch bend event.
-func (e *PitchBendEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.PitchBend+e.channel)
+// Kind returns PitchBend.
+func (e *PitchBendEvent) Kind() Kind {
+	return PitchBend
+}
 
-	msb := byte(e.pitch >> 7)
+// AppendTo appends serialized pitch bend event to dst and returns the extended buffer.
+func (e *PitchBendEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.PitchBend+e.channel)
+
+	// The pitch is sent LSB first.
 	lsb := byte(e.pitch & 0x7f)
-	bs = append(bs, msb, lsb)
+	msb := byte(e.pitch >> 7)
+	dst = append(dst, lsb, msb)
+
+	return dst
+}
 
-	return bs
+// Serialize serializes pitch bend event.
+func (e *PitchBendEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized pitch bend event.
+func (e *PitchBendEvent) Len() int {
+	return 3
 }
 
 // SetRunningStatus sets running status.
@@ -75,6 +91,67 @@ func (e *PitchBendEvent) Pitch() uint16 {
 	return e.pitch
 }
 
+// SetBend sets pitch as normalized value. The range is -1.0 (lowest) to +1.0 (highest) and 0 means no bend.
+func (e *PitchBendEvent) SetBend(bend float64) error {
+	if math.IsNaN(bend) || bend < -1 || bend > 1 {
+		return fmt.Errorf("midi: bend must be -1.0 to 1.0")
+	}
+
+	scale := float64(PitchBendCenter)
+	if bend > 0 {
+		scale = 0x3fff - PitchBendCenter
+	}
+
+	return e.SetPitch(uint16(PitchBendCenter + int(math.Round(bend*scale))))
+}
+
+// Bend returns pitch as normalized value. The range is -1.0 (lowest) to +1.0 (highest) and 0 means no bend.
+func (e *PitchBendEvent) Bend() float64 {
+	offset := float64(int(e.pitch) - PitchBendCenter)
+	if offset > 0 {
+		return offset / (0x3fff - PitchBendCenter)
+	}
+
+	return offset / PitchBendCenter
+}
+
+// SetSemitones sets pitch in semitones. The sensitivity is the bend range in semitones,
+// which is set by RPN 0 (pitch bend sensitivity) and is 2 semitones by default.
+func (e *PitchBendEvent) SetSemitones(semitones, sensitivity float64) error {
+	if sensitivity <= 0 {
+		return fmt.Errorf("midi: sensitivity must be greater than 0")
+	}
+
+	return e.SetBend(semitones / sensitivity)
+}
+
+// Semitones returns pitch in semitones. The sensitivity is the bend range in semitones.
+func (e *PitchBendEvent) Semitones(sensitivity float64) float64 {
+	return e.Bend() * sensitivity
+}
+
+// Cents returns pitch in cents. The sensitivity is the bend range in semitones.
+func (e *PitchBendEvent) Cents(sensitivity float64) float64 {
+	return e.Semitones(sensitivity) * 100
+}
+
+// Clone returns a deep copy of pitch bend event.
+func (e *PitchBendEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same pitch bend event. The running status and the encoding of delta time are ignored.
+func (e *PitchBendEvent) Equal(other Event) bool {
+	v, ok := other.(*PitchBendEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.pitch == v.pitch
+}
+
 // String returns string representation of pitch bend event.
 func (e *PitchBendEvent) String() string {
 	return fmt.Sprintf("&PitchBendEvent{channel: %v, pitch: %v}", e.channel, e.pitch)
@@ -85,7 +162,9 @@ func NewPitchBendEvent(deltaTime *deltatime.DeltaTime, channel uint8, pitch uint
 	var err error
 
 	event := &PitchBendEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
@@ -29,7 +29,7 @@ func TestPitchBendEvent_Serialize(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	expected := []byte{0xe1, 0x00, 0x32}
+	expected := []byte{0xe1, 0x32, 0x00}
 	actual := event.Serialize()
 
 	if len(expected) != len(actual) {
@@ -112,3 +112,66 @@ func TestNewPitchBendEvent(t *testing.T) {
 		t.Fatalf("expected: 0x3fff actual: 0x%x", event.pitch)
 	}
 }
+
+func TestPitchBendEvent_SetBend(t *testing.T) {
+	event := &PitchBendEvent{}
+
+	for _, bend := range []float64{-1.1, 1.1} {
+		if err := event.SetBend(bend); err == nil {
+			t.Fatalf("err must not be nil")
+		}
+	}
+	for _, c := range []struct {
+		bend  float64
+		pitch uint16
+	}{
+		{-1, 0},
+		{0, 0x2000},
+		{0.5, 0x3000},
+		{1, 0x3fff},
+	} {
+		if err := event.SetBend(c.bend); err != nil {
+			t.Fatal(err)
+		}
+		if event.Pitch() != c.pitch {
+			t.Fatalf("expected: %v actual: %v", c.pitch, event.Pitch())
+		}
+	}
+}
+
+func TestPitchBendEvent_Bend(t *testing.T) {
+	for _, c := range []struct {
+		pitch uint16
+		bend  float64
+	}{
+		{0, -1},
+		{0x1000, -0.5},
+		{0x2000, 0},
+		{0x3fff, 1},
+	} {
+		event := &PitchBendEvent{pitch: c.pitch}
+		if event.Bend() != c.bend {
+			t.Fatalf("expected: %v actual: %v", c.bend, event.Bend())
+		}
+	}
+}
+
+func TestPitchBendEvent_Semitones(t *testing.T) {
+	event := &PitchBendEvent{}
+
+	if err := event.SetSemitones(1, 0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := event.SetSemitones(-6, 12); err != nil {
+		t.Fatal(err)
+	}
+	if event.Pitch() != 0x1000 {
+		t.Fatalf("expected: 4096 actual: %v", event.Pitch())
+	}
+	if actual := event.Semitones(12); actual != -6 {
+		t.Fatalf("expected: -6 actual: %v", actual)
+	}
+	if actual := event.Cents(2); actual != -100 {
+		t.Fatalf("expected: -100 actual: %v", actual)
+	}
+}
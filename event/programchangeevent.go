@@ -9,7 +9,7 @@ import (
 
 // ProgramChangeEvent corresponds to program change event.
 type ProgramChangeEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	channel       uint8
 	program       constant.GM
@@ -17,20 +17,30 @@ type ProgramChangeEvent struct {
 
 // deltatime.DeltaTime returns delta time of program change event.
 func (e *ProgramChangeEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
+	return &e.deltaTime
+}
 
-	return e.deltaTime
+// Kind returns ProgramChange.
+func (e *ProgramChangeEvent) Kind() Kind {
+	return ProgramChange
+}
+
+// AppendTo appends serialized program change event to dst and returns the extended buffer.
+func (e *ProgramChangeEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.ProgramChange+e.channel)
+	dst = append(dst, byte(e.program))
+
+	return dst
 }
 
 // Serialize serializes program change event.
 func (e *ProgramChangeEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.ProgramChange+e.channel)
-	bs = append(bs, byte(e.program))
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized program change event.
+func (e *ProgramChangeEvent) Len() int {
+	return 2
 }
 
 // SetRunningStatus sets running status.
@@ -73,6 +83,23 @@ func (e *ProgramChangeEvent) Program() constant.GM {
 	return e.program
 }
 
+// Clone returns a deep copy of program change event.
+func (e *ProgramChangeEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same program change event. The running status and the encoding of delta time are ignored.
+func (e *ProgramChangeEvent) Equal(other Event) bool {
+	v, ok := other.(*ProgramChangeEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.channel == v.channel && e.program == v.program
+}
+
 // String returns string representation of program change event.
 func (e *ProgramChangeEvent) String() string {
 	return fmt.Sprintf("&ProgramChangeEvent{channel: %v, program: %v}", e.channel, e.program)
@@ -83,7 +110,9 @@ func NewProgramChangeEvent(deltaTime *deltatime.DeltaTime, channel uint8, progra
 	var err error
 
 	event := &ProgramChangeEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetChannel(channel)
 	if err != nil {
@@ -0,0 +1,127 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+	"github.com/moutend/go-midi/quantity"
+)
+
+// ProgramNameEvent corresponds to program name event.
+type ProgramNameEvent struct {
+	deltaTime     deltatime.DeltaTime
+	runningStatus bool
+	text          []byte
+}
+
+// deltatime.DeltaTime returns delta time of program name event.
+func (e *ProgramNameEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns ProgramName.
+func (e *ProgramNameEvent) Kind() Kind {
+	return ProgramName
+}
+
+// MetaEventType returns meta event type of program name event.
+func (e *ProgramNameEvent) MetaEventType() uint8 {
+	return constant.ProgramName
+}
+
+// Data returns text as data of meta event.
+func (e *ProgramNameEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized program name event to dst and returns the extended buffer.
+func (e *ProgramNameEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.ProgramName)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
+}
+
+// Serialize serializes program name event.
+func (e *ProgramNameEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized program name event.
+func (e *ProgramNameEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
+}
+
+// SetRunningStatus sets running status.
+func (e *ProgramNameEvent) SetRunningStatus(status bool) {
+	e.runningStatus = status
+}
+
+// RunningStatus returns running status.
+func (e *ProgramNameEvent) RunningStatus() bool {
+	return e.runningStatus
+}
+
+// SetText sets text.
+func (e *ProgramNameEvent) SetText(text []byte) error {
+	if len(text) > 0xfffffff {
+		return fmt.Errorf("midi: maximum size of text is 256 MB")
+	}
+	e.text = text
+
+	return nil
+}
+
+// Text returns text.
+func (e *ProgramNameEvent) Text() []byte {
+	if e.text == nil {
+		e.text = []byte{}
+	}
+
+	text := make([]byte, len(e.text))
+	copy(text, e.text)
+
+	return text
+}
+
+// Clone returns a deep copy of program name event.
+func (e *ProgramNameEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same program name event. The running status and the encoding of delta time are ignored.
+func (e *ProgramNameEvent) Equal(other Event) bool {
+	v, ok := other.(*ProgramNameEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
+// String returns string representation of program name event.
+func (e *ProgramNameEvent) String() string {
+	return fmt.Sprintf("&ProgramNameEvent{text: \"%v\"}", string(e.Text()))
+}
+
+// NewProgramNameEvent returns ProgramNameEvent with the given parameter.
+func NewProgramNameEvent(deltaTime *deltatime.DeltaTime, text []byte) (*ProgramNameEvent, error) {
+	var err error
+
+	event := &ProgramNameEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err = event.SetText(text)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,143 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+// Registry holds the codecs for meta event types and the manufacturer IDs of sequencer specific events.
+// The parser decodes the meta events with the registered codecs into CustomEvent. The zero value is an empty registry.
+type Registry struct {
+	meta              map[uint8]Codec
+	sequencerSpecific map[string]Codec
+}
+
+// isStandardMeta reports whether the meta event type is defined by the standard MIDI file specification.
+func isStandardMeta(metaEventType uint8) bool {
+	switch metaEventType {
+	case constant.SequenceNumber, constant.Text, constant.CopyrightNotice, constant.SequenceOrTrackName,
+		constant.InstrumentName, constant.Lyrics, constant.Marker, constant.CuePoint, constant.ProgramName,
+		constant.DeviceName, constant.MIDIChannelPrefix, constant.MIDIPortPrefix, constant.EndOfTrack,
+		constant.SetTempo, constant.SMPTEOffset, constant.TimeSignature, constant.KeySignature,
+		constant.XMFPatchTypePrefix, constant.SequencerSpecific:
+		return true
+	}
+
+	return false
+}
+
+// validateManufacturerID returns error if the manufacturer ID is neither 1 byte ID nor 3 bytes ID begins with 0x00.
+func validateManufacturerID(manufacturerID []byte) error {
+	switch {
+	case len(manufacturerID) == 1 && manufacturerID[0] != 0x00 && manufacturerID[0] < 0x80:
+		return nil
+	case len(manufacturerID) == 3 && manufacturerID[0] == 0x00 && manufacturerID[1] < 0x80 && manufacturerID[2] < 0x80:
+		return nil
+	}
+
+	return fmt.Errorf("midi: manufacturer ID must be 1 byte or 3 bytes begins with 0x00 (% x)", manufacturerID)
+}
+
+// splitManufacturerID splits data of sequencer specific event into manufacturer ID and payload.
+func splitManufacturerID(data []byte) ([]byte, []byte, bool) {
+	size := 1
+	if len(data) > 0 && data[0] == 0x00 {
+		size = 3
+	}
+	if len(data) < size {
+		return nil, nil, false
+	}
+
+	return data[:size], data[size:], true
+}
+
+// RegisterMeta registers codec for meta event type. The standard meta event types can't be registered.
+func (r *Registry) RegisterMeta(metaEventType uint8, codec Codec) error {
+	if isStandardMeta(metaEventType) {
+		return fmt.Errorf("midi: meta event type 0x%x is reserved by standard MIDI file", metaEventType)
+	}
+	if codec == nil {
+		return fmt.Errorf("midi: codec must not be nil")
+	}
+
+	if r.meta == nil {
+		r.meta = map[uint8]Codec{}
+	}
+
+	r.meta[metaEventType] = codec
+
+	return nil
+}
+
+// RegisterSequencerSpecific registers codec for the payload of sequencer specific event which follows manufacturer ID.
+func (r *Registry) RegisterSequencerSpecific(manufacturerID []byte, codec Codec) error {
+	if err := validateManufacturerID(manufacturerID); err != nil {
+		return err
+	}
+	if codec == nil {
+		return fmt.Errorf("midi: codec must not be nil")
+	}
+
+	if r.sequencerSpecific == nil {
+		r.sequencerSpecific = map[string]Codec{}
+	}
+
+	r.sequencerSpecific[string(manufacturerID)] = codec
+
+	return nil
+}
+
+// Decode decodes data of meta event with the registered codec.
+// It returns false if no codec is registered, the codec fails or the value is not encoded back to the same data,
+// so that the caller can fall back to AlienEvent or SequencerSpecificEvent without losing any byte.
+func (r *Registry) Decode(metaEventType uint8, data []byte) (*CustomEvent, bool) {
+	var codec Codec
+	var manufacturerID, payload []byte
+
+	if metaEventType == constant.SequencerSpecific {
+		var ok bool
+
+		manufacturerID, payload, ok = splitManufacturerID(data)
+		if !ok {
+			return nil, false
+		}
+
+		codec = r.sequencerSpecific[string(manufacturerID)]
+	} else {
+		payload = data
+		codec = r.meta[metaEventType]
+	}
+	if codec == nil {
+		return nil, false
+	}
+
+	value, err := codec.Decode(payload)
+	if err != nil {
+		return nil, false
+	}
+
+	encoded, err := codec.Encode(value)
+	if err != nil || !bytes.Equal(encoded, payload) {
+		return nil, false
+	}
+
+	e := &CustomEvent{
+		metaEventType:  metaEventType,
+		manufacturerID: cloneBytes(manufacturerID),
+		codec:          codec,
+		value:          value,
+		data:           encoded,
+	}
+
+	return e, true
+}
+
+// NewRegistry returns empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		meta:              map[uint8]Codec{},
+		sequencerSpecific: map[string]Codec{},
+	}
+}
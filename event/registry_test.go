@@ -0,0 +1,132 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+type pair struct {
+	a, b uint8
+}
+
+// pairCodec decodes 2 bytes into pair.
+type pairCodec struct{}
+
+func (pairCodec) Decode(data []byte) (interface{}, error) {
+	if len(data) != 2 {
+		return nil, fmt.Errorf("length of data must be 2")
+	}
+
+	return &pair{data[0], data[1]}, nil
+}
+
+func (pairCodec) Encode(value interface{}) ([]byte, error) {
+	v, ok := value.(*pair)
+	if !ok {
+		return nil, fmt.Errorf("value must be *pair")
+	}
+
+	return []byte{v.a, v.b}, nil
+}
+
+// lossyCodec decodes only the first byte, so that the data is not encoded back.
+type lossyCodec struct{}
+
+func (lossyCodec) Decode(data []byte) (interface{}, error) {
+	return data[0], nil
+}
+
+func (lossyCodec) Encode(value interface{}) ([]byte, error) {
+	return []byte{value.(byte)}, nil
+}
+
+func TestRegistry_RegisterMeta(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.RegisterMeta(0x51, pairCodec{}); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := r.RegisterMeta(0x7f, pairCodec{}); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := r.RegisterMeta(0x70, nil); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := r.RegisterMeta(0x70, pairCodec{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistry_RegisterSequencerSpecific(t *testing.T) {
+	r := NewRegistry()
+
+	for _, id := range [][]byte{{}, {0x00}, {0x80}, {0x41, 0x10}, {0x00, 0x20}} {
+		if err := r.RegisterSequencerSpecific(id, pairCodec{}); err == nil {
+			t.Fatalf("err must not be nil (manufacturerID: % x)", id)
+		}
+	}
+	for _, id := range [][]byte{{0x41}, {0x00, 0x20, 0x29}} {
+		if err := r.RegisterSequencerSpecific(id, pairCodec{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRegistry_zeroValue(t *testing.T) {
+	var r Registry
+
+	if _, ok := r.Decode(0x70, []byte{0x01, 0x02}); ok {
+		t.Fatalf("empty registry must not decode")
+	}
+	if err := r.RegisterMeta(0x70, pairCodec{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterSequencerSpecific([]byte{0x41}, pairCodec{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Decode(0x70, []byte{0x01, 0x02}); !ok {
+		t.Fatalf("registered meta event must be decoded")
+	}
+	if _, ok := r.Decode(0x7f, []byte{0x41, 0x01, 0x02}); !ok {
+		t.Fatalf("registered sequencer specific event must be decoded")
+	}
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterMeta(0x70, pairCodec{})
+	r.RegisterMeta(0x71, lossyCodec{})
+	r.RegisterSequencerSpecific([]byte{0x00, 0x20, 0x29}, pairCodec{})
+
+	for _, c := range []struct {
+		metaEventType uint8
+		data          []byte
+		ok            bool
+	}{
+		{0x70, []byte{0x01, 0x02}, true},
+		{0x70, []byte{0x01}, false},
+		{0x71, []byte{0x01, 0x02}, false},
+		{0x72, []byte{0x01, 0x02}, false},
+		{0x7f, []byte{0x00, 0x20, 0x29, 0x01, 0x02}, true},
+		{0x7f, []byte{0x41, 0x01, 0x02}, false},
+		{0x7f, []byte{0x00, 0x20}, false},
+	} {
+		e, ok := r.Decode(c.metaEventType, c.data)
+		if ok != c.ok {
+			t.Fatalf("expected: %v actual: %v (metaEventType: 0x%x data: % x)", c.ok, ok, c.metaEventType, c.data)
+		}
+		if !ok {
+			continue
+		}
+		if e.MetaEventType() != c.metaEventType {
+			t.Fatalf("expected: 0x%x actual: 0x%x", c.metaEventType, e.MetaEventType())
+		}
+		if !bytes.Equal(c.data, e.Data()) {
+			t.Fatalf("expected: % x actual: % x", c.data, e.Data())
+		}
+		if v := e.Value().(*pair); v.a != 0x01 || v.b != 0x02 {
+			t.Fatalf("expected: &{1 2} actual: %v", v)
+		}
+	}
+}
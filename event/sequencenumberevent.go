@@ -0,0 +1,119 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// SequenceNumberEvent corresponds to sequence number meta event.
+// It identifies the sequence in format 2 MIDI data or in a song collection.
+type SequenceNumberEvent struct {
+	deltaTime     deltatime.DeltaTime
+	runningStatus bool
+	number        uint16
+}
+
+// deltatime.DeltaTime returns delta time of sequence number event.
+func (e *SequenceNumberEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns SequenceNumber.
+func (e *SequenceNumberEvent) Kind() Kind {
+	return SequenceNumber
+}
+
+// MetaEventType returns meta event type of sequence number event.
+func (e *SequenceNumberEvent) MetaEventType() uint8 {
+	return constant.SequenceNumber
+}
+
+// Data returns data of sequence number event, i.e. the serialized event without its type and length.
+func (e *SequenceNumberEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 2))
+}
+
+// appendData appends data of sequence number event to dst.
+func (e *SequenceNumberEvent) appendData(dst []byte) []byte {
+	return append(dst, byte(e.number>>8), byte(e.number&0xff))
+}
+
+// AppendTo appends serialized sequence number event to dst and returns the extended buffer.
+func (e *SequenceNumberEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.SequenceNumber)
+	dst = append(dst, 0x02)
+
+	return e.appendData(dst)
+}
+
+// Serialize serializes sequence number event.
+func (e *SequenceNumberEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized sequence number event.
+func (e *SequenceNumberEvent) Len() int {
+	return 5
+}
+
+// SetRunningStatus sets running status.
+func (e *SequenceNumberEvent) SetRunningStatus(status bool) {
+	e.runningStatus = status
+}
+
+// RunningStatus returns running status.
+func (e *SequenceNumberEvent) RunningStatus() bool {
+	return e.runningStatus
+}
+
+// SetNumber sets sequence number.
+func (e *SequenceNumberEvent) SetNumber(number uint16) error {
+	e.number = number
+
+	return nil
+}
+
+// Number returns sequence number.
+func (e *SequenceNumberEvent) Number() uint16 {
+	return e.number
+}
+
+// Clone returns a deep copy of sequence number event.
+func (e *SequenceNumberEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same sequence number event. The running status and the encoding of delta time are ignored.
+func (e *SequenceNumberEvent) Equal(other Event) bool {
+	v, ok := other.(*SequenceNumberEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.number == v.number
+}
+
+// String returns string representation of sequence number event.
+func (e *SequenceNumberEvent) String() string {
+	return fmt.Sprintf("&SequenceNumberEvent{number: %v}", e.number)
+}
+
+// NewSequenceNumberEvent returns SequenceNumberEvent with the given parameter.
+func NewSequenceNumberEvent(deltaTime *deltatime.DeltaTime, number uint16) (*SequenceNumberEvent, error) {
+	var err error
+
+	event := &SequenceNumberEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err = event.SetNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,55 @@
+package event
+
+import "testing"
+
+func TestSequenceNumberEventDeltaTime(t *testing.T) {
+	event := &SequenceNumberEvent{}
+	dt := event.DeltaTime()
+	if dt == nil {
+		t.Fatal("deltatime.DeltaTime() don't return nil")
+	}
+}
+
+func TestSequenceNumberEvent_String(t *testing.T) {
+	event, err := NewSequenceNumberEvent(nil, 258)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "&SequenceNumberEvent{number: 258}"
+	actual := event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestSequenceNumberEvent_Serialize(t *testing.T) {
+	event, err := NewSequenceNumberEvent(nil, 0x0102)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xff, 0x00, 0x02, 0x01, 0x02}
+	actual := event.Serialize()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		a := actual[i]
+		if e != a {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		}
+	}
+}
+
+func TestSequenceNumberEvent_Number(t *testing.T) {
+	event := &SequenceNumberEvent{number: 1}
+
+	expected := uint16(1)
+	actual := event.Number()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // SequenceOrTrackNameEvent corresponds to sequence or track name event.
 type SequenceOrTrackNameEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of sequence or track name event.
 func (e *SequenceOrTrackNameEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns SequenceOrTrackName.
+func (e *SequenceOrTrackNameEvent) Kind() Kind {
+	return SequenceOrTrackName
+}
+
+// MetaEventType returns meta event type of sequence or track name event.
+func (e *SequenceOrTrackNameEvent) MetaEventType() uint8 {
+	return constant.SequenceOrTrackName
+}
+
+// Data returns text as data of meta event.
+func (e *SequenceOrTrackNameEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized sequence or track name event to dst and returns the extended buffer.
+func (e *SequenceOrTrackNameEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.SequenceOrTrackName)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes sequence or track name event.
 func (e *SequenceOrTrackNameEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.SequenceOrTrackName)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized sequence or track name event.
+func (e *SequenceOrTrackNameEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *SequenceOrTrackNameEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of sequence or track name event.
+func (e *SequenceOrTrackNameEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same sequence or track name event. The running status and the encoding of delta time are ignored.
+func (e *SequenceOrTrackNameEvent) Equal(other Event) bool {
+	v, ok := other.(*SequenceOrTrackNameEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of sequence or track name event.
 func (e *SequenceOrTrackNameEvent) String() string {
 	return fmt.Sprintf("&SequenceOrTrackNameEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewSequenceOrTrackNameEvent(deltaTime *deltatime.DeltaTime, text []byte) (*
 	var err error
 
 	event := &SequenceOrTrackNameEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,43 @@ import (
 
 // SequencerSpecificEvent corresponds to sequencer specific event.
 type SequencerSpecificEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	data          []byte
 }
 
 // deltatime.DeltaTime returns delta time.
 func (e *SequencerSpecificEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns SequencerSpecific.
+func (e *SequencerSpecificEvent) Kind() Kind {
+	return SequencerSpecific
+}
+
+// MetaEventType returns meta event type of sequencer specific event.
+func (e *SequencerSpecificEvent) MetaEventType() uint8 {
+	return constant.SequencerSpecific
+}
+
+// AppendTo appends serialized sequencer specific event to dst and returns the extended buffer.
+func (e *SequencerSpecificEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.SequencerSpecific)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.data)))
+	dst = append(dst, e.data...)
+
+	return dst
 }
 
 // Serialize serializes sequencer specific event.
 func (e *SequencerSpecificEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.SequencerSpecific)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Data())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Data()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized sequencer specific event.
+func (e *SequencerSpecificEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.data))) + len(e.data)
 }
 
 // SetRunningStatus sets running status.
@@ -64,6 +78,24 @@ func (e *SequencerSpecificEvent) Data() []byte {
 	return e.data
 }
 
+// Clone returns a deep copy of sequencer specific event.
+func (e *SequencerSpecificEvent) Clone() Event {
+	clone := *e
+	clone.data = cloneBytes(e.data)
+
+	return &clone
+}
+
+// Equal reports whether other is the same sequencer specific event. The running status and the encoding of delta time are ignored.
+func (e *SequencerSpecificEvent) Equal(other Event) bool {
+	v, ok := other.(*SequencerSpecificEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.data, v.data)
+}
+
 // String returns string representation of sequencer specific event.
 func (e *SequencerSpecificEvent) String() string {
 	return fmt.Sprintf("&SequencerSpecificEvent{data: %v bytes}", len(e.Data()))
@@ -74,7 +106,9 @@ func NewSequencerSpecificEvent(deltaTime *deltatime.DeltaTime, data []byte) (*Se
 	var err error
 
 	event := &SequencerSpecificEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetData(data)
 	if err != nil {
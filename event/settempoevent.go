@@ -9,29 +9,52 @@ import (
 
 // SetTempoEvent corresponds to set tempo event.
 type SetTempoEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	tempo         uint32
 }
 
 // deltatime.DeltaTime returns delta time of set tempo event.
 func (e *SetTempoEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns SetTempo.
+func (e *SetTempoEvent) Kind() Kind {
+	return SetTempo
+}
+
+// MetaEventType returns meta event type of tempo event.
+func (e *SetTempoEvent) MetaEventType() uint8 {
+	return constant.SetTempo
+}
+
+// Data returns data of tempo event, i.e. the serialized event without its type and length.
+func (e *SetTempoEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 3))
+}
+
+// appendData appends data of tempo event to dst.
+func (e *SetTempoEvent) appendData(dst []byte) []byte {
+	return append(dst, byte(e.tempo>>16), byte((0xff00&e.tempo)>>8), byte(e.tempo&0xff))
+}
+
+// AppendTo appends serialized set tempo event to dst and returns the extended buffer.
+func (e *SetTempoEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.SetTempo)
+	dst = append(dst, 0x03)
+
+	return e.appendData(dst)
 }
 
 // Serialize serializes set tempo event.
 func (e *SetTempoEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.SetTempo)
-	bs = append(bs, 0x03)
-	bs = append(bs, byte(e.tempo>>16))
-	bs = append(bs, byte((0xff00&e.tempo)>>8))
-	bs = append(bs, byte(e.tempo&0xff))
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized set tempo event.
+func (e *SetTempoEvent) Len() int {
+	return 6
 }
 
 // SetRunningStatus sets running status.
@@ -59,6 +82,23 @@ func (e *SetTempoEvent) Tempo() uint32 {
 	return e.tempo
 }
 
+// Clone returns a deep copy of tempo event.
+func (e *SetTempoEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same tempo event. The running status and the encoding of delta time are ignored.
+func (e *SetTempoEvent) Equal(other Event) bool {
+	v, ok := other.(*SetTempoEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.tempo == v.tempo
+}
+
 // String returns string representation of tempo event.
 func (e *SetTempoEvent) String() string {
 	return fmt.Sprintf("&SetTempoEvent{tempo: %v}", e.tempo)
@@ -69,7 +109,9 @@ func NewSetTempoEvent(deltaTime *deltatime.DeltaTime, tempo uint32) (*SetTempoEv
 	var err error
 
 	event := &SetTempoEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetTempo(tempo)
 	if err != nil {
@@ -8,9 +8,11 @@ import (
 )
 
 // SMPTEOffsetEvent corresponds to SMPTE offset event.
+// The frame rate is stored in the upper bits of the hour byte as well as MIDI time code.
 type SMPTEOffsetEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
+	frameRate     constant.FrameRate
 	hour          uint8
 	minute        uint8
 	second        uint8
@@ -20,19 +22,45 @@ type SMPTEOffsetEvent struct {
 
 // deltatime.DeltaTime returns delta time of SMPTE offset event.
 func (e *SMPTEOffsetEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns SMPTEOffset.
+func (e *SMPTEOffsetEvent) Kind() Kind {
+	return SMPTEOffset
+}
+
+// MetaEventType returns meta event type of SMPTE offset event.
+func (e *SMPTEOffsetEvent) MetaEventType() uint8 {
+	return constant.SMPTEOffset
+}
+
+// Data returns data of SMPTE offset event, i.e. the serialized event without its type and length.
+func (e *SMPTEOffsetEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 5))
+}
+
+// appendData appends data of SMPTE offset event to dst.
+func (e *SMPTEOffsetEvent) appendData(dst []byte) []byte {
+	return append(dst, byte(e.frameRate)<<5|e.hour, e.minute, e.second, e.frame, e.subFrame)
+}
+
+// AppendTo appends serialized SMPTE offset event to dst and returns the extended buffer.
+func (e *SMPTEOffsetEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.SMPTEOffset)
+	dst = append(dst, 0x05)
+
+	return e.appendData(dst)
 }
 
 // Serialize serializes SMPTE offset event.
 func (e *SMPTEOffsetEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.SMPTEOffset)
-	bs = append(bs, 0x05, e.hour, e.minute, e.second, e.frame, e.subFrame)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized SMPTE offset event.
+func (e *SMPTEOffsetEvent) Len() int {
+	return 8
 }
 
 // SetRunningStatus sets running status.
@@ -45,6 +73,21 @@ func (e *SMPTEOffsetEvent) RunningStatus() bool {
 	return e.runningStatus
 }
 
+// SetFrameRate sets frame rate.
+func (e *SMPTEOffsetEvent) SetFrameRate(frameRate constant.FrameRate) error {
+	if frameRate > constant.FPS30 {
+		return fmt.Errorf("midi: frame rate is 0 to 3")
+	}
+	e.frameRate = frameRate
+
+	return nil
+}
+
+// FrameRate returns frame rate.
+func (e *SMPTEOffsetEvent) FrameRate() constant.FrameRate {
+	return e.frameRate
+}
+
 // SetHour sets hour.
 func (e *SMPTEOffsetEvent) SetHour(hour uint8) error {
 	if hour > 23 {
@@ -120,6 +163,23 @@ func (e *SMPTEOffsetEvent) SubFrame() uint8 {
 	return e.subFrame
 }
 
+// Clone returns a deep copy of SMPTE offset event.
+func (e *SMPTEOffsetEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same SMPTE offset event. The running status and the encoding of delta time are ignored.
+func (e *SMPTEOffsetEvent) Equal(other Event) bool {
+	v, ok := other.(*SMPTEOffsetEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.frameRate == v.frameRate && e.hour == v.hour && e.minute == v.minute && e.second == v.second && e.frame == v.frame && e.subFrame == v.subFrame
+}
+
 // String returns string representation of SMPTE offset event.
 func (e *SMPTEOffsetEvent) String() string {
 	return fmt.Sprintf("&SMPTEOffsetEvent{hour: %v, minute: %v, second: %v, frame: %v, subFrame: %v}", e.hour, e.minute, e.second, e.frame, e.subFrame)
@@ -130,7 +190,9 @@ func NewSMPTEOffsetEvent(deltaTime *deltatime.DeltaTime, hour, minute, second, f
 	var err error
 
 	event := &SMPTEOffsetEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 	err = event.SetHour(hour)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,11 @@
 package event
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+)
 
 func TestSMPTEOffsetEventDeltaTime(t *testing.T) {
 	event := &SMPTEOffsetEvent{}
@@ -189,3 +194,26 @@ func TestNewSMPTEOffsetEvent(t *testing.T) {
 		t.Fatalf("expected: 99 actual: %v", event.subFrame)
 	}
 }
+
+func TestSMPTEOffsetEvent_SetFrameRate(t *testing.T) {
+	event, err := NewSMPTEOffsetEvent(nil, 1, 0, 0, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = event.SetFrameRate(4)
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	err = event.SetFrameRate(constant.FPS30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xff, 0x54, 0x05, 0x61, 0x00, 0x00, 0x00, 0x00}
+	actual := event.Serialize()
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+}
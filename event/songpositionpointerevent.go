@@ -0,0 +1,105 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// SongPositionPointerEvent corresponds to song position pointer message, which is system common message sent only on the wire.
+// The position is counted in MIDI beats, i.e. sixteenth notes, from the start of the song.
+type SongPositionPointerEvent struct {
+	deltaTime deltatime.DeltaTime
+	position  uint16
+}
+
+// deltatime.DeltaTime returns delta time of song position pointer event.
+func (e *SongPositionPointerEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns SongPositionPointer.
+func (e *SongPositionPointerEvent) Kind() Kind {
+	return SongPositionPointer
+}
+
+// AppendTo appends serialized song position pointer event to dst and returns the extended buffer.
+func (e *SongPositionPointerEvent) AppendTo(dst []byte) []byte {
+	// The position is sent LSB first like pitch bend.
+	return append(dst, constant.SongPositionPointer, byte(e.position&0x7f), byte(e.position>>7))
+}
+
+// Serialize serializes song position pointer event.
+func (e *SongPositionPointerEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized song position pointer event.
+func (e *SongPositionPointerEvent) Len() int {
+	return 3
+}
+
+// RunningStatus is fake method.
+// It returns always false because the system common messages cancel running status.
+func (e *SongPositionPointerEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the system common messages cancel running status.
+func (e *SongPositionPointerEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// SetPosition sets position in MIDI beats.
+func (e *SongPositionPointerEvent) SetPosition(position uint16) error {
+	if position > 0x3fff {
+		return fmt.Errorf("midi: maximum value of song position is 16383 (0x3fff)")
+	}
+	e.position = position
+
+	return nil
+}
+
+// Position returns position in MIDI beats.
+func (e *SongPositionPointerEvent) Position() uint16 {
+	return e.position
+}
+
+// Clone returns a deep copy of song position pointer event.
+func (e *SongPositionPointerEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same song position pointer event. The encoding of delta time is ignored.
+func (e *SongPositionPointerEvent) Equal(other Event) bool {
+	v, ok := other.(*SongPositionPointerEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.position == v.position
+}
+
+// String returns string representation of song position pointer event.
+func (e *SongPositionPointerEvent) String() string {
+	return fmt.Sprintf("&SongPositionPointerEvent{position: %v}", e.position)
+}
+
+// NewSongPositionPointerEvent returns SongPositionPointerEvent with the given parameter.
+func NewSongPositionPointerEvent(deltaTime *deltatime.DeltaTime, position uint16) (*SongPositionPointerEvent, error) {
+	event := &SongPositionPointerEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err := event.SetPosition(position)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,56 @@
+package event
+
+import "testing"
+
+func TestSongPositionPointerEvent_Serialize(t *testing.T) {
+	event, err := NewSongPositionPointerEvent(nil, 0x0102)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xf2, 0x02, 0x02}
+	actual := event.Serialize()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		a := actual[i]
+		if e != a {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		}
+	}
+}
+
+func TestSongPositionPointerEvent_SetPosition(t *testing.T) {
+	event := &SongPositionPointerEvent{}
+
+	err := event.SetPosition(0x4000)
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	err = event.SetPosition(0x3fff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := uint16(0x3fff)
+	actual := event.Position()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestSongPositionPointerEvent_String(t *testing.T) {
+	event, err := NewSongPositionPointerEvent(nil, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "&SongPositionPointerEvent{position: 16}"
+	actual := event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,103 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// SongSelectEvent corresponds to song select message, which is system common message sent only on the wire.
+type SongSelectEvent struct {
+	deltaTime deltatime.DeltaTime
+	song      uint8
+}
+
+// deltatime.DeltaTime returns delta time of song select event.
+func (e *SongSelectEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns SongSelect.
+func (e *SongSelectEvent) Kind() Kind {
+	return SongSelect
+}
+
+// AppendTo appends serialized song select event to dst and returns the extended buffer.
+func (e *SongSelectEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.SongSelect, e.song)
+}
+
+// Serialize serializes song select event.
+func (e *SongSelectEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized song select event.
+func (e *SongSelectEvent) Len() int {
+	return 2
+}
+
+// RunningStatus is fake method.
+// It returns always false because the system common messages cancel running status.
+func (e *SongSelectEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the system common messages cancel running status.
+func (e *SongSelectEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// SetSong sets song number.
+func (e *SongSelectEvent) SetSong(song uint8) error {
+	if song > 0x7f {
+		return fmt.Errorf("midi: maximum value of song is 127 (0x7f)")
+	}
+	e.song = song
+
+	return nil
+}
+
+// Song returns song number.
+func (e *SongSelectEvent) Song() uint8 {
+	return e.song
+}
+
+// Clone returns a deep copy of song select event.
+func (e *SongSelectEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same song select event. The encoding of delta time is ignored.
+func (e *SongSelectEvent) Equal(other Event) bool {
+	v, ok := other.(*SongSelectEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.song == v.song
+}
+
+// String returns string representation of song select event.
+func (e *SongSelectEvent) String() string {
+	return fmt.Sprintf("&SongSelectEvent{song: %v}", e.song)
+}
+
+// NewSongSelectEvent returns SongSelectEvent with the given parameter.
+func NewSongSelectEvent(deltaTime *deltatime.DeltaTime, song uint8) (*SongSelectEvent, error) {
+	event := &SongSelectEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err := event.SetSong(song)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,30 @@
+package event
+
+import "testing"
+
+func TestSongSelectEvent_Serialize(t *testing.T) {
+	event, err := NewSongSelectEvent(nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xf3, 0x03}
+	actual := event.Serialize()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		a := actual[i]
+		if e != a {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		}
+	}
+}
+
+func TestNewSongSelectEvent(t *testing.T) {
+	_, err := NewSongSelectEvent(nil, 0x80)
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
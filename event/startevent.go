@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// StartEvent corresponds to start message, which is system real-time message sent only on the wire.
+type StartEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of start event.
+func (e *StartEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns Start.
+func (e *StartEvent) Kind() Kind {
+	return Start
+}
+
+// AppendTo appends serialized start event to dst and returns the extended buffer.
+func (e *StartEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.Start)
+}
+
+// Serialize serializes start event.
+func (e *StartEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized start event.
+func (e *StartEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the start event has no data bytes.
+func (e *StartEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the start event has no data bytes.
+func (e *StartEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of start event.
+func (e *StartEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same start event. The encoding of delta time is ignored.
+func (e *StartEvent) Equal(other Event) bool {
+	v, ok := other.(*StartEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of start event.
+func (e *StartEvent) String() string {
+	return "&StartEvent{}"
+}
+
+// NewStartEvent returns StartEvent with the given parameter.
+func NewStartEvent(deltaTime *deltatime.DeltaTime) (*StartEvent, error) {
+	event := &StartEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
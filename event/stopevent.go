@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// StopEvent corresponds to stop message, which is system real-time message sent only on the wire.
+type StopEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of stop event.
+func (e *StopEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns Stop.
+func (e *StopEvent) Kind() Kind {
+	return Stop
+}
+
+// AppendTo appends serialized stop event to dst and returns the extended buffer.
+func (e *StopEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.Stop)
+}
+
+// Serialize serializes stop event.
+func (e *StopEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized stop event.
+func (e *StopEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the stop event has no data bytes.
+func (e *StopEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the stop event has no data bytes.
+func (e *StopEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of stop event.
+func (e *StopEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same stop event. The encoding of delta time is ignored.
+func (e *StopEvent) Equal(other Event) bool {
+	v, ok := other.(*StopEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of stop event.
+func (e *StopEvent) String() string {
+	return "&StopEvent{}"
+}
+
+// NewStopEvent returns StopEvent with the given parameter.
+func NewStopEvent(deltaTime *deltatime.DeltaTime) (*StopEvent, error) {
+	event := &StopEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,43 @@ import (
 
 // SystemExclusiveEvent corresponds to system exclusive meta event.
 type SystemExclusiveEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	data          []byte
 }
 
 // deltatime.DeltaTime returns delta time of system exclusive event.
 func (e *SystemExclusiveEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns SystemExclusive.
+func (e *SystemExclusiveEvent) Kind() Kind {
+	return SystemExclusive
+}
+
+// SystemExclusiveType returns 0xf0.
+func (e *SystemExclusiveEvent) SystemExclusiveType() uint8 {
+	return constant.SystemExclusive
+}
+
+// AppendTo appends serialized system exclusive event to dst and returns the extended buffer.
+func (e *SystemExclusiveEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.SystemExclusive)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.data)))
+	dst = append(dst, e.data...)
+
+	return dst
 }
 
 // Serialize serializes system exclusive event.
 func (e *SystemExclusiveEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.SystemExclusive)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Data())))
-	bs = append(bs, q.Serialize()...)
-	bs = append(bs, e.Data()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized system exclusive event.
+func (e *SystemExclusiveEvent) Len() int {
+	return 1 + quantity.Len(uint32(len(e.data))) + len(e.data)
 }
 
 // SetRunningStatus sets running status.
@@ -65,6 +79,24 @@ func (e *SystemExclusiveEvent) Data() []byte {
 	return e.data
 }
 
+// Clone returns a deep copy of system exclusive event.
+func (e *SystemExclusiveEvent) Clone() Event {
+	clone := *e
+	clone.data = cloneBytes(e.data)
+
+	return &clone
+}
+
+// Equal reports whether other is the same system exclusive event. The running status and the encoding of delta time are ignored.
+func (e *SystemExclusiveEvent) Equal(other Event) bool {
+	v, ok := other.(*SystemExclusiveEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.data, v.data)
+}
+
 // String returns string representation of system exclusive event.
 func (e *SystemExclusiveEvent) String() string {
 	return fmt.Sprintf("&SystemExclusiveEvent{data: %v bytes}", len(e.Data()))
@@ -75,7 +107,9 @@ func NewSystemExclusiveEvent(deltaTime *deltatime.DeltaTime, data []byte) (*Syst
 	var err error
 
 	event := &SystemExclusiveEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetData(data)
 	if err != nil {
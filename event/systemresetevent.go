@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// SystemResetEvent corresponds to system reset message, which is system real-time message sent only on the wire.
+type SystemResetEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of system reset event.
+func (e *SystemResetEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns SystemReset.
+func (e *SystemResetEvent) Kind() Kind {
+	return SystemReset
+}
+
+// AppendTo appends serialized system reset event to dst and returns the extended buffer.
+func (e *SystemResetEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.SystemReset)
+}
+
+// Serialize serializes system reset event.
+func (e *SystemResetEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized system reset event.
+func (e *SystemResetEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the system reset event has no data bytes.
+func (e *SystemResetEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the system reset event has no data bytes.
+func (e *SystemResetEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of system reset event.
+func (e *SystemResetEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same system reset event. The encoding of delta time is ignored.
+func (e *SystemResetEvent) Equal(other Event) bool {
+	v, ok := other.(*SystemResetEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of system reset event.
+func (e *SystemResetEvent) String() string {
+	return "&SystemResetEvent{}"
+}
+
+// NewSystemResetEvent returns SystemResetEvent with the given parameter.
+func NewSystemResetEvent(deltaTime *deltatime.DeltaTime) (*SystemResetEvent, error) {
+	event := &SystemResetEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
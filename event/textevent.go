@@ -1,6 +1,7 @@
 package event
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/moutend/go-midi/constant"
@@ -10,30 +11,48 @@ import (
 
 // TextEvent corresponds to text event.
 type TextEvent struct {
-	deltaTime     *deltatime.DeltaTime
+	deltaTime     deltatime.DeltaTime
 	runningStatus bool
 	text          []byte
 }
 
 // deltatime.DeltaTime returns delta time of text event.
 func (e *TextEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns Text.
+func (e *TextEvent) Kind() Kind {
+	return Text
+}
+
+// MetaEventType returns meta event type of text event.
+func (e *TextEvent) MetaEventType() uint8 {
+	return constant.Text
+}
+
+// Data returns text as data of meta event.
+func (e *TextEvent) Data() []byte {
+	return e.Text()
+}
+
+// AppendTo appends serialized text event to dst and returns the extended buffer.
+func (e *TextEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.Text)
+	dst = quantity.AppendVLQ(dst, uint32(len(e.text)))
+	dst = append(dst, e.text...)
+
+	return dst
 }
 
 // Serialize serializes text event.
 func (e *TextEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.Text)
-
-	q := &quantity.Quantity{}
-	q.SetUint32(uint32(len(e.Text())))
-	bs = append(bs, q.Value()...)
-	bs = append(bs, e.Text()...)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized text event.
+func (e *TextEvent) Len() int {
+	return 2 + quantity.Len(uint32(len(e.text))) + len(e.text)
 }
 
 // SetRunningStatus sets running status.
@@ -68,6 +87,24 @@ func (e *TextEvent) Text() []byte {
 	return text
 }
 
+// Clone returns a deep copy of text event.
+func (e *TextEvent) Clone() Event {
+	clone := *e
+	clone.text = cloneBytes(e.text)
+
+	return &clone
+}
+
+// Equal reports whether other is the same text event. The running status and the encoding of delta time are ignored.
+func (e *TextEvent) Equal(other Event) bool {
+	v, ok := other.(*TextEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && bytes.Equal(e.text, v.text)
+}
+
 // String returns string representation of text event.
 func (e *TextEvent) String() string {
 	return fmt.Sprintf("&TextEvent{text: \"%v\"}", string(e.Text()))
@@ -78,7 +115,9 @@ func NewTextEvent(deltaTime *deltatime.DeltaTime, text []byte) (*TextEvent, erro
 	var err error
 
 	event := &TextEvent{}
-	event.deltaTime = deltaTime
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
 
 	err = event.SetText(text)
 	if err != nil {
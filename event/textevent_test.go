@@ -98,3 +98,47 @@ func TestNewTextEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestTextEvent_Clone(t *testing.T) {
+	data := []byte("text")
+
+	event, err := NewTextEvent(nil, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := event.Clone()
+
+	if !event.Equal(clone) {
+		t.Fatalf("clone must be equal to the original")
+	}
+
+	data[0] = 'n'
+	clone.DeltaTime().SetUint32(1)
+
+	if string(clone.(*TextEvent).Text()) != "text" {
+		t.Fatalf("expected: text actual: %s", clone.(*TextEvent).Text())
+	}
+	if event.DeltaTime().Uint32() != 0 {
+		t.Fatalf("expected: 0 actual: %v", event.DeltaTime().Uint32())
+	}
+}
+
+func TestTextEvent_Equal(t *testing.T) {
+	a, _ := NewTextEvent(nil, []byte("text"))
+	b, _ := NewTextEvent(nil, []byte("text"))
+	c, _ := NewTextEvent(nil, []byte("other"))
+	d, _ := NewMarkerEvent(nil, []byte("text"))
+
+	b.SetRunningStatus(true)
+
+	if !a.Equal(b) {
+		t.Fatalf("events must be equal regardless of running status")
+	}
+	if a.Equal(c) {
+		t.Fatalf("events with different text must not be equal")
+	}
+	if a.Equal(d) {
+		t.Fatalf("events with different type must not be equal")
+	}
+}
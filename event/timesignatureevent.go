@@ -9,7 +9,7 @@ import (
 
 // TimeSignatureEvent corresponds to time signature meta event.
 type TimeSignatureEvent struct {
-	deltaTime      *deltatime.DeltaTime
+	deltaTime      deltatime.DeltaTime
 	runningStatus  bool
 	numerator      uint8
 	denominator    uint8
@@ -19,19 +19,45 @@ type TimeSignatureEvent struct {
 
 // deltatime.DeltaTime returns delta time of time signature event.
 func (e *TimeSignatureEvent) DeltaTime() *deltatime.DeltaTime {
-	if e.deltaTime == nil {
-		e.deltaTime = &deltatime.DeltaTime{}
-	}
-	return e.deltaTime
+	return &e.deltaTime
+}
+
+// Kind returns TimeSignature.
+func (e *TimeSignatureEvent) Kind() Kind {
+	return TimeSignature
+}
+
+// MetaEventType returns meta event type of time signature event.
+func (e *TimeSignatureEvent) MetaEventType() uint8 {
+	return constant.TimeSignature
+}
+
+// Data returns data of time signature event, i.e. the serialized event without its type and length.
+func (e *TimeSignatureEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 4))
+}
+
+// appendData appends data of time signature event to dst.
+func (e *TimeSignatureEvent) appendData(dst []byte) []byte {
+	return append(dst, e.numerator, e.denominator, e.metronomePulse, e.quarterNote)
+}
+
+// AppendTo appends serialized time signature event to dst and returns the extended buffer.
+func (e *TimeSignatureEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.TimeSignature)
+	dst = append(dst, 0x04)
+
+	return e.appendData(dst)
 }
 
 // Serialize serializes time signature event.
 func (e *TimeSignatureEvent) Serialize() []byte {
-	bs := []byte{}
-	bs = append(bs, constant.Meta, constant.TimeSignature)
-	bs = append(bs, 0x04, e.numerator, e.denominator, e.metronomePulse, e.quarterNote)
+	return e.AppendTo(nil)
+}
 
-	return bs
+// Len returns the length of serialized time signature event.
+func (e *TimeSignatureEvent) Len() int {
+	return 7
 }
 
 // SetRunningStatus sets running status.
@@ -92,6 +118,23 @@ func (e *TimeSignatureEvent) QuarterNote() uint8 {
 	return e.quarterNote
 }
 
+// Clone returns a deep copy of time signature event.
+func (e *TimeSignatureEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same time signature event. The running status and the encoding of delta time are ignored.
+func (e *TimeSignatureEvent) Equal(other Event) bool {
+	v, ok := other.(*TimeSignatureEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.numerator == v.numerator && e.denominator == v.denominator && e.metronomePulse == v.metronomePulse && e.quarterNote == v.quarterNote
+}
+
 // String returns string representation of time signature event.
 func (e *TimeSignatureEvent) String() string {
 	return fmt.Sprintf("&TimeSignatureEvent{numerator: %v, denominator: %v, metronomePulse: %v, quarterNote: %v}", e.numerator, e.denominator, e.metronomePulse, e.quarterNote)
@@ -100,11 +143,14 @@ func (e *TimeSignatureEvent) String() string {
 // NewTimeSignatureEvent returns TimeSignatureEvent with the given parameter.
 func NewTimeSignatureEvent(deltaTime *deltatime.DeltaTime, numerator, denominator, metronomePulse, quarterNote uint8) (*TimeSignatureEvent, error) {
 	event := &TimeSignatureEvent{
-		deltaTime:      deltaTime,
 		numerator:      numerator,
 		denominator:    denominator,
 		metronomePulse: metronomePulse,
 		quarterNote:    quarterNote,
 	}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
 	return event, nil
 }
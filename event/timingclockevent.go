@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// TimingClockEvent corresponds to timing clock message, which is system real-time message sent only on the wire.
+type TimingClockEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of timing clock event.
+func (e *TimingClockEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns TimingClock.
+func (e *TimingClockEvent) Kind() Kind {
+	return TimingClock
+}
+
+// AppendTo appends serialized timing clock event to dst and returns the extended buffer.
+func (e *TimingClockEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.TimingClock)
+}
+
+// Serialize serializes timing clock event.
+func (e *TimingClockEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized timing clock event.
+func (e *TimingClockEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the timing clock event has no data bytes.
+func (e *TimingClockEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the timing clock event has no data bytes.
+func (e *TimingClockEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of timing clock event.
+func (e *TimingClockEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same timing clock event. The encoding of delta time is ignored.
+func (e *TimingClockEvent) Equal(other Event) bool {
+	v, ok := other.(*TimingClockEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of timing clock event.
+func (e *TimingClockEvent) String() string {
+	return "&TimingClockEvent{}"
+}
+
+// NewTimingClockEvent returns TimingClockEvent with the given parameter.
+func NewTimingClockEvent(deltaTime *deltatime.DeltaTime) (*TimingClockEvent, error) {
+	event := &TimingClockEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
@@ -0,0 +1,49 @@
+package event
+
+import "testing"
+
+func TestTimingClockEvent_Serialize(t *testing.T) {
+	for _, c := range []struct {
+		event    Event
+		expected byte
+	}{
+		{&TuneRequestEvent{}, 0xf6},
+		{&TimingClockEvent{}, 0xf8},
+		{&StartEvent{}, 0xfa},
+		{&ContinueEvent{}, 0xfb},
+		{&StopEvent{}, 0xfc},
+		{&ActiveSensingEvent{}, 0xfe},
+		{&SystemResetEvent{}, 0xff},
+	} {
+		actual := c.event.Serialize()
+
+		if len(actual) != 1 || actual[0] != c.expected {
+			t.Fatalf("expected: %x actual: % x", c.expected, actual)
+		}
+	}
+}
+
+func TestTimingClockEvent_SetRunningStatus(t *testing.T) {
+	event := &TimingClockEvent{}
+	event.SetRunningStatus(true)
+
+	expected := false
+	actual := event.RunningStatus()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestTimingClockEvent_String(t *testing.T) {
+	event, err := NewTimingClockEvent(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "&TimingClockEvent{}"
+	actual := event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,80 @@
+package event
+
+import (
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// TuneRequestEvent corresponds to tune request message, which is system common message sent only on the wire.
+type TuneRequestEvent struct {
+	deltaTime deltatime.DeltaTime
+}
+
+// deltatime.DeltaTime returns delta time of tune request event.
+func (e *TuneRequestEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns TuneRequest.
+func (e *TuneRequestEvent) Kind() Kind {
+	return TuneRequest
+}
+
+// AppendTo appends serialized tune request event to dst and returns the extended buffer.
+func (e *TuneRequestEvent) AppendTo(dst []byte) []byte {
+	return append(dst, constant.TuneRequest)
+}
+
+// Serialize serializes tune request event.
+func (e *TuneRequestEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized tune request event.
+func (e *TuneRequestEvent) Len() int {
+	return 1
+}
+
+// RunningStatus is fake method.
+// It returns always false because the tune request event has no data bytes.
+func (e *TuneRequestEvent) RunningStatus() bool {
+	return false
+}
+
+// SetRunningStatus is fake method.
+// It does nothing because the tune request event has no data bytes.
+func (e *TuneRequestEvent) SetRunningStatus(status bool) {
+	return
+}
+
+// Clone returns a deep copy of tune request event.
+func (e *TuneRequestEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same tune request event. The encoding of delta time is ignored.
+func (e *TuneRequestEvent) Equal(other Event) bool {
+	v, ok := other.(*TuneRequestEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32()
+}
+
+// String returns string representation of tune request event.
+func (e *TuneRequestEvent) String() string {
+	return "&TuneRequestEvent{}"
+}
+
+// NewTuneRequestEvent returns TuneRequestEvent with the given parameter.
+func NewTuneRequestEvent(deltaTime *deltatime.DeltaTime) (*TuneRequestEvent, error) {
+	event := &TuneRequestEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	return event, nil
+}
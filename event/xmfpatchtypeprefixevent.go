@@ -0,0 +1,122 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/deltatime"
+)
+
+// XMFPatchTypePrefixEvent corresponds to XMF patch type prefix meta event.
+// It tells which instrument set, General MIDI 1, General MIDI 2 or DLS, is used by the following events.
+type XMFPatchTypePrefixEvent struct {
+	deltaTime     deltatime.DeltaTime
+	runningStatus bool
+	patchType     uint8
+}
+
+// deltatime.DeltaTime returns delta time of XMF patch type prefix event.
+func (e *XMFPatchTypePrefixEvent) DeltaTime() *deltatime.DeltaTime {
+	return &e.deltaTime
+}
+
+// Kind returns XMFPatchTypePrefix.
+func (e *XMFPatchTypePrefixEvent) Kind() Kind {
+	return XMFPatchTypePrefix
+}
+
+// MetaEventType returns meta event type of XMF patch type prefix event.
+func (e *XMFPatchTypePrefixEvent) MetaEventType() uint8 {
+	return constant.XMFPatchTypePrefix
+}
+
+// Data returns data of XMF patch type prefix event, i.e. the serialized event without its type and length.
+func (e *XMFPatchTypePrefixEvent) Data() []byte {
+	return e.appendData(make([]byte, 0, 1))
+}
+
+// appendData appends data of XMF patch type prefix event to dst.
+func (e *XMFPatchTypePrefixEvent) appendData(dst []byte) []byte {
+	return append(dst, e.patchType)
+}
+
+// AppendTo appends serialized XMF patch type prefix event to dst and returns the extended buffer.
+func (e *XMFPatchTypePrefixEvent) AppendTo(dst []byte) []byte {
+	dst = append(dst, constant.Meta, constant.XMFPatchTypePrefix)
+	dst = append(dst, 0x01)
+
+	return e.appendData(dst)
+}
+
+// Serialize serializes XMF patch type prefix event.
+func (e *XMFPatchTypePrefixEvent) Serialize() []byte {
+	return e.AppendTo(nil)
+}
+
+// Len returns the length of serialized XMF patch type prefix event.
+func (e *XMFPatchTypePrefixEvent) Len() int {
+	return 4
+}
+
+// SetRunningStatus sets running status.
+func (e *XMFPatchTypePrefixEvent) SetRunningStatus(status bool) {
+	e.runningStatus = status
+}
+
+// RunningStatus returns running status.
+func (e *XMFPatchTypePrefixEvent) RunningStatus() bool {
+	return e.runningStatus
+}
+
+// SetPatchType sets patch type. It must be constant.GeneralMIDI1, constant.GeneralMIDI2 or constant.DLS.
+func (e *XMFPatchTypePrefixEvent) SetPatchType(patchType uint8) error {
+	if patchType < constant.GeneralMIDI1 || patchType > constant.DLS {
+		return fmt.Errorf("midi: patch type must be 1 (GM1), 2 (GM2) or 3 (DLS)")
+	}
+	e.patchType = patchType
+
+	return nil
+}
+
+// PatchType returns patch type.
+func (e *XMFPatchTypePrefixEvent) PatchType() uint8 {
+	return e.patchType
+}
+
+// Clone returns a deep copy of XMF patch type prefix event.
+func (e *XMFPatchTypePrefixEvent) Clone() Event {
+	clone := *e
+
+	return &clone
+}
+
+// Equal reports whether other is the same XMF patch type prefix event. The running status and the encoding of delta time are ignored.
+func (e *XMFPatchTypePrefixEvent) Equal(other Event) bool {
+	v, ok := other.(*XMFPatchTypePrefixEvent)
+	if !ok {
+		return false
+	}
+
+	return e.deltaTime.Uint32() == v.deltaTime.Uint32() && e.patchType == v.patchType
+}
+
+// String returns string representation of XMF patch type prefix event.
+func (e *XMFPatchTypePrefixEvent) String() string {
+	return fmt.Sprintf("&XMFPatchTypePrefixEvent{patchType: %v}", e.patchType)
+}
+
+// NewXMFPatchTypePrefixEvent returns XMFPatchTypePrefixEvent with the given parameter.
+func NewXMFPatchTypePrefixEvent(deltaTime *deltatime.DeltaTime, patchType uint8) (*XMFPatchTypePrefixEvent, error) {
+	var err error
+
+	event := &XMFPatchTypePrefixEvent{}
+	if deltaTime != nil {
+		event.deltaTime = *deltaTime
+	}
+
+	err = event.SetPatchType(patchType)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
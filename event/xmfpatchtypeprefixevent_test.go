@@ -0,0 +1,71 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+func TestXMFPatchTypePrefixEventDeltaTime(t *testing.T) {
+	event := &XMFPatchTypePrefixEvent{}
+	dt := event.DeltaTime()
+	if dt == nil {
+		t.Fatal("deltatime.DeltaTime() don't return nil")
+	}
+}
+
+func TestXMFPatchTypePrefixEvent_String(t *testing.T) {
+	event, err := NewXMFPatchTypePrefixEvent(nil, constant.GeneralMIDI2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "&XMFPatchTypePrefixEvent{patchType: 2}"
+	actual := event.String()
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestXMFPatchTypePrefixEvent_Serialize(t *testing.T) {
+	event, err := NewXMFPatchTypePrefixEvent(nil, constant.DLS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xff, 0x60, 0x01, 0x03}
+	actual := event.Serialize()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		a := actual[i]
+		if e != a {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		}
+	}
+}
+
+func TestXMFPatchTypePrefixEvent_SetPatchType(t *testing.T) {
+	event := &XMFPatchTypePrefixEvent{}
+
+	for _, patchType := range []uint8{0x00, 0x04} {
+		if err := event.SetPatchType(patchType); err == nil {
+			t.Fatalf("err must not be nil (patchType: %v)", patchType)
+		}
+	}
+	if err := event.SetPatchType(constant.GeneralMIDI1); err != nil {
+		t.Fatal(err)
+	}
+	if event.PatchType() != constant.GeneralMIDI1 {
+		t.Fatalf("expected: 1 actual: %v", event.PatchType())
+	}
+}
+
+func TestNewXMFPatchTypePrefixEvent(t *testing.T) {
+	_, err := NewXMFPatchTypePrefixEvent(nil, 0)
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
@@ -0,0 +1,150 @@
+package instrument
+
+import "github.com/moutend/go-midi/constant"
+
+// gm2Variations is the GM2 sound set keyed by program and bank select LSB.
+// The variations with bank select LSB 0 are the GM sounds.
+var gm2Variations = map[variation]string{
+	{constant.AcousticGrandPiano, 1}:  "Acoustic Grand Piano (wide)",
+	{constant.AcousticGrandPiano, 2}:  "Acoustic Grand Piano (dark)",
+	{constant.BrightAcousticPiano, 1}: "Bright Acoustic Piano (wide)",
+	{constant.ElectricGrandPiano, 1}:  "Electric Grand Piano (wide)",
+	{constant.HonkyTonkPiano, 1}:      "Honky-tonk Piano (wide)",
+	{constant.ElectricPiano1, 1}:      "Detuned Electric Piano 1",
+	{constant.ElectricPiano1, 2}:      "Electric Piano 1 (velocity mix)",
+	{constant.ElectricPiano1, 3}:      "60's Electric Piano",
+	{constant.ElectricPiano2, 1}:      "Detuned Electric Piano 2",
+	{constant.ElectricPiano2, 2}:      "Electric Piano 2 (velocity mix)",
+	{constant.ElectricPiano2, 3}:      "EP Legend",
+	{constant.ElectricPiano2, 4}:      "EP Phase",
+	{constant.Harpsichord, 1}:         "Harpsichord (octave mix)",
+	{constant.Harpsichord, 2}:         "Harpsichord (wide)",
+	{constant.Harpsichord, 3}:         "Harpsichord (with key off)",
+	{constant.Clavi, 1}:               "Pulse Clavi",
+	{constant.Vibraphone, 1}:          "Vibraphone (wide)",
+	{constant.Marimba, 1}:             "Marimba (wide)",
+	{constant.TubularBells, 1}:        "Church Bell",
+	{constant.TubularBells, 2}:        "Carillon",
+	{constant.DrawbarOrgan, 1}:        "Detuned Drawbar Organ",
+	{constant.DrawbarOrgan, 2}:        "Italian 60's Organ",
+	{constant.DrawbarOrgan, 3}:        "Drawbar Organ 2",
+	{constant.PercussiveOrgan, 1}:     "Detuned Percussive Organ",
+	{constant.PercussiveOrgan, 2}:     "Percussive Organ 2",
+	{constant.RockOrgan, 1}:           "Rock Organ 2",
+	{constant.ChurchOrgan, 1}:         "Church Organ (octave mix)",
+	{constant.ChurchOrgan, 2}:         "Detuned Church Organ",
+	{constant.ReedOrgan, 1}:           "Puff Organ",
+	{constant.Accordion, 1}:           "Accordion 2",
+	{constant.AcousticNylonGuitar, 1}: "Ukulele",
+	{constant.AcousticNylonGuitar, 2}: "Acoustic Guitar (nylon + key off)",
+	{constant.AcousticNylonGuitar, 3}: "Acoustic Guitar (nylon 2)",
+	{constant.AcousticSteelGuitar, 1}: "12-Strings Guitar",
+	{constant.AcousticSteelGuitar, 2}: "Mandolin",
+	{constant.AcousticSteelGuitar, 3}: "Steel Guitar with Body Sound",
+	{constant.ElectricJazzGuitar, 1}:  "Electric Guitar (pedal steel)",
+	{constant.ElectricCleanGuitar, 1}: "Electric Guitar (detuned clean)",
+	{constant.ElectricCleanGuitar, 2}: "Mid Tone Guitar",
+	{constant.ElectricMutedGuitar, 1}: "Electric Guitar (funky cutting)",
+	{constant.ElectricMutedGuitar, 2}: "Electric Guitar (muted velo-sw)",
+	{constant.ElectricMutedGuitar, 3}: "Jazz Man",
+	{constant.OverdrivenGuitar, 1}:    "Guitar Pinch",
+	{constant.DistortionGuitar, 1}:    "Distortion Guitar (with feedback)",
+	{constant.DistortionGuitar, 2}:    "Distorted Rhythm Guitar",
+	{constant.GuitarHarmonics, 1}:     "Guitar Feedback",
+	{constant.ElectricFingerBass, 1}:  "Finger Slap Bass",
+	{constant.SynthBass1, 1}:          "Synth Bass (warm)",
+	{constant.SynthBass1, 2}:          "Synth Bass 3 (resonance)",
+	{constant.SynthBass1, 3}:          "Clavi Bass",
+	{constant.SynthBass1, 4}:          "Hammer",
+	{constant.SynthBass2, 1}:          "Synth Bass 4 (attack)",
+	{constant.SynthBass2, 2}:          "Synth Bass (rubber)",
+	{constant.SynthBass2, 3}:          "Attack Pulse",
+	{constant.Violin, 1}:              "Violin (slow attack)",
+	{constant.OrchestralHarp, 1}:      "Yang Chin",
+	{constant.StringEnsemble1, 1}:     "Strings and Brass",
+	{constant.StringEnsemble1, 2}:     "60s Strings",
+	{constant.SynthStrings1, 1}:       "Synth Strings 3",
+	{constant.ChoirAahs, 1}:           "Choir Aahs 2",
+	{constant.VoiceOohs, 1}:           "Humming",
+	{constant.SynthVoice, 1}:          "Analog Voice",
+	{constant.OrchestraHit, 1}:        "Bass Hit Plus",
+	{constant.OrchestraHit, 2}:        "6th Hit",
+	{constant.OrchestraHit, 3}:        "Euro Hit",
+	{constant.Trumpet, 1}:             "Dark Trumpet Soft",
+	{constant.Trombone, 1}:            "Trombone 2",
+	{constant.Trombone, 2}:            "Bright Trombone",
+	{constant.MutedTrumpet, 1}:        "Muted Trumpet 2",
+	{constant.FrenchHorn, 1}:          "French Horn 2 (warm)",
+	{constant.BrassSection, 1}:        "Brass Section 2 (octave mix)",
+	{constant.SynthBrass1, 1}:         "Synth Brass 3",
+	{constant.SynthBrass1, 2}:         "Analog Synth Brass 1",
+	{constant.SynthBrass1, 3}:         "Jump Brass",
+	{constant.SynthBrass2, 1}:         "Synth Brass 4",
+	{constant.SynthBrass2, 2}:         "Analog Synth Brass 2",
+	{constant.Lead1Square, 1}:         "Lead 1a (square 2)",
+	{constant.Lead1Square, 2}:         "Lead 1b (sine)",
+	{constant.Lead2Sawtooth, 1}:       "Lead 2a (sawtooth 2)",
+	{constant.Lead2Sawtooth, 2}:       "Lead 2b (saw + pulse)",
+	{constant.Lead2Sawtooth, 3}:       "Lead 2c (double sawtooth)",
+	{constant.Lead2Sawtooth, 4}:       "Lead 2d (sequenced analog)",
+	{constant.Lead5Charang, 1}:        "Lead 5a (wire lead)",
+	{constant.Lead8BassLead, 1}:       "Lead 8a (soft wrl)",
+	{constant.Pad2Warm, 1}:            "Pad 2a (sine pad)",
+	{constant.Pad4Choir, 1}:           "Pad 4a (itopia)",
+	{constant.FX3Crystal, 1}:          "FX 3a (synth mallet)",
+	{constant.FX7Echoes, 1}:           "FX 7a (echo bell)",
+	{constant.FX7Echoes, 2}:           "FX 7b (echo pan)",
+	{constant.Sitar, 1}:               "Sitar 2 (bend)",
+	{constant.Koto, 1}:                "Taisho Koto",
+	{constant.Woodblock, 1}:           "Castanets",
+	{constant.TaikoDrum, 1}:           "Concert Bass Drum",
+	{constant.MelodicTom, 1}:          "Melodic Tom 2 (power)",
+	{constant.SynthDrum, 1}:           "Rhythm Box Tom",
+	{constant.SynthDrum, 2}:           "Electric Drum",
+	{constant.GuitarFretNoise, 1}:     "Guitar Cutting Noise",
+	{constant.GuitarFretNoise, 2}:     "Acoustic Bass String Slap",
+	{constant.BreathNoise, 1}:         "Flute Key Click",
+	{constant.Seashore, 1}:            "Rain",
+	{constant.Seashore, 2}:            "Thunder",
+	{constant.Seashore, 3}:            "Wind",
+	{constant.Seashore, 4}:            "Stream",
+	{constant.Seashore, 5}:            "Bubble",
+	{constant.BirdTweet, 1}:           "Dog",
+	{constant.BirdTweet, 2}:           "Horse Gallop",
+	{constant.BirdTweet, 3}:           "Bird Tweet 2",
+	{constant.TelephoneRing, 1}:       "Telephone Ring 2",
+	{constant.TelephoneRing, 2}:       "Door Creaking",
+	{constant.TelephoneRing, 3}:       "Door",
+	{constant.TelephoneRing, 4}:       "Scratch",
+	{constant.TelephoneRing, 5}:       "Wind Chime",
+	{constant.Helicopter, 1}:          "Car Engine",
+	{constant.Helicopter, 2}:          "Car Stop",
+	{constant.Helicopter, 3}:          "Car Pass",
+	{constant.Helicopter, 4}:          "Car Crash",
+	{constant.Helicopter, 5}:          "Siren",
+	{constant.Helicopter, 6}:          "Train",
+	{constant.Helicopter, 7}:          "Jetplane",
+	{constant.Helicopter, 8}:          "Starship",
+	{constant.Helicopter, 9}:          "Burst Noise",
+	{constant.Applause, 1}:            "Laughing",
+	{constant.Applause, 2}:            "Screaming",
+	{constant.Applause, 3}:            "Punch",
+	{constant.Applause, 4}:            "Heart Beat",
+	{constant.Applause, 5}:            "Footsteps",
+	{constant.Gunshot, 1}:             "Machine Gun",
+	{constant.Gunshot, 2}:             "Lasergun",
+	{constant.Gunshot, 3}:             "Explosion",
+}
+
+// gm2DrumSets is the GM2 drum sets keyed by program.
+var gm2DrumSets = map[constant.GM]string{
+	0:  "Standard Set",
+	8:  "Room Set",
+	16: "Power Set",
+	24: "Electronic Set",
+	25: "Analog Set",
+	32: "Jazz Set",
+	40: "Brush Set",
+	48: "Orchestra Set",
+	56: "SFX Set",
+}
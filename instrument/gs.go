@@ -0,0 +1,106 @@
+package instrument
+
+import "github.com/moutend/go-midi/constant"
+
+// gsVariations is the common GS variation tones keyed by program and bank select MSB.
+// The tones with bank select MSB 0 are the capital tones which correspond to the GM sounds.
+var gsVariations = map[variation]string{
+	{constant.AcousticGrandPiano, 8}:   "Piano 1w",
+	{constant.AcousticGrandPiano, 16}:  "Piano 1d",
+	{constant.BrightAcousticPiano, 8}:  "Piano 2w",
+	{constant.ElectricGrandPiano, 8}:   "Piano 3w",
+	{constant.HonkyTonkPiano, 8}:       "Honky-tonk 2",
+	{constant.ElectricPiano1, 8}:       "Detuned EP 1",
+	{constant.ElectricPiano1, 16}:      "E.Piano 1w",
+	{constant.ElectricPiano1, 24}:      "60's E.Piano",
+	{constant.ElectricPiano2, 8}:       "Detuned EP 2",
+	{constant.ElectricPiano2, 16}:      "E.Piano 2w",
+	{constant.Harpsichord, 8}:          "Coupled Hps.",
+	{constant.Harpsichord, 16}:         "Harpsi.w",
+	{constant.Harpsichord, 24}:         "Harpsi.o",
+	{constant.Vibraphone, 8}:           "Vib.w",
+	{constant.Marimba, 8}:              "Marimba w",
+	{constant.TubularBells, 8}:         "Church Bell",
+	{constant.TubularBells, 9}:         "Carillon",
+	{constant.DrawbarOrgan, 8}:         "Detuned Or.1",
+	{constant.DrawbarOrgan, 16}:        "60's Organ 1",
+	{constant.DrawbarOrgan, 32}:        "Organ 4",
+	{constant.PercussiveOrgan, 8}:      "Detuned Or.2",
+	{constant.PercussiveOrgan, 32}:     "Organ 5",
+	{constant.ChurchOrgan, 8}:          "Church Org.2",
+	{constant.ChurchOrgan, 16}:         "Church Org.3",
+	{constant.Accordion, 8}:            "Accordion It",
+	{constant.AcousticNylonGuitar, 8}:  "Ukulele",
+	{constant.AcousticNylonGuitar, 16}: "Nylon Gt.o",
+	{constant.AcousticNylonGuitar, 32}: "Nylon Gt.2",
+	{constant.AcousticSteelGuitar, 8}:  "12-str.Gt",
+	{constant.AcousticSteelGuitar, 16}: "Mandolin",
+	{constant.ElectricJazzGuitar, 8}:   "Hawaiian Gt.",
+	{constant.ElectricCleanGuitar, 8}:  "Chorus Gt.",
+	{constant.ElectricMutedGuitar, 8}:  "Funk Gt.",
+	{constant.DistortionGuitar, 8}:     "Feedback Gt.",
+	{constant.GuitarHarmonics, 8}:      "Gt. Feedback",
+	{constant.SynthBass2, 8}:           "Synth Bass 4",
+	{constant.SynthBass2, 16}:          "Rubber Bass",
+	{constant.Violin, 8}:               "Slow Violin",
+	{constant.StringEnsemble1, 8}:      "Orchestra",
+	{constant.SynthStrings1, 8}:        "Syn.Strings3",
+	{constant.BrassSection, 8}:         "Brass 2",
+	{constant.SynthBrass1, 8}:          "Synth Brass3",
+	{constant.SynthBrass2, 8}:          "Synth Brass4",
+	{constant.Lead1Square, 8}:          "Sine Wave",
+	{constant.Lead2Sawtooth, 8}:        "Doctor Solo",
+	{constant.Sitar, 8}:                "Sitar 2",
+	{constant.Koto, 8}:                 "Taisho Koto",
+	{constant.Woodblock, 8}:            "Castanets",
+	{constant.TaikoDrum, 8}:            "Concert BD",
+	{constant.MelodicTom, 8}:           "Melo. Tom 2",
+	{constant.SynthDrum, 8}:            "808 Tom",
+	{constant.GuitarFretNoise, 1}:      "Gt.Cut Noise",
+	{constant.GuitarFretNoise, 2}:      "String Slap",
+	{constant.BreathNoise, 1}:          "Fl.Key Click",
+	{constant.Seashore, 1}:             "Rain",
+	{constant.Seashore, 2}:             "Thunder",
+	{constant.Seashore, 3}:             "Wind",
+	{constant.Seashore, 4}:             "Stream",
+	{constant.Seashore, 5}:             "Bubble",
+	{constant.BirdTweet, 1}:            "Dog",
+	{constant.BirdTweet, 2}:            "Horse-Gallop",
+	{constant.BirdTweet, 3}:            "Bird 2",
+	{constant.TelephoneRing, 1}:        "Telephone 2",
+	{constant.TelephoneRing, 2}:        "DoorCreaking",
+	{constant.TelephoneRing, 3}:        "Door",
+	{constant.TelephoneRing, 4}:        "Scratch",
+	{constant.TelephoneRing, 5}:        "Wind Chimes",
+	{constant.Helicopter, 1}:           "Car-Engine",
+	{constant.Helicopter, 2}:           "Car-Stop",
+	{constant.Helicopter, 3}:           "Car-Pass",
+	{constant.Helicopter, 4}:           "Car-Crash",
+	{constant.Helicopter, 5}:           "Siren",
+	{constant.Helicopter, 6}:           "Train",
+	{constant.Helicopter, 7}:           "Jetplane",
+	{constant.Helicopter, 8}:           "Starship",
+	{constant.Helicopter, 9}:           "Burst Noise",
+	{constant.Applause, 1}:             "Laughing",
+	{constant.Applause, 2}:             "Screaming",
+	{constant.Applause, 3}:             "Punch",
+	{constant.Applause, 4}:             "Heart Beat",
+	{constant.Applause, 5}:             "Footsteps",
+	{constant.Gunshot, 1}:              "Machine Gun",
+	{constant.Gunshot, 2}:              "Lasergun",
+	{constant.Gunshot, 3}:              "Explosion",
+}
+
+// gsDrumSets is the GS drum sets keyed by program.
+var gsDrumSets = map[constant.GM]string{
+	0:   "Standard Set",
+	8:   "Room Set",
+	16:  "Power Set",
+	24:  "Electronic Set",
+	25:  "TR-808 Set",
+	32:  "Jazz Set",
+	40:  "Brush Set",
+	48:  "Orchestra Set",
+	56:  "SFX Set",
+	127: "CM-64/32L Set",
+}
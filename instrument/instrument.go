@@ -0,0 +1,158 @@
+//go:generate stringer -type=Standard -output=standard_string.go
+
+/*
+Package instrument resolves the instrument selected by bank select and program change
+according to General MIDI, General MIDI Level 2, Roland GS and Yamaha XG.
+*/
+package instrument
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+// Standard represents the standard which defines the meaning of bank select.
+type Standard uint8
+
+const (
+	GM Standard = iota
+	GM2
+	GS
+	XG
+)
+
+const (
+	// GM2RhythmBank is the bank select MSB which selects a drum set in GM2.
+	GM2RhythmBank = 0x78
+
+	// GM2MelodyBank is the bank select MSB which selects a melodic sound in GM2.
+	GM2MelodyBank = 0x79
+
+	// XGSFXVoiceBank is the bank select MSB which selects a SFX voice in XG.
+	XGSFXVoiceBank = 0x40
+
+	// XGSFXKitBank is the bank select MSB which selects a SFX kit in XG.
+	XGSFXKitBank = 0x7e
+
+	// XGDrumKitBank is the bank select MSB which selects a drum kit in XG.
+	XGDrumKitBank = 0x7f
+)
+
+// Instrument represents the instrument selected on a channel.
+type Instrument struct {
+	Standard Standard
+	BankMSB  uint8
+	BankLSB  uint8
+	Program  constant.GM
+
+	// Drum is true if the instrument is a drum kit.
+	Drum bool
+
+	// Fallback is true if the variation is unknown and the name of capital tone is used instead.
+	Fallback bool
+
+	Name string
+}
+
+// String returns string representation of instrument.
+func (i Instrument) String() string {
+	return fmt.Sprintf("&Instrument{standard: %v, bankMSB: %v, bankLSB: %v, program: %v, drum: %v, name: %q}", i.Standard, i.BankMSB, i.BankLSB, i.Program, i.Drum, i.Name)
+}
+
+type variation struct {
+	program constant.GM
+	bank    uint8
+}
+
+// IsDrumBank reports whether the bank select MSB selects a drum kit in the standard.
+// GM and GS don't select drum kits with bank select, the drum part is assigned to channel instead.
+func IsDrumBank(standard Standard, msb uint8) bool {
+	switch standard {
+	case GM2:
+		return msb == GM2RhythmBank
+	case XG:
+		return msb == XGDrumKitBank || msb == XGSFXKitBank
+	}
+
+	return false
+}
+
+// Lookup returns the instrument selected by bank select MSB, LSB and program.
+// The drum must be true when the channel is assigned to drum part.
+// The tables cover the sounds defined by GM2 and the common variations and kits of GS and XG.
+// Unknown variations fall back to the capital tone like the real GS and XG sound modules do.
+func Lookup(standard Standard, msb, lsb uint8, program constant.GM, drum bool) Instrument {
+	i := Instrument{
+		Standard: standard,
+		BankMSB:  msb,
+		BankLSB:  lsb,
+		Program:  program,
+		Drum:     drum,
+	}
+
+	if drum {
+		i.Name, i.Fallback = lookupDrumKit(standard, msb, program)
+		return i
+	}
+
+	var name string
+	var ok bool
+
+	switch standard {
+	case GM2:
+		name, ok = gm2Variations[variation{program, lsb}]
+		if lsb == 0 {
+			name, ok = program.Name(), true
+		}
+	case GS:
+		name, ok = gsVariations[variation{program, msb}]
+		if msb == 0 {
+			name, ok = program.Name(), true
+		}
+	case XG:
+		if msb == XGSFXVoiceBank {
+			name, ok = xgSFXVoices[program]
+		} else {
+			name, ok = xgVariations[variation{program, lsb}]
+			if msb == 0 && lsb == 0 {
+				name, ok = program.Name(), true
+			}
+		}
+	default:
+		name, ok = program.Name(), true
+	}
+
+	if !ok {
+		name = program.Name()
+		i.Fallback = true
+	}
+
+	i.Name = name
+
+	return i
+}
+
+func lookupDrumKit(standard Standard, msb uint8, program constant.GM) (string, bool) {
+	var kits map[constant.GM]string
+
+	switch standard {
+	case GM2:
+		kits = gm2DrumSets
+	case GS:
+		kits = gsDrumSets
+	case XG:
+		kits = xgDrumKits
+		if msb == XGSFXKitBank {
+			kits = xgSFXKits
+		}
+	default:
+		return gm2DrumSets[0], program != 0
+	}
+
+	if name, ok := kits[program]; ok {
+		return name, false
+	}
+
+	return kits[0], true
+}
@@ -0,0 +1,52 @@
+package instrument
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+func TestLookup(t *testing.T) {
+	for _, c := range []struct {
+		standard Standard
+		msb, lsb uint8
+		program  constant.GM
+		drum     bool
+		name     string
+		fallback bool
+	}{
+		{GM, 0, 0, constant.AcousticNylonGuitar, false, "Acoustic Guitar (nylon)", false},
+		{GM, 0, 0, 0, true, "Standard Set", false},
+		{GM2, GM2MelodyBank, 1, constant.AcousticNylonGuitar, false, "Ukulele", false},
+		{GM2, GM2MelodyBank, 0, constant.AcousticNylonGuitar, false, "Acoustic Guitar (nylon)", false},
+		{GM2, GM2MelodyBank, 9, constant.AcousticNylonGuitar, false, "Acoustic Guitar (nylon)", true},
+		{GM2, GM2RhythmBank, 0, 32, true, "Jazz Set", false},
+		{GS, 32, 0, constant.AcousticNylonGuitar, false, "Nylon Gt.2", false},
+		{GS, 0, 0, 25, true, "TR-808 Set", false},
+		{GS, 0, 0, 3, true, "Standard Set", true},
+		{XG, 0, 16, constant.AcousticNylonGuitar, false, "Nylon Guitar 2", false},
+		{XG, XGSFXVoiceBank, 0, 84, false, "Siren", false},
+		{XG, XGDrumKitBank, 0, 32, true, "Jazz Kit", false},
+		{XG, XGSFXKitBank, 0, 1, true, "SFX 2", false},
+	} {
+		i := Lookup(c.standard, c.msb, c.lsb, c.program, c.drum)
+		if i.Name != c.name {
+			t.Fatalf("expected: %v actual: %v", c.name, i.Name)
+		}
+		if i.Fallback != c.fallback {
+			t.Fatalf("expected: %v actual: %v (%v)", c.fallback, i.Fallback, i)
+		}
+	}
+}
+
+func TestIsDrumBank(t *testing.T) {
+	if !IsDrumBank(GM2, GM2RhythmBank) {
+		t.Fatalf("bank 120 must be drum bank in GM2")
+	}
+	if !IsDrumBank(XG, XGDrumKitBank) {
+		t.Fatalf("bank 127 must be drum bank in XG")
+	}
+	if IsDrumBank(GS, XGDrumKitBank) {
+		t.Fatalf("bank 127 must not be drum bank in GS")
+	}
+}
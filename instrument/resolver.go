@@ -0,0 +1,185 @@
+package instrument
+
+import (
+	"bytes"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+var (
+	gmSystemOn  = []byte{0x7e, 0x7f, 0x09, 0x01, 0xf7}
+	gm2SystemOn = []byte{0x7e, 0x7f, 0x09, 0x03, 0xf7}
+	gsReset     = []byte{0x41, 0x10, 0x42, 0x12, 0x40, 0x00, 0x7f, 0x00, 0x41, 0xf7}
+	xgSystemOn  = []byte{0x43, 0x10, 0x4c, 0x00, 0x00, 0x7e, 0x00, 0xf7}
+)
+
+type channelState struct {
+	bankMSB    uint8
+	bankLSB    uint8
+	drumPart   bool
+	instrument Instrument
+}
+
+// Resolver tracks bank select, program change and system exclusive messages
+// and resolves the instrument selected on each channel.
+type Resolver struct {
+	standard Standard
+	channels [16]channelState
+}
+
+// Standard returns the standard currently in effect.
+func (r *Resolver) Standard() Standard {
+	return r.standard
+}
+
+// SetStandard sets the standard and resets all channels to the default state.
+func (r *Resolver) SetStandard(standard Standard) {
+	r.standard = standard
+
+	for channel := range r.channels {
+		state := &r.channels[channel]
+		state.bankMSB = 0
+		state.bankLSB = 0
+		state.drumPart = constant.IsPercussionChannel(uint8(channel))
+
+		if state.drumPart {
+			switch standard {
+			case GM2:
+				state.bankMSB = GM2RhythmBank
+			case XG:
+				state.bankMSB = XGDrumKitBank
+			}
+		} else if standard == GM2 {
+			state.bankMSB = GM2MelodyBank
+		}
+
+		state.instrument = r.lookup(state, 0)
+	}
+}
+
+// Apply updates the state with the event. The bank select takes effect on the next program change.
+// The GM, GM2, GS and XG system on messages switch the standard.
+func (r *Resolver) Apply(e event.Event) {
+	switch v := e.(type) {
+	case *event.ControllerEvent:
+		state := &r.channels[v.Channel()]
+
+		switch v.Control() {
+		case constant.BankSelect:
+			state.bankMSB = v.Value()
+		case constant.BankSelectLSB:
+			state.bankLSB = v.Value()
+		}
+	case *event.ProgramChangeEvent:
+		state := &r.channels[v.Channel()]
+		state.instrument = r.lookup(state, v.Program())
+	case *event.SystemExclusiveEvent:
+		r.applySystemExclusive(v.Data())
+	}
+}
+
+func (r *Resolver) applySystemExclusive(data []byte) {
+	switch {
+	case bytes.Equal(data, gmSystemOn):
+		r.SetStandard(GM)
+	case bytes.Equal(data, gm2SystemOn):
+		r.SetStandard(GM2)
+	case bytes.Equal(data, gsReset):
+		r.SetStandard(GS)
+	case bytes.Equal(data, xgSystemOn):
+		r.SetStandard(XG)
+	case len(data) == 10 && bytes.Equal(data[:5], []byte{0x41, 0x10, 0x42, 0x12, 0x40}) && data[5]&0xf0 == 0x10 && data[6] == 0x15:
+		// GS USE FOR RHYTHM PART. The block 0 is the part 10, the blocks 1 to 9 are the parts 1 to 9.
+		block := data[5] & 0x0f
+		channel := block
+
+		switch {
+		case block == 0:
+			channel = 9
+		case block <= 9:
+			channel = block - 1
+		}
+
+		state := &r.channels[channel]
+		state.drumPart = data[7] != 0
+		state.instrument = r.lookup(state, state.instrument.Program)
+	}
+}
+
+func (r *Resolver) lookup(state *channelState, program constant.GM) Instrument {
+	drum := IsDrumBank(r.standard, state.bankMSB)
+
+	switch r.standard {
+	case GM, GS:
+		drum = state.drumPart
+	case GM2:
+		if state.bankMSB != GM2RhythmBank && state.bankMSB != GM2MelodyBank {
+			drum = state.drumPart
+		}
+	}
+
+	return Lookup(r.standard, state.bankMSB, state.bankLSB, program, drum)
+}
+
+// Instrument returns the instrument selected on the channel.
+func (r *Resolver) Instrument(channel uint8) Instrument {
+	return r.channels[channel&0x0f].instrument
+}
+
+// NewResolver returns Resolver which assumes the given standard until a system on message is found.
+func NewResolver(standard Standard) *Resolver {
+	r := &Resolver{}
+	r.SetStandard(standard)
+
+	return r
+}
+
+// Change represents the instrument selected at the tick.
+type Change struct {
+	Tick       uint32
+	Channel    uint8
+	Instrument Instrument
+}
+
+// Changes returns all program changes of MIDI data with the resolved instrument.
+func Changes(m *midi.MIDI, standard Standard) []Change {
+	r := NewResolver(standard)
+	changes := []Change{}
+
+	for _, te := range m.TimedEvents() {
+		r.Apply(te.Event)
+
+		if v, ok := te.Event.(*event.ProgramChangeEvent); ok {
+			changes = append(changes, Change{
+				Tick:       te.Tick,
+				Channel:    v.Channel(),
+				Instrument: r.Instrument(v.Channel()),
+			})
+		}
+	}
+
+	return changes
+}
+
+// At returns the instruments of 16 channels in effect at the tick.
+// The events at the tick are taken into account.
+func At(m *midi.MIDI, standard Standard, tick uint32) []Instrument {
+	r := NewResolver(standard)
+
+	for _, te := range m.TimedEvents() {
+		if te.Tick > tick {
+			break
+		}
+		r.Apply(te.Event)
+	}
+
+	instruments := make([]Instrument, 16)
+
+	for channel := range instruments {
+		instruments[channel] = r.Instrument(uint8(channel))
+	}
+
+	return instruments
+}
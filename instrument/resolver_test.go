@@ -0,0 +1,100 @@
+package instrument
+
+import (
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestResolver_Apply(t *testing.T) {
+	r := NewResolver(GM)
+
+	if i := r.Instrument(9); !i.Drum {
+		t.Fatalf("channel 9 must be drum part")
+	}
+
+	xgOn, _ := event.NewSystemExclusiveEvent(nil, xgSystemOn)
+	r.Apply(xgOn)
+
+	if r.Standard() != XG {
+		t.Fatalf("expected: XG actual: %v", r.Standard())
+	}
+
+	bankLSB, _ := event.NewControllerEvent(nil, 0, constant.BankSelectLSB, 16)
+	programChange, _ := event.NewProgramChangeEvent(nil, 0, constant.AcousticNylonGuitar)
+
+	r.Apply(bankLSB)
+
+	if i := r.Instrument(0); i.Program != constant.AcousticGrandPiano {
+		t.Fatalf("bank select must not take effect before program change")
+	}
+
+	r.Apply(programChange)
+
+	if i := r.Instrument(0); i.Name != "Nylon Guitar 2" {
+		t.Fatalf("expected: Nylon Guitar 2 actual: %v", i.Name)
+	}
+
+	jazz, _ := event.NewProgramChangeEvent(nil, 9, 32)
+	r.Apply(jazz)
+
+	if i := r.Instrument(9); i.Name != "Jazz Kit" {
+		t.Fatalf("expected: Jazz Kit actual: %v", i.Name)
+	}
+}
+
+func TestResolver_Apply_gsRhythmPart(t *testing.T) {
+	r := NewResolver(GS)
+
+	// USE FOR RHYTHM PART of the part 11 (channel 10) is set to map 1.
+	rhythm, _ := event.NewSystemExclusiveEvent(nil, []byte{0x41, 0x10, 0x42, 0x12, 0x40, 0x1a, 0x15, 0x01, 0x10, 0xf7})
+	r.Apply(rhythm)
+
+	brush, _ := event.NewProgramChangeEvent(nil, 10, 40)
+	r.Apply(brush)
+
+	if i := r.Instrument(10); !i.Drum || i.Name != "Brush Set" {
+		t.Fatalf("unexpected instrument %v", i)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	bankMSB, _ := event.NewControllerEvent(nil, 1, constant.BankSelect, 8)
+	programChange1, _ := event.NewProgramChangeEvent(nil, 1, constant.AcousticNylonGuitar)
+	programChange2, _ := event.NewProgramChangeEvent(nil, 1, constant.AcousticSteelGuitar)
+	endOfTrack, _ := event.NewEndOfTrackEvent(nil)
+
+	m := &midi.MIDI{
+		Tracks: []*midi.Track{
+			midi.NewTrackFromTimedEvents([]midi.TimedEvent{
+				{Tick: 0, Event: bankMSB},
+				{Tick: 0, Event: programChange1},
+				{Tick: 960, Event: programChange2},
+				{Tick: 1920, Event: endOfTrack},
+			}),
+		},
+	}
+
+	changes := Changes(m, GS)
+
+	if len(changes) != 2 {
+		t.Fatalf("expected: 2 changes actual: %v changes", len(changes))
+	}
+	if changes[0].Instrument.Name != "Ukulele" {
+		t.Fatalf("expected: Ukulele actual: %v", changes[0].Instrument.Name)
+	}
+	if changes[1].Tick != 960 || changes[1].Instrument.Name != "12-str.Gt" {
+		t.Fatalf("unexpected change %v", changes[1])
+	}
+
+	instruments := At(m, GS, 480)
+
+	if len(instruments) != 16 {
+		t.Fatalf("expected: 16 instruments actual: %v instruments", len(instruments))
+	}
+	if instruments[1].Name != "Ukulele" {
+		t.Fatalf("expected: Ukulele actual: %v", instruments[1].Name)
+	}
+}
@@ -0,0 +1,87 @@
+package instrument
+
+import "github.com/moutend/go-midi/constant"
+
+// xgVariations is the common XG variation voices keyed by program and bank select LSB.
+var xgVariations = map[variation]string{
+	{constant.AcousticGrandPiano, 1}:   "Grand Piano KSP",
+	{constant.AcousticGrandPiano, 40}:  "Piano Strings",
+	{constant.AcousticGrandPiano, 41}:  "Dream",
+	{constant.BrightAcousticPiano, 1}:  "Bright Piano KSP",
+	{constant.ElectricGrandPiano, 1}:   "Electric Grand KSP",
+	{constant.ElectricGrandPiano, 32}:  "Detuned CP80",
+	{constant.HonkyTonkPiano, 1}:       "Honky-tonk KSP",
+	{constant.AcousticNylonGuitar, 16}: "Nylon Guitar 2",
+	{constant.AcousticNylonGuitar, 25}: "Nylon Guitar 3",
+	{constant.AcousticNylonGuitar, 43}: "Velocity Guitar Harmonics",
+	{constant.AcousticNylonGuitar, 96}: "Ukulele",
+	{constant.AcousticSteelGuitar, 35}: "12-String Guitar",
+	{constant.AcousticSteelGuitar, 40}: "Nylon & Steel",
+	{constant.AcousticSteelGuitar, 41}: "Steel Guitar with Body Sound",
+	{constant.AcousticSteelGuitar, 96}: "Mandolin",
+}
+
+// xgSFXVoices is the XG SFX voices selected by bank select MSB 64, keyed by program.
+var xgSFXVoices = map[constant.GM]string{
+	0:   "Cutting Noise",
+	1:   "Cutting Noise 2",
+	3:   "String Slap",
+	16:  "Flute Key Click",
+	32:  "Shower",
+	33:  "Thunder",
+	34:  "Wind",
+	35:  "Stream",
+	36:  "Bubble",
+	37:  "Feed",
+	48:  "Dog",
+	49:  "Horse",
+	50:  "Bird Tweet 2",
+	55:  "Ghost",
+	56:  "Maou",
+	64:  "Telephone Dial",
+	65:  "Door Squeak",
+	66:  "Door Slam",
+	67:  "Scratch Cut",
+	68:  "Scratch Split",
+	69:  "Wind Chime",
+	70:  "Telephone Ring 2",
+	80:  "Car Engine Ignition",
+	81:  "Car Tires Squeal",
+	82:  "Car Passing",
+	83:  "Car Crash",
+	84:  "Siren",
+	85:  "Train",
+	86:  "Jet Plane",
+	87:  "Starship",
+	88:  "Burst",
+	89:  "Roller Coaster",
+	90:  "Submarine",
+	96:  "Laugh",
+	97:  "Scream",
+	98:  "Punch",
+	99:  "Heartbeat",
+	100: "Footsteps",
+	112: "Machine Gun",
+	113: "Laser Gun",
+	114: "Explosion",
+	115: "Firework",
+}
+
+// xgDrumKits is the XG drum kits selected by bank select MSB 127, keyed by program.
+var xgDrumKits = map[constant.GM]string{
+	0:  "Standard Kit",
+	1:  "Standard 2 Kit",
+	8:  "Room Kit",
+	16: "Rock Kit",
+	24: "Electro Kit",
+	25: "Analog Kit",
+	32: "Jazz Kit",
+	40: "Brush Kit",
+	48: "Classic Kit",
+}
+
+// xgSFXKits is the XG SFX kits selected by bank select MSB 126, keyed by program.
+var xgSFXKits = map[constant.GM]string{
+	0: "SFX 1",
+	1: "SFX 2",
+}
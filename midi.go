@@ -1,5 +1,7 @@
 package midi
 
+import "fmt"
+
 // MIDI represents standard MIDI data.
 type MIDI struct {
 	formatType   uint16
@@ -7,11 +9,24 @@ type MIDI struct {
 	Tracks       []*Track
 }
 
-// Serialize serializes MIDI data.
-func (m *MIDI) Serialize() []byte {
-	stream := []byte("MThd")
-	stream = append(stream, 0x00, 0x00, 0x00, 0x06)
-	stream = append(stream, 0x00, byte(m.formatType&0xff))
+// AppendTo appends serialized MIDI data to dst and returns the extended buffer.
+// The buffer is grown once to the length of MIDI data.
+func (m *MIDI) AppendTo(dst []byte) []byte {
+	// The sizes of tracks are kept on the stack unless there are many tracks.
+	var small [64]int
+
+	sizes := small[:0]
+	size := 14
+
+	for _, track := range m.Tracks {
+		sizes = append(sizes, track.sizeOfData())
+		size += 8 + sizes[len(sizes)-1]
+	}
+
+	dst = grow(dst, size+2)
+	dst = append(dst, "MThd"...)
+	dst = append(dst, 0x00, 0x00, 0x00, 0x06)
+	dst = append(dst, 0x00, byte(m.formatType&0xff))
 
 	var numberOfTracks uint16
 	if len(m.Tracks) > 0xffff {
@@ -20,15 +35,31 @@ func (m *MIDI) Serialize() []byte {
 		numberOfTracks = uint16(len(m.Tracks))
 	}
 
-	stream = append(stream, byte((numberOfTracks>>8)&0xff))
-	stream = append(stream, byte(numberOfTracks&0xff))
-	stream = append(stream, m.TimeDivision().Serialize()...)
+	dst = append(dst, byte((numberOfTracks>>8)&0xff))
+	dst = append(dst, byte(numberOfTracks&0xff))
+	dst = m.TimeDivision().AppendTo(dst)
+
+	for i, track := range m.Tracks {
+		dst = track.appendTo(dst, sizes[i])
+	}
+
+	return dst
+}
+
+// Serialize serializes MIDI data.
+func (m *MIDI) Serialize() []byte {
+	return m.AppendTo(nil)
+}
+
+// Len returns the length of serialized MIDI data.
+func (m *MIDI) Len() int {
+	size := 14
 
 	for _, track := range m.Tracks {
-		stream = append(stream, track.Serialize()...)
+		size += track.Len()
 	}
 
-	return stream
+	return size
 }
 
 // TimeDivision returns time division.
@@ -38,3 +69,58 @@ func (m *MIDI) TimeDivision() *TimeDivision {
 	}
 	return m.timeDivision
 }
+
+// SetFormatType sets format type. The format type must be 0, 1 or 2.
+func (m *MIDI) SetFormatType(formatType uint16) error {
+	if formatType > 2 {
+		return fmt.Errorf("midi: format type must be 0, 1 or 2")
+	}
+	m.formatType = formatType
+
+	return nil
+}
+
+// FormatType returns format type.
+func (m *MIDI) FormatType() uint16 {
+	return m.formatType
+}
+
+// SetTimeDivision sets time division.
+func (m *MIDI) SetTimeDivision(timeDivision *TimeDivision) {
+	m.timeDivision = timeDivision
+}
+
+// Clone returns a deep copy of MIDI data. Editing the copy never changes the original.
+func (m *MIDI) Clone() *MIDI {
+	timeDivision := *m.TimeDivision()
+	clone := &MIDI{
+		formatType:   m.formatType,
+		timeDivision: &timeDivision,
+	}
+
+	if m.Tracks != nil {
+		clone.Tracks = make([]*Track, len(m.Tracks))
+	}
+	for i, track := range m.Tracks {
+		clone.Tracks[i] = track.Clone()
+	}
+
+	return clone
+}
+
+// Equal reports whether both MIDI data have the same format type, time division and tracks.
+func (m *MIDI) Equal(other *MIDI) bool {
+	if m.formatType != other.formatType || m.TimeDivision().value != other.TimeDivision().value {
+		return false
+	}
+	if len(m.Tracks) != len(other.Tracks) {
+		return false
+	}
+	for i, track := range m.Tracks {
+		if !track.Equal(other.Tracks[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,6 +1,7 @@
 package midi
 
 import (
+	"bytes"
 	"io/ioutil"
 	"testing"
 )
@@ -23,6 +24,9 @@ func TestMIDI_Serialize(t *testing.T) {
 		if len(expected) != len(actual) {
 			t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
 		}
+		if len(expected) != m.Len() {
+			t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), m.Len())
+		}
 		for i, e := range expected {
 			a := actual[i]
 			if e != a {
@@ -39,3 +43,105 @@ func TestMIDI_TimeDivision(t *testing.T) {
 		t.Fatalf("TimeDivision must not return nil")
 	}
 }
+
+func TestMIDI_SetFormatType(t *testing.T) {
+	m := &MIDI{}
+
+	if err := m.SetFormatType(3); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := m.SetFormatType(1); err != nil {
+		t.Fatal(err)
+	}
+	if m.FormatType() != 1 {
+		t.Fatalf("expected: 1 actual: %v", m.FormatType())
+	}
+}
+
+func TestMIDI_Clone(t *testing.T) {
+	for _, pathToMid := range pathsToMid {
+		file, err := ioutil.ReadFile(pathToMid)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m, err := NewParser(file).SetZeroCopy(true).Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clone := m.Clone()
+
+		if !m.Equal(clone) {
+			t.Fatalf("clone must be equal to the original (%v)", pathToMid)
+		}
+
+		// The original refers to the input buffer, but the clone doesn't.
+		expected := append([]byte{}, file...)
+
+		for i := range file {
+			file[i] = 0
+		}
+		if !bytes.Equal(expected, clone.Serialize()) {
+			t.Fatalf("clone must not refer to the input buffer (%v)", pathToMid)
+		}
+
+		clone.Tracks[0].Events[0].DeltaTime().SetUint32(1000)
+		clone.TimeDivision().SetBPM(1)
+
+		if m.Tracks[0].Events[0].DeltaTime().Uint32() == 1000 || m.TimeDivision().value == clone.TimeDivision().value {
+			t.Fatalf("editing clone must not change the original (%v)", pathToMid)
+		}
+	}
+}
+
+// loadBenchmarkMIDI parses the test files for the benchmarks.
+func loadBenchmarkMIDI(b *testing.B) []*MIDI {
+	b.Helper()
+
+	ms := []*MIDI{}
+
+	for _, pathToMid := range pathsToMid {
+		file, err := ioutil.ReadFile(pathToMid)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		m, err := NewParser(file).Parse()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		ms = append(ms, m)
+	}
+
+	return ms
+}
+
+func BenchmarkMIDI_Serialize(b *testing.B) {
+	ms := loadBenchmarkMIDI(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, m := range ms {
+			m.Serialize()
+		}
+	}
+}
+
+func BenchmarkMIDI_AppendTo(b *testing.B) {
+	ms := loadBenchmarkMIDI(b)
+
+	buf := []byte{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, m := range ms {
+			buf = m.AppendTo(buf[:0])
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package midisync
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/player"
+)
+
+const (
+	// PulsesPerQuarterNote is the resolution of MIDI clock.
+	PulsesPerQuarterNote = 24
+
+	// PulsesPerBeat is the number of pulses in a MIDI beat, i.e. sixteenth note, which is the unit of song position pointer.
+	PulsesPerBeat = 6
+)
+
+// ClockMaster sends MIDI clock at 24 pulses per quarter note following the tempo map of song, as well as start, stop,
+// continue and song position pointer. The methods are safe for concurrent use.
+type ClockMaster struct {
+	mu sync.Mutex
+
+	tempoMap *midi.TempoMap
+	output   player.Output
+	clock    player.Clock
+	interval time.Duration
+
+	running bool
+
+	// The pulse anchorPulse is sent at anchorTime. The pulse is the next one to be sent.
+	pulse       uint32
+	anchorPulse uint32
+	anchorTime  time.Time
+}
+
+// duration returns the duration from the beginning of song to the pulse.
+func (c *ClockMaster) duration(pulse uint32) time.Duration {
+	ticks := uint64(pulse) * uint64(c.tempoMap.TicksPerQuarterNote())
+	tick, rest := uint32(ticks/PulsesPerQuarterNote), ticks%PulsesPerQuarterNote
+
+	d := c.tempoMap.Duration(tick)
+	if rest > 0 {
+		d += (c.tempoMap.Duration(tick+1) - d) * time.Duration(rest) / PulsesPerQuarterNote
+	}
+
+	return d
+}
+
+// pulseTime returns the time to send the pulse.
+func (c *ClockMaster) pulseTime(pulse uint32) time.Time {
+	return c.anchorTime.Add(c.duration(pulse) - c.duration(c.anchorPulse))
+}
+
+// songPositionPointer returns song position pointer message of the pulse, which is rounded down to MIDI beat.
+func songPositionPointer(pulse uint32) []byte {
+	beats := pulse / PulsesPerBeat
+
+	return []byte{constant.SongPositionPointer, byte(beats & 0x7f), byte(beats >> 7 & 0x7f)}
+}
+
+// dispatch sends the pulses scheduled until now.
+func (c *ClockMaster) dispatch(now time.Time) (time.Time, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return time.Time{}, false, nil
+	}
+
+	for {
+		at := c.pulseTime(c.pulse)
+		if at.After(now) {
+			return at, true, nil
+		}
+		if err := c.output.Send([]byte{constant.TimingClock}); err != nil {
+			c.running = false
+			return time.Time{}, false, err
+		}
+
+		c.pulse++
+	}
+}
+
+// locate moves to the beginning of MIDI beat which contains the pulse.
+func (c *ClockMaster) locate(pulse uint32, now time.Time) {
+	c.pulse = pulse / PulsesPerBeat * PulsesPerBeat
+	c.anchorPulse = c.pulse
+	c.anchorTime = now
+}
+
+// Run starts the slaves and sends MIDI clock until Stop is called. It blocks while running.
+// It sends start at the beginning of song, otherwise song position pointer and continue.
+func (c *ClockMaster) Run() error {
+	c.mu.Lock()
+
+	if c.running {
+		c.mu.Unlock()
+		return fmt.Errorf("midi: clock master is already running")
+	}
+
+	c.running = true
+	c.locate(c.pulse, c.clock.Now())
+
+	var err error
+
+	if c.pulse == 0 {
+		err = c.output.Send([]byte{constant.Start})
+	} else if err = c.output.Send(songPositionPointer(c.pulse)); err == nil {
+		err = c.output.Send([]byte{constant.Continue})
+	}
+	if err != nil {
+		c.running = false
+	}
+
+	clock, interval := c.clock, c.interval
+
+	c.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return run(clock, interval, c.dispatch)
+}
+
+// Stop sends stop and stops sending MIDI clock. The position is kept, so that Run continues from it.
+func (c *ClockMaster) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return nil
+	}
+
+	c.running = false
+
+	return c.output.Send([]byte{constant.Stop})
+}
+
+// Seek moves to the tick, which is rounded down to MIDI beat, and sends song position pointer.
+// While running, it sends stop before and continue after song position pointer.
+func (c *ClockMaster) Seek(tick uint32) error {
+	pulse := uint64(tick) * PulsesPerQuarterNote / uint64(c.tempoMap.TicksPerQuarterNote())
+	if pulse/PulsesPerBeat > 0x3fff {
+		return fmt.Errorf("midi: tick %v is beyond the range of song position pointer", tick)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.locate(uint32(pulse), c.clock.Now())
+
+	messages := [][]byte{songPositionPointer(c.pulse)}
+	if c.running {
+		messages = [][]byte{{constant.Stop}, songPositionPointer(c.pulse), {constant.Continue}}
+	}
+	for _, message := range messages {
+		if err := c.output.Send(message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Position returns the position in ticks of the next pulse.
+func (c *ClockMaster) Position() uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return uint32(uint64(c.pulse) * uint64(c.tempoMap.TicksPerQuarterNote()) / PulsesPerQuarterNote)
+}
+
+// SetInterval sets the maximum duration of sleep between the wake-ups. The default is DefaultInterval.
+func (c *ClockMaster) SetInterval(interval time.Duration) *ClockMaster {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interval = interval
+
+	return c
+}
+
+// SetClock sets clock. The default is player.SystemClock.
+func (c *ClockMaster) SetClock(clock player.Clock) *ClockMaster {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clock = clock
+
+	return c
+}
+
+// NewClockMaster returns ClockMaster which sends MIDI clock of m to output.
+func NewClockMaster(m *midi.MIDI, output player.Output) (*ClockMaster, error) {
+	tempoMap, err := m.TempoMap()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ClockMaster{
+		tempoMap: tempoMap,
+		output:   output,
+		clock:    player.SystemClock,
+		interval: DefaultInterval,
+	}
+
+	return c, nil
+}
@@ -0,0 +1,137 @@
+package midisync
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/player"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// stopClock calls f once when the time reaches at.
+type stopClock struct {
+	*player.FakeClock
+	at time.Time
+	f  func()
+}
+
+func (c *stopClock) Sleep(d time.Duration) {
+	c.FakeClock.Sleep(d)
+
+	if c.f != nil && !c.Now().Before(c.at) {
+		f := c.f
+		c.f = nil
+		f()
+	}
+}
+
+func format(rs []player.Record) []string {
+	ss := make([]string, len(rs))
+
+	for i, r := range rs {
+		ss[i] = fmt.Sprintf("%v % x", r.Time.Sub(epoch), r.Message)
+	}
+
+	return ss
+}
+
+// newTestMIDI returns a song at 120 BPM which changes to 60 BPM at the second quarter note.
+func newTestMIDI(t *testing.T) *midi.MIDI {
+	tempo1, _ := event.NewSetTempoEvent(nil, 500000)
+	tempo2, _ := event.NewSetTempoEvent(nil, 1000000)
+
+	m := &midi.MIDI{
+		Tracks: []*midi.Track{
+			midi.NewTrackFromTimedEvents([]midi.TimedEvent{
+				{Tick: 0, Event: tempo1},
+				{Tick: 96, Event: tempo2},
+			}),
+		},
+	}
+	m.TimeDivision().SetBPM(96)
+
+	return m
+}
+
+func TestClockMaster_Run(t *testing.T) {
+	fake := player.NewFakeClock(epoch)
+	clock := &stopClock{FakeClock: fake, at: epoch.Add(1000 * time.Millisecond)}
+	output := player.NewRecordingOutput(fake)
+
+	c, err := NewClockMaster(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetClock(clock)
+	clock.f = func() {
+		if err := c.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := c.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	actual := format(output.Records())
+
+	// Start, 24 pulses of 500 ms quarter note, 12 pulses of 1000 ms quarter note, then stop.
+	if len(actual) != 1+24+12+1 {
+		t.Fatalf("expected: 38 messages actual: %v messages (%q)", len(actual), actual)
+	}
+	for i, expected := range map[int]string{
+		0:  "0s fa",
+		1:  "0s f8",
+		2:  fmt.Sprintf("%v f8", 500*time.Millisecond/24),
+		25: "500ms f8",
+		26: fmt.Sprintf("%v f8", 500*time.Millisecond+time.Second/24),
+		37: "1s fc",
+	} {
+		if expected != actual[i] {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected, i, actual[i])
+		}
+	}
+	if c.Position() != 144 {
+		t.Fatalf("expected: 144 actual: %v", c.Position())
+	}
+}
+
+func TestClockMaster_Seek(t *testing.T) {
+	fake := player.NewFakeClock(epoch)
+	clock := &stopClock{FakeClock: fake, at: epoch.Add(10 * time.Millisecond)}
+	output := player.NewRecordingOutput(fake)
+
+	c, err := NewClockMaster(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The tick 100 is rounded down to the MIDI beat 4, i.e. tick 96.
+	if err := c.Seek(100); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Seek(96 * 0x1000); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+
+	c.SetClock(clock)
+	clock.f = func() {
+		c.Stop()
+	}
+
+	if err := c.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"0s f2 04 00", "0s f2 04 00", "0s fb", "0s f8", "10ms fc"}
+	actual := format(output.Records())
+
+	if fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Fatalf("expected: %q actual: %q", expected, actual)
+	}
+}
@@ -0,0 +1,121 @@
+package midisync
+
+import (
+	"time"
+
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// DefaultSmoothing is the default weight of the latest interval of MIDI clock in the estimated tempo.
+const DefaultSmoothing = 0.1
+
+// ClockFollower follows MIDI clock, start, stop, continue and song position pointer sent by a master, and estimates
+// the tempo and the position. The jitter of MIDI clock is smoothed by exponential moving average of the intervals.
+type ClockFollower struct {
+	smoothing float64
+
+	running bool
+	pulse   uint32
+
+	last     time.Time
+	interval float64
+}
+
+// Apply applies the message. The messages must be applied in the order of arrival.
+func (f *ClockFollower) Apply(m stream.Message) {
+	switch v := m.Event.(type) {
+	case *event.TimingClockEvent:
+		f.applyClock(m.Time)
+
+		if f.running {
+			f.pulse++
+		}
+	case *event.StartEvent:
+		f.running = true
+		f.pulse = 0
+	case *event.ContinueEvent:
+		f.running = true
+	case *event.StopEvent:
+		f.running = false
+	case *event.SongPositionPointerEvent:
+		f.pulse = uint32(v.Position()) * PulsesPerBeat
+	}
+}
+
+// applyClock updates the estimated interval of MIDI clock.
+func (f *ClockFollower) applyClock(t time.Time) {
+	last := f.last
+	f.last = t
+
+	if last.IsZero() {
+		return
+	}
+
+	interval := float64(t.Sub(last))
+
+	switch {
+	case interval <= 0:
+		return
+	case f.interval == 0:
+		f.interval = interval
+	case interval > f.interval*4:
+		// The master has paused sending MIDI clock.
+		return
+	default:
+		f.interval += (interval - f.interval) * f.smoothing
+	}
+}
+
+// Running reports whether the master is playing.
+func (f *ClockFollower) Running() bool {
+	return f.running
+}
+
+// Pulses returns the position in pulses, i.e. the number of MIDI clocks from the beginning of song.
+func (f *ClockFollower) Pulses() uint32 {
+	return f.pulse
+}
+
+// Position returns the position in ticks of the resolution.
+func (f *ClockFollower) Position(ticksPerQuarterNote uint16) uint32 {
+	return uint32(uint64(f.pulse) * uint64(ticksPerQuarterNote) / PulsesPerQuarterNote)
+}
+
+// Tempo returns the estimated tempo in microseconds per quarter note. It returns false until 2 clocks are received.
+func (f *ClockFollower) Tempo() (uint32, bool) {
+	if f.interval == 0 {
+		return 0, false
+	}
+
+	return uint32(f.interval*PulsesPerQuarterNote/float64(time.Microsecond) + 0.5), true
+}
+
+// BPM returns the estimated tempo in beats per minute. It returns false until 2 clocks are received.
+func (f *ClockFollower) BPM() (float64, bool) {
+	if f.interval == 0 {
+		return 0, false
+	}
+
+	return float64(time.Minute) / (f.interval * PulsesPerQuarterNote), true
+}
+
+// SetSmoothing sets the weight of the latest interval from 0 to 1. The larger weight follows the tempo change faster,
+// and the smaller weight smooths the jitter more. The weight out of the range is treated as DefaultSmoothing.
+// The default is DefaultSmoothing.
+func (f *ClockFollower) SetSmoothing(smoothing float64) *ClockFollower {
+	if smoothing <= 0 || smoothing > 1 {
+		smoothing = DefaultSmoothing
+	}
+
+	f.smoothing = smoothing
+
+	return f
+}
+
+// NewClockFollower returns ClockFollower.
+func NewClockFollower() *ClockFollower {
+	return &ClockFollower{
+		smoothing: DefaultSmoothing,
+	}
+}
@@ -0,0 +1,71 @@
+package midisync
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+func TestClockFollower_Apply(t *testing.T) {
+	f := NewClockFollower().SetSmoothing(0.1)
+
+	if _, ok := f.Tempo(); ok {
+		t.Fatalf("tempo must be unknown")
+	}
+
+	now := epoch
+	apply := func(e event.Event) {
+		f.Apply(stream.Message{Time: now, Event: e})
+	}
+
+	position, _ := event.NewSongPositionPointerEvent(nil, 4)
+	apply(position)
+	apply(&event.ContinueEvent{})
+
+	// The pulses of 120 BPM with jitter of +-1 ms.
+	interval := 500 * time.Millisecond / PulsesPerQuarterNote
+	for i := 0; i < 48; i++ {
+		jitter := time.Millisecond
+		if i%2 == 0 {
+			jitter = -jitter
+		}
+
+		now = epoch.Add(time.Duration(i+1)*interval + jitter)
+		apply(&event.TimingClockEvent{})
+	}
+
+	if !f.Running() {
+		t.Fatalf("follower must be running")
+	}
+	if f.Pulses() != 24+48 {
+		t.Fatalf("expected: 72 actual: %v", f.Pulses())
+	}
+	if f.Position(480) != 1440 {
+		t.Fatalf("expected: 1440 actual: %v", f.Position(480))
+	}
+	if bpm, _ := f.BPM(); math.Abs(bpm-120) > 1 {
+		t.Fatalf("expected: 120 BPM actual: %v BPM", bpm)
+	}
+
+	// The pause of clock doesn't affect tempo.
+	tempo, _ := f.Tempo()
+	now = now.Add(time.Second)
+	apply(&event.StopEvent{})
+	apply(&event.TimingClockEvent{})
+
+	if actual, _ := f.Tempo(); tempo != actual {
+		t.Fatalf("expected: %v actual: %v", tempo, actual)
+	}
+	if f.Running() || f.Pulses() != 72 {
+		t.Fatalf("follower must be stopped at 72 actual: running = %v, pulses = %v", f.Running(), f.Pulses())
+	}
+
+	apply(&event.StartEvent{})
+
+	if f.Pulses() != 0 {
+		t.Fatalf("expected: 0 actual: %v", f.Pulses())
+	}
+}
@@ -0,0 +1,267 @@
+package midisync
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/player"
+)
+
+// QuarterFrames returns 8 MTC quarter frame events which carry the time code.
+// The quarter frames span 2 frames, so that the next set carries the time code 2 frames later.
+func QuarterFrames(t Timecode) []*event.MTCQuarterFrameEvent {
+	values := [8]uint8{
+		t.Frame & 0x0f, t.Frame >> 4,
+		t.Second & 0x0f, t.Second >> 4,
+		t.Minute & 0x0f, t.Minute >> 4,
+		t.Hour & 0x0f, t.Hour>>4 | uint8(t.FrameRate)<<1,
+	}
+
+	es := make([]*event.MTCQuarterFrameEvent, len(values))
+
+	for i, value := range values {
+		es[i], _ = event.NewMTCQuarterFrameEvent(nil, uint8(i), value&0x0f)
+	}
+
+	return es
+}
+
+// FullFrame returns MTC full frame message, which is universal real-time system exclusive message to locate the time code.
+// The data starts with the device ID 0x7f, i.e. all devices, and ends with 0xf7.
+func FullFrame(t Timecode) *event.SystemExclusiveEvent {
+	e := &event.SystemExclusiveEvent{}
+	e.SetData([]byte{0x7f, 0x7f, 0x01, 0x01, uint8(t.FrameRate)<<5 | t.Hour, t.Minute, t.Second, t.Frame, constant.EndOfExclusive})
+
+	return e
+}
+
+// MTCDecoder decodes MTC quarter frame and full frame messages into time code.
+type MTCDecoder struct {
+	values   [8]uint8
+	received uint8
+	next     uint8
+}
+
+// Apply applies the event. It returns the time code when a full frame message is received, or 8 quarter frames
+// are received in order. The time code of quarter frames is advanced by 2 frames, which have elapsed during the transfer.
+// The other events are ignored.
+func (d *MTCDecoder) Apply(e event.Event) (Timecode, bool) {
+	switch v := e.(type) {
+	case *event.MTCQuarterFrameEvent:
+		messageType := v.MessageType()
+
+		if messageType == 0 || messageType != d.next {
+			d.received = 0
+		}
+
+		d.values[messageType] = v.Value()
+		d.received |= 1 << messageType
+		d.next = (messageType + 1) % 8
+
+		if messageType != 7 || d.received != 0xff {
+			return Timecode{}, false
+		}
+
+		t := Timecode{
+			Frame:     d.values[0] | (d.values[1]&0x01)<<4,
+			Second:    d.values[2] | (d.values[3]&0x03)<<4,
+			Minute:    d.values[4] | (d.values[5]&0x03)<<4,
+			Hour:      d.values[6] | (d.values[7]&0x01)<<4,
+			FrameRate: constant.FrameRate(d.values[7] >> 1 & 0x03),
+		}
+
+		return t.Add(2), true
+	case *event.SystemExclusiveEvent:
+		data := v.Data()
+
+		if len(data) < 8 || data[0] != 0x7f || data[2] != 0x01 || data[3] != 0x01 {
+			return Timecode{}, false
+		}
+
+		d.received = 0
+
+		t := Timecode{
+			Hour:      data[4] & 0x1f,
+			Minute:    data[5],
+			Second:    data[6],
+			Frame:     data[7],
+			FrameRate: constant.FrameRate(data[4] >> 5 & 0x03),
+		}
+
+		return t, true
+	}
+
+	return Timecode{}, false
+}
+
+// NewMTCDecoder returns MTCDecoder.
+func NewMTCDecoder() *MTCDecoder {
+	return &MTCDecoder{}
+}
+
+// MTCMaster sends MTC quarter frames in real time. The methods are safe for concurrent use.
+type MTCMaster struct {
+	mu sync.Mutex
+
+	output    player.Output
+	clock     player.Clock
+	interval  time.Duration
+	frameRate constant.FrameRate
+
+	running bool
+
+	// The quarter frame count is sent at anchorTime + count quarter frames. The count 0 carries anchor.
+	anchor     Timecode
+	anchorTime time.Time
+	count      int
+}
+
+// quarterFrameTime returns the time of quarter frame.
+func (m *MTCMaster) quarterFrameTime(count int) time.Time {
+	numerator, denominator := frameDuration(m.frameRate)
+
+	return m.anchorTime.Add(time.Duration(int64(count) * numerator * int64(time.Second) / (4 * denominator)))
+}
+
+// send sends the event to output.
+func (m *MTCMaster) send(e event.Event) error {
+	data := e.Serialize()
+
+	if sysEx, ok := e.(*event.SystemExclusiveEvent); ok {
+		data = append([]byte{constant.SystemExclusive}, sysEx.Data()...)
+	}
+
+	return m.output.Send(data)
+}
+
+// dispatch sends the quarter frames scheduled until now.
+func (m *MTCMaster) dispatch(now time.Time) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return time.Time{}, false, nil
+	}
+
+	for {
+		at := m.quarterFrameTime(m.count)
+		if at.After(now) {
+			return at, true, nil
+		}
+
+		e := QuarterFrames(m.anchor.Add(m.count / 8 * 2))[m.count%8]
+		if err := m.send(e); err != nil {
+			m.running = false
+			return time.Time{}, false, err
+		}
+
+		m.count++
+	}
+}
+
+// Timecode returns the current time code.
+func (m *MTCMaster) Timecode() Timecode {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.timecode(m.clock.Now())
+}
+
+func (m *MTCMaster) timecode(now time.Time) Timecode {
+	if !m.running {
+		return m.anchor
+	}
+
+	numerator, denominator := frameDuration(m.frameRate)
+	frames := int64(now.Sub(m.anchorTime)) * denominator / (numerator * int64(time.Second))
+
+	return m.anchor.Add(int(frames))
+}
+
+// Run sends full frame message to locate the current time code, then sends quarter frames until Stop is called.
+// It blocks while running.
+func (m *MTCMaster) Run() error {
+	m.mu.Lock()
+
+	if m.running {
+		m.mu.Unlock()
+		return fmt.Errorf("midi: MTC master is already running")
+	}
+
+	m.running = true
+	m.anchorTime = m.clock.Now()
+	m.count = 0
+
+	err := m.send(FullFrame(m.anchor))
+	clock, interval := m.clock, m.interval
+
+	m.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return run(clock, interval, m.dispatch)
+}
+
+// Stop stops sending quarter frames. The time code is kept, so that Run resumes from it.
+func (m *MTCMaster) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return
+	}
+
+	m.anchor = m.timecode(m.clock.Now())
+	m.running = false
+}
+
+// Seek moves to the time code and sends full frame message. The frame rate of t is replaced with the one of master.
+// While running, the quarter frames continue from the time code.
+func (m *MTCMaster) Seek(t Timecode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t.FrameRate = m.frameRate
+
+	m.anchor = t
+	m.anchorTime = m.clock.Now()
+	m.count = 0
+
+	return m.send(FullFrame(t))
+}
+
+// SetInterval sets the maximum duration of sleep between the wake-ups. The default is DefaultInterval.
+func (m *MTCMaster) SetInterval(interval time.Duration) *MTCMaster {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.interval = interval
+
+	return m
+}
+
+// SetClock sets clock. The default is player.SystemClock.
+func (m *MTCMaster) SetClock(clock player.Clock) *MTCMaster {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.clock = clock
+
+	return m
+}
+
+// NewMTCMaster returns MTCMaster which sends MTC of the frame rate to output from 00:00:00:00.
+func NewMTCMaster(output player.Output, frameRate constant.FrameRate) *MTCMaster {
+	return &MTCMaster{
+		output:    output,
+		clock:     player.SystemClock,
+		interval:  DefaultInterval,
+		frameRate: frameRate,
+		anchor:    Timecode{FrameRate: frameRate},
+	}
+}
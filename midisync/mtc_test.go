@@ -0,0 +1,96 @@
+package midisync
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/player"
+)
+
+func TestQuarterFrames(t *testing.T) {
+	tc := Timecode{Hour: 17, Minute: 35, Second: 42, Frame: 27, FrameRate: constant.FPS30}
+
+	expected := []string{"f1 0b", "f1 11", "f1 2a", "f1 32", "f1 43", "f1 52", "f1 61", "f1 77"}
+
+	for i, e := range QuarterFrames(tc) {
+		if actual := fmt.Sprintf("% x", e.Serialize()); expected[i] != actual {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected[i], i, actual)
+		}
+	}
+}
+
+func TestMTCDecoder_Apply(t *testing.T) {
+	tc := Timecode{Hour: 17, Minute: 35, Second: 42, Frame: 27, FrameRate: constant.FPS30}
+	d := NewMTCDecoder()
+
+	// The quarter frames from the middle are ignored until the next set begins.
+	es := QuarterFrames(tc)
+	for _, e := range es[4:] {
+		if _, ok := d.Apply(e); ok {
+			t.Fatalf("time code must not be decoded from %v", e)
+		}
+	}
+	for i, e := range es {
+		actual, ok := d.Apply(e)
+
+		if ok != (i == 7) {
+			t.Fatalf("expected: %v actual: %v", i == 7, ok)
+		}
+		if ok && actual != tc.Add(2) {
+			t.Fatalf("expected: %v actual: %v", tc.Add(2), actual)
+		}
+	}
+
+	full := Timecode{Hour: 1, Minute: 2, Second: 3, Frame: 4, FrameRate: constant.FPS30Drop}
+
+	actual, ok := d.Apply(FullFrame(full))
+	if !ok || actual != full {
+		t.Fatalf("expected: %v actual: %v", full, actual)
+	}
+	if _, ok := d.Apply(&event.TimingClockEvent{}); ok {
+		t.Fatalf("time code must not be decoded from timing clock")
+	}
+}
+
+func TestMTCMaster_Run(t *testing.T) {
+	fake := player.NewFakeClock(epoch)
+	clock := &stopClock{FakeClock: fake, at: epoch.Add(80 * time.Millisecond)}
+	output := player.NewRecordingOutput(fake)
+
+	m := NewMTCMaster(output, constant.FPS25).SetClock(clock)
+	if err := m.Seek(Timecode{Hour: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	clock.f = m.Stop
+
+	if err := m.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The quarter frames are sent every 10 ms at 25 fps.
+	expected := []string{
+		"0s f0 7f 7f 01 01 21 00 00 00 f7",
+		"0s f0 7f 7f 01 01 21 00 00 00 f7",
+		"0s f1 00", "10ms f1 10", "20ms f1 20", "30ms f1 30",
+		"40ms f1 40", "50ms f1 50", "60ms f1 61", "70ms f1 72",
+	}
+	actual := format(output.Records())
+
+	if fmt.Sprint(expected) != fmt.Sprint(actual) {
+		t.Fatalf("expected: %q actual: %q", expected, actual)
+	}
+	if tc := m.Timecode(); tc.String() != "01:00:00:02" {
+		t.Fatalf("expected: 01:00:00:02 actual: %v", tc)
+	}
+
+	d := NewMTCDecoder()
+	for _, e := range QuarterFrames(Timecode{Hour: 1, FrameRate: constant.FPS25}) {
+		if tc, ok := d.Apply(e); ok && tc.String() != "01:00:00:02" {
+			t.Fatalf("expected: 01:00:00:02 actual: %v", tc)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package midisync
+
+import (
+	"time"
+
+	"github.com/moutend/go-midi/player"
+)
+
+// DefaultInterval is the default maximum duration of sleep between the wake-ups of masters.
+const DefaultInterval = 5 * time.Millisecond
+
+// run calls dispatch repeatedly until it returns false or error. Between the calls, it sleeps until the time returned
+// by dispatch, but at most interval, so that the master can be stopped from another goroutine.
+func run(clock player.Clock, interval time.Duration, dispatch func(now time.Time) (time.Time, bool, error)) error {
+	for {
+		now := clock.Now()
+
+		next, running, err := dispatch(now)
+		if !running || err != nil {
+			return err
+		}
+
+		wait := next.Sub(now)
+		if interval > 0 && wait > interval {
+			wait = interval
+		}
+
+		clock.Sleep(wait)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Package midisync generates and follows MIDI clock and MIDI time code (MTC), so that the other devices such as drum
+machines and sequencers play in sync.
+*/
+package midisync
+
+import (
+	"fmt"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Timecode represents SMPTE time code.
+type Timecode struct {
+	Hour      uint8
+	Minute    uint8
+	Second    uint8
+	Frame     uint8
+	FrameRate constant.FrameRate
+}
+
+const (
+	// The 29.97 drop frame skips the frame 0 and 1 at the beginning of every minute except for every tenth minute.
+	dropFramesPerMinute    = 30*60 - 2
+	dropFramesPer10Minutes = dropFramesPerMinute*10 + 2
+)
+
+// framesPerDay returns the number of frames in 24 hours.
+func framesPerDay(rate constant.FrameRate) int {
+	if rate.DropFrame() {
+		return dropFramesPer10Minutes * 6 * 24
+	}
+
+	return rate.FramesPerSecond() * 60 * 60 * 24
+}
+
+// frameDuration returns the duration of frame as a fraction of second.
+func frameDuration(rate constant.FrameRate) (numerator, denominator int64) {
+	if rate.DropFrame() {
+		return 1001, 30000
+	}
+
+	return 1, int64(rate.FramesPerSecond())
+}
+
+// String returns string representation of time code, e.g. "01:02:03:04". The drop frame uses ";" before frames.
+func (t Timecode) String() string {
+	separator := ":"
+	if t.FrameRate.DropFrame() {
+		separator = ";"
+	}
+
+	return fmt.Sprintf("%02d:%02d:%02d%s%02d", t.Hour, t.Minute, t.Second, separator, t.Frame)
+}
+
+// Frames returns the number of frames from 00:00:00:00.
+func (t Timecode) Frames() int {
+	fps := t.FrameRate.FramesPerSecond()
+	minutes := int(t.Hour)*60 + int(t.Minute)
+	frames := (minutes*60+int(t.Second))*fps + int(t.Frame)
+
+	if t.FrameRate.DropFrame() {
+		frames -= 2 * (minutes - minutes/10)
+	}
+
+	return frames
+}
+
+// Add returns the time code advanced by frames. It wraps around at 24 hours.
+func (t Timecode) Add(frames int) Timecode {
+	return TimecodeFromFrames(t.Frames()+frames, t.FrameRate)
+}
+
+// Duration returns the duration from 00:00:00:00.
+func (t Timecode) Duration() time.Duration {
+	numerator, denominator := frameDuration(t.FrameRate)
+
+	return time.Duration(int64(t.Frames()) * numerator * int64(time.Second) / denominator)
+}
+
+// SMPTEOffsetEvent returns SMPTE offset event of the time code. The sub frame is 0.
+func (t Timecode) SMPTEOffsetEvent() (*event.SMPTEOffsetEvent, error) {
+	e, err := event.NewSMPTEOffsetEvent(nil, t.Hour, t.Minute, t.Second, t.Frame, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.SetFrameRate(t.FrameRate); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// TimecodeFromFrames returns the time code of the number of frames from 00:00:00:00. It wraps around at 24 hours.
+func TimecodeFromFrames(frames int, rate constant.FrameRate) Timecode {
+	day := framesPerDay(rate)
+	frames %= day
+	if frames < 0 {
+		frames += day
+	}
+	if rate.DropFrame() {
+		tens, rest := frames/dropFramesPer10Minutes, frames%dropFramesPer10Minutes
+		frames += 18 * tens
+		if rest >= 2 {
+			frames += 2 * ((rest - 2) / dropFramesPerMinute)
+		}
+	}
+
+	fps := rate.FramesPerSecond()
+
+	return Timecode{
+		Hour:      uint8(frames / (fps * 3600)),
+		Minute:    uint8(frames / (fps * 60) % 60),
+		Second:    uint8(frames / fps % 60),
+		Frame:     uint8(frames % fps),
+		FrameRate: rate,
+	}
+}
+
+// TimecodeAt returns the time code of the frame at the duration from 00:00:00:00.
+func TimecodeAt(d time.Duration, rate constant.FrameRate) Timecode {
+	numerator, denominator := frameDuration(rate)
+
+	return TimecodeFromFrames(int(int64(d)*denominator/(numerator*int64(time.Second))), rate)
+}
+
+// FromSMPTEOffset returns the time code of SMPTE offset event. The sub frame is discarded.
+func FromSMPTEOffset(e *event.SMPTEOffsetEvent) Timecode {
+	return Timecode{
+		Hour:      e.Hour(),
+		Minute:    e.Minute(),
+		Second:    e.Second(),
+		Frame:     e.Frame(),
+		FrameRate: e.FrameRate(),
+	}
+}
+
+// SMPTEOffset returns the time code of the SMPTE offset event at the beginning of song.
+func SMPTEOffset(m *midi.MIDI) (Timecode, bool) {
+	for _, te := range m.TimedEvents() {
+		if te.Tick > 0 {
+			break
+		}
+		if e, ok := te.Event.(*event.SMPTEOffsetEvent); ok {
+			return FromSMPTEOffset(e), true
+		}
+	}
+
+	return Timecode{}, false
+}
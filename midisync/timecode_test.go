@@ -0,0 +1,80 @@
+package midisync
+
+import (
+	"testing"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestTimecodeFromFrames(t *testing.T) {
+	for _, c := range []struct {
+		frames   int
+		rate     constant.FrameRate
+		expected string
+	}{
+		{0, constant.FPS25, "00:00:00:00"},
+		{25*3600 + 26, constant.FPS25, "01:00:01:01"},
+		{1799, constant.FPS30Drop, "00:00:59;29"},
+		{1800, constant.FPS30Drop, "00:01:00;02"},
+		{17982, constant.FPS30Drop, "00:10:00;00"},
+		{17982 + 1800, constant.FPS30Drop, "00:11:00;02"},
+		{24 * 3600 * 24, constant.FPS24, "00:00:00:00"},
+		{-1, constant.FPS30, "23:59:59:29"},
+	} {
+		tc := TimecodeFromFrames(c.frames, c.rate)
+
+		if actual := tc.String(); c.expected != actual {
+			t.Fatalf("expected: %v actual: %v", c.expected, actual)
+		}
+
+		day := framesPerDay(c.rate)
+		if actual := tc.Frames(); (c.frames%day+day)%day != actual {
+			t.Fatalf("expected: %v actual: %v", c.frames, actual)
+		}
+	}
+}
+
+func TestTimecode_Duration(t *testing.T) {
+	tc := Timecode{Minute: 10, FrameRate: constant.FPS30Drop}
+
+	// 17982 frames of 29.97 fps.
+	expected := 599999400 * time.Microsecond
+
+	if actual := tc.Duration(); actual != expected {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+	if actual := TimecodeAt(expected, constant.FPS30Drop); actual != tc {
+		t.Fatalf("expected: %v actual: %v", tc, actual)
+	}
+	if actual := TimecodeAt(1500*time.Millisecond, constant.FPS24).String(); actual != "00:00:01:12" {
+		t.Fatalf("expected: 00:00:01:12 actual: %v", actual)
+	}
+}
+
+func TestSMPTEOffset(t *testing.T) {
+	offset, _ := event.NewSMPTEOffsetEvent(nil, 1, 2, 3, 4, 0)
+	offset.SetFrameRate(constant.FPS25)
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrack(offset)}}
+
+	tc, ok := SMPTEOffset(m)
+	if !ok {
+		t.Fatalf("SMPTE offset must be found")
+	}
+
+	expected := Timecode{Hour: 1, Minute: 2, Second: 3, Frame: 4, FrameRate: constant.FPS25}
+	if tc != expected {
+		t.Fatalf("expected: %v actual: %v", expected, tc)
+	}
+
+	e, err := tc.SMPTEOffsetEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !e.Equal(offset) {
+		t.Fatalf("expected: %v actual: %v", offset, e)
+	}
+}
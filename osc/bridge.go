@@ -0,0 +1,232 @@
+package osc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// Bridge sends and receives the channel events as OSC messages over UDP.
+//
+// Bridge implements Output and TimedOutput of the player package, so that a player can play MIDI data to OSC.
+// The messages sent with time are wrapped in OSC bundle whose time tag tells the receiver when to process them.
+type Bridge struct {
+	mu     sync.Mutex
+	conn   *net.UDPConn
+	scheme *Scheme
+	remote *net.UDPAddr
+	closed bool
+
+	// The messages of the received bundle which are not returned by Receive yet.
+	receiveMu sync.Mutex
+	pending   []stream.Message
+	buf       []byte
+}
+
+// Addr returns the local address of bridge.
+func (b *Bridge) Addr() *net.UDPAddr {
+	return b.conn.LocalAddr().(*net.UDPAddr)
+}
+
+// SetRemote sets the address where the messages are sent, e.g. "127.0.0.1:9000".
+func (b *Bridge) SetRemote(address string) error {
+	remote, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remote = remote
+
+	return nil
+}
+
+// SetScheme sets scheme. The default is NewScheme().
+func (b *Bridge) SetScheme(scheme *Scheme) *Bridge {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.scheme = scheme
+
+	return b
+}
+
+// SendEvents sends the channel events to the remote address. The events are sent in OSC bundle with the time tag of at,
+// or in OSC message if at is zero and there is only one event.
+func (b *Bridge) SendEvents(at time.Time, es ...event.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.remote == nil {
+		return fmt.Errorf("midi: remote address of OSC bridge is not set")
+	}
+
+	bundle := &Bundle{Time: at}
+
+	for _, e := range es {
+		m, err := b.scheme.Message(e)
+		if err != nil {
+			return err
+		}
+
+		bundle.Elements = append(bundle.Elements, m)
+	}
+
+	var p Packet = bundle
+
+	if at.IsZero() && len(bundle.Elements) == 1 {
+		p = bundle.Elements[0]
+	}
+
+	data, err := p.AppendTo(nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.conn.WriteToUDP(data, b.remote)
+
+	return err
+}
+
+// decode decodes the raw MIDI messages and drops the events which are not mapped by scheme, e.g. system exclusive.
+func (b *Bridge) decode(data []byte) ([]event.Event, error) {
+	decoder := stream.NewDecoder(bytes.NewReader(data))
+
+	var es []event.Event
+
+	for {
+		m, err := decoder.Decode()
+		if err == io.EOF {
+			return es, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		b.mu.Lock()
+		mapped := b.scheme.Address(m.Event.Kind()) != ""
+		b.mu.Unlock()
+
+		if mapped {
+			es = append(es, m.Event)
+		}
+	}
+}
+
+// Send sends the raw MIDI messages immediately. The messages which are not mapped by scheme are dropped.
+func (b *Bridge) Send(data []byte) error {
+	return b.SendAt(data, time.Time{})
+}
+
+// SendAt sends the raw MIDI messages to be processed at the time. The messages which are not mapped by scheme are dropped.
+func (b *Bridge) SendAt(data []byte, at time.Time) error {
+	es, err := b.decode(data)
+	if err != nil || len(es) == 0 {
+		return err
+	}
+
+	return b.SendEvents(at, es...)
+}
+
+// appendMessages appends the events of the OSC packet to ms. The messages which are not mapped by scheme are skipped.
+// The time of message is the time tag of the bundle, or arrival if it's immediate.
+func (b *Bridge) appendMessages(ms []stream.Message, p Packet, arrival time.Time) []stream.Message {
+	switch v := p.(type) {
+	case *Message:
+		b.mu.Lock()
+		e, err := b.scheme.Event(v)
+		b.mu.Unlock()
+
+		if err == nil {
+			ms = append(ms, stream.Message{Time: arrival, Event: e})
+		}
+	case *Bundle:
+		at := v.Time
+		if at.IsZero() {
+			at = arrival
+		}
+		for _, element := range v.Elements {
+			ms = append(ms, b.appendMessages(nil, element, at)...)
+		}
+	}
+
+	return ms
+}
+
+// Receive returns the event received from any address. It returns io.EOF after the bridge is closed.
+// The OSC packets which are malformed or not mapped by scheme are skipped.
+func (b *Bridge) Receive() (stream.Message, error) {
+	b.receiveMu.Lock()
+	defer b.receiveMu.Unlock()
+
+	for len(b.pending) == 0 {
+		n, _, err := b.conn.ReadFromUDP(b.buf)
+		if err != nil {
+			b.mu.Lock()
+			closed := b.closed
+			b.mu.Unlock()
+
+			if closed {
+				return stream.Message{}, io.EOF
+			}
+			return stream.Message{}, err
+		}
+
+		p, err := Parse(b.buf[:n])
+		if err != nil {
+			continue
+		}
+
+		b.pending = b.appendMessages(b.pending, p, time.Now())
+	}
+
+	m := b.pending[0]
+	b.pending = b.pending[1:]
+
+	return m, nil
+}
+
+// Close closes the bridge.
+func (b *Bridge) Close() error {
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+
+	b.closed = true
+	b.mu.Unlock()
+
+	return b.conn.Close()
+}
+
+// Listen returns Bridge which receives OSC packets on the address, e.g. "127.0.0.1:8000".
+// The port 0 chooses a free port. Call SetRemote before sending.
+func Listen(address string) (*Bridge, error) {
+	addr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bridge{
+		conn:   conn,
+		scheme: NewScheme(),
+		buf:    make([]byte, 65536),
+	}
+
+	return b, nil
+}
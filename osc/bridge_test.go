@@ -0,0 +1,100 @@
+package osc
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/player"
+)
+
+var _ player.TimedOutput = &Bridge{}
+
+func listen(t *testing.T) *Bridge {
+	t.Helper()
+
+	b, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestBridge(t *testing.T) {
+	sender := listen(t)
+	defer sender.Close()
+
+	receiver := listen(t)
+	defer receiver.Close()
+
+	if err := sender.SetRemote(receiver.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	noteOn, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0x40)
+	noteOff, _ := event.NewNoteOffEvent(nil, 0, constant.C3, 0x00)
+	pitchBend, _ := event.NewPitchBendEvent(nil, 1, 0x2000)
+	at := time.Now().Add(time.Hour).Round(time.Microsecond)
+
+	before := time.Now()
+
+	if err := sender.SendEvents(time.Time{}, noteOn); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.SendEvents(at, pitchBend, noteOff); err != nil {
+		t.Fatal(err)
+	}
+
+	// The system exclusive message is dropped and the pitch bend is sent.
+	if err := sender.SendAt([]byte{0xf0, 0x7e, 0xf7, 0xe1, 0x00, 0x40}, at); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Send([]byte{0xf8}); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []event.Event{noteOn, pitchBend, noteOff, pitchBend} {
+		m, err := receiver.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(m.Event) {
+			t.Fatalf("expected: %v actual: %v", expected, m.Event)
+		}
+
+		// The time of immediate message is the arrival time.
+		if i == 0 && m.Time.Before(before) {
+			t.Fatalf("expected: after %v actual: %v", before, m.Time)
+		}
+		if i > 0 && !m.Time.Equal(at) {
+			t.Fatalf("expected: %v actual: %v", at, m.Time)
+		}
+	}
+
+	if err := receiver.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := receiver.Receive(); err != io.EOF {
+		t.Fatalf("expected: %v actual: %v", io.EOF, err)
+	}
+}
+
+func TestBridge_SendEvents_error(t *testing.T) {
+	b := listen(t)
+	defer b.Close()
+
+	noteOn, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0x40)
+
+	if err := b.SendEvents(time.Time{}, noteOn); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := b.SetRemote(b.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SendEvents(time.Time{}, &event.TimingClockEvent{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Package osc bridges MIDI events and Open Sound Control (OSC) 1.0 messages over UDP.
+
+Scheme maps the channel events to OSC addresses such as "/midi/ch/1/note", and Bridge sends and receives them.
+The OSC bundle carries the time tag, so that the receiver can schedule the messages for playback.
+*/
+package osc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Packet represents OSC packet, i.e. *Message or *Bundle.
+type Packet interface {
+	AppendTo(dst []byte) ([]byte, error)
+}
+
+// Message represents OSC message. The supported types of arguments are int32, float32, string, []byte and bool.
+type Message struct {
+	Address   string
+	Arguments []interface{}
+}
+
+// Bundle represents OSC bundle. The elements are to be processed at Time, or immediately if Time is zero.
+type Bundle struct {
+	Time     time.Time
+	Elements []Packet
+}
+
+// secondsFrom1900To1970 is the difference of the epoch of OSC time tag from the one of Unix time.
+const secondsFrom1900To1970 = 2208988800
+
+// immediately is the special time tag which means immediately.
+const immediately = 1
+
+// appendString appends OSC-string, i.e. the string terminated by 0x00 and padded to a multiple of 4 bytes.
+func appendString(dst []byte, s string) []byte {
+	dst = append(dst, s...)
+
+	return append(dst, make([]byte, 4-len(s)%4)...)
+}
+
+// appendBlob appends OSC-blob, i.e. the size of data followed by data padded to a multiple of 4 bytes.
+func appendBlob(dst []byte, data []byte) []byte {
+	dst = appendUint32(dst, uint32(len(data)))
+	dst = append(dst, data...)
+
+	return append(dst, make([]byte, (4-len(data)%4)%4)...)
+}
+
+func appendUint32(dst []byte, v uint32) []byte {
+	return append(dst, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// timeTag returns OSC time tag of t, i.e. NTP timestamp.
+func timeTag(t time.Time) uint64 {
+	if t.IsZero() {
+		return immediately
+	}
+
+	seconds := uint64(t.Unix() + secondsFrom1900To1970)
+
+	// The fraction is rounded up, so that timeFromTag returns the same nanoseconds.
+	fraction := (uint64(t.Nanosecond())<<32 + uint64(time.Second) - 1) / uint64(time.Second)
+
+	return seconds<<32 | fraction
+}
+
+// timeFromTag returns the time of OSC time tag. It returns zero time for immediately.
+func timeFromTag(tag uint64) time.Time {
+	if tag == immediately {
+		return time.Time{}
+	}
+
+	seconds := int64(tag>>32) - secondsFrom1900To1970
+	nanoseconds := int64((tag & 0xffffffff) * uint64(time.Second) >> 32)
+
+	return time.Unix(seconds, nanoseconds)
+}
+
+// AppendTo appends serialized OSC message to dst and returns the extended buffer.
+func (m *Message) AppendTo(dst []byte) ([]byte, error) {
+	if !strings.HasPrefix(m.Address, "/") {
+		return nil, fmt.Errorf("midi: OSC address must begin with '/' (%q)", m.Address)
+	}
+
+	tags := []byte{','}
+
+	for _, argument := range m.Arguments {
+		switch v := argument.(type) {
+		case int32:
+			tags = append(tags, 'i')
+		case float32:
+			tags = append(tags, 'f')
+		case string:
+			tags = append(tags, 's')
+		case []byte:
+			tags = append(tags, 'b')
+		case bool:
+			if v {
+				tags = append(tags, 'T')
+			} else {
+				tags = append(tags, 'F')
+			}
+		default:
+			return nil, fmt.Errorf("midi: unsupported type of OSC argument %T", argument)
+		}
+	}
+
+	dst = appendString(dst, m.Address)
+	dst = appendString(dst, string(tags))
+
+	for _, argument := range m.Arguments {
+		switch v := argument.(type) {
+		case int32:
+			dst = appendUint32(dst, uint32(v))
+		case float32:
+			dst = appendUint32(dst, math.Float32bits(v))
+		case string:
+			dst = appendString(dst, v)
+		case []byte:
+			dst = appendBlob(dst, v)
+		}
+	}
+
+	return dst, nil
+}
+
+// AppendTo appends serialized OSC bundle to dst and returns the extended buffer.
+func (b *Bundle) AppendTo(dst []byte) ([]byte, error) {
+	dst = appendString(dst, "#bundle")
+	dst = appendUint32(dst, uint32(timeTag(b.Time)>>32))
+	dst = appendUint32(dst, uint32(timeTag(b.Time)))
+
+	for _, element := range b.Elements {
+		data, err := element.AppendTo(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		dst = appendBlob(dst, data)
+	}
+
+	return dst, nil
+}
+
+// parseString parses OSC-string and returns the rest of data.
+func parseString(data []byte) (string, []byte, error) {
+	for i, b := range data {
+		if b != 0x00 {
+			continue
+		}
+
+		size := (i/4 + 1) * 4
+		if len(data) < size {
+			break
+		}
+
+		return string(data[:i]), data[size:], nil
+	}
+
+	return "", nil, fmt.Errorf("midi: OSC-string is not terminated")
+}
+
+// parseBlob parses OSC-blob and returns the rest of data.
+func parseBlob(data []byte) ([]byte, []byte, error) {
+	if len(data) < 4 {
+		return nil, nil, fmt.Errorf("midi: missing size of OSC-blob")
+	}
+
+	size := int(binary.BigEndian.Uint32(data))
+	padded := (size + 3) / 4 * 4
+
+	if size < 0 || len(data)-4 < padded {
+		return nil, nil, fmt.Errorf("midi: OSC-blob is shorter than its size (%v)", size)
+	}
+
+	return append([]byte{}, data[4:4+size]...), data[4+padded:], nil
+}
+
+func parseMessage(data []byte) (*Message, error) {
+	address, data, err := parseString(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Message{Address: address}
+
+	// The type tag string is optional in the old implementations.
+	if len(data) == 0 {
+		return m, nil
+	}
+
+	tags, data, err := parseString(data)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(tags, ",") {
+		return nil, fmt.Errorf("midi: OSC type tag string must begin with ',' (%q)", tags)
+	}
+
+	for _, tag := range tags[1:] {
+		switch tag {
+		case 'i', 'f':
+			if len(data) < 4 {
+				return nil, fmt.Errorf("midi: OSC argument of type '%c' is truncated", tag)
+			}
+
+			v := binary.BigEndian.Uint32(data)
+			data = data[4:]
+
+			if tag == 'i' {
+				m.Arguments = append(m.Arguments, int32(v))
+			} else {
+				m.Arguments = append(m.Arguments, math.Float32frombits(v))
+			}
+		case 's':
+			var s string
+
+			if s, data, err = parseString(data); err != nil {
+				return nil, err
+			}
+
+			m.Arguments = append(m.Arguments, s)
+		case 'b':
+			var blob []byte
+
+			if blob, data, err = parseBlob(data); err != nil {
+				return nil, err
+			}
+
+			m.Arguments = append(m.Arguments, blob)
+		case 'T', 'F':
+			m.Arguments = append(m.Arguments, tag == 'T')
+		default:
+			return nil, fmt.Errorf("midi: unsupported OSC type tag '%c'", tag)
+		}
+	}
+
+	return m, nil
+}
+
+func parseBundle(data []byte) (*Bundle, error) {
+	if len(data) < 16 {
+		return nil, fmt.Errorf("midi: OSC bundle is too short (%v bytes)", len(data))
+	}
+
+	b := &Bundle{Time: timeFromTag(binary.BigEndian.Uint64(data[8:]))}
+	data = data[16:]
+
+	for len(data) > 0 {
+		element, rest, err := parseBlob(data)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := Parse(element)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Elements = append(b.Elements, p)
+		data = rest
+	}
+
+	return b, nil
+}
+
+// Parse parses OSC packet. It returns *Message or *Bundle.
+func Parse(data []byte) (Packet, error) {
+	switch {
+	case len(data) >= 8 && string(data[:8]) == "#bundle\x00":
+		return parseBundle(data)
+	case len(data) > 0 && data[0] == '/':
+		return parseMessage(data)
+	}
+
+	return nil, fmt.Errorf("midi: data is neither OSC message nor OSC bundle")
+}
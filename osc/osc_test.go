@@ -0,0 +1,116 @@
+package osc
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMessage_AppendTo(t *testing.T) {
+	m := &Message{
+		Address:   "/midi",
+		Arguments: []interface{}{int32(1), float32(0.5), "ab", []byte{0x01}, true},
+	}
+
+	expected := []byte{
+		'/', 'm', 'i', 'd', 'i', 0, 0, 0,
+		',', 'i', 'f', 's', 'b', 'T', 0, 0,
+		0x00, 0x00, 0x00, 0x01,
+		0x3f, 0x00, 0x00, 0x00,
+		'a', 'b', 0, 0,
+		0x00, 0x00, 0x00, 0x01, 0x01, 0, 0, 0,
+	}
+	actual, err := m.AppendTo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+
+	if _, err := (&Message{Address: "midi"}).AppendTo(nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := (&Message{Address: "/midi", Arguments: []interface{}{1}}).AppendTo(nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParse(t *testing.T) {
+	at := time.Date(2020, 1, 2, 3, 4, 5, 123456789, time.UTC)
+
+	for _, expected := range []Packet{
+		&Message{Address: "/a"},
+		&Message{Address: "/abc", Arguments: []interface{}{int32(-1), float32(1.25), "", []byte{}, false}},
+		&Bundle{Time: at, Elements: []Packet{
+			&Message{Address: "/a", Arguments: []interface{}{int32(1)}},
+			&Bundle{Elements: []Packet{&Message{Address: "/b"}}},
+		}},
+	} {
+		data, err := expected.AppendTo(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := Parse(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The time of bundle is in local time, so that the bundle is compared by serializing it again.
+		if b, ok := actual.(*Bundle); ok {
+			if !b.Time.Equal(at) {
+				t.Fatalf("expected: %v actual: %v", at, b.Time)
+			}
+
+			serialized, err := b.AppendTo(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, serialized) {
+				t.Fatalf("expected: % x actual: % x", data, serialized)
+			}
+			if !reflect.DeepEqual(expected.(*Bundle).Elements[0], b.Elements[0]) {
+				t.Fatalf("expected: %+v actual: %+v", expected.(*Bundle).Elements[0], b.Elements[0])
+			}
+			continue
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected: %+v actual: %+v", expected, actual)
+		}
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	for _, data := range [][]byte{
+		{},
+		{'a', 0, 0, 0},
+		{'/', 'a', 'b', 'c'},
+		{'/', 'a', 0, 0, 'i', 0, 0, 0},
+		{'/', 'a', 0, 0, ',', 'i', 0, 0, 0x00},
+		{'/', 'a', 0, 0, ',', 'x', 0, 0},
+		{'/', 'a', 0, 0, ',', 'b', 0, 0, 0x00, 0x00, 0x00, 0x08, 0x00},
+		{'#', 'b', 'u', 'n', 'd', 'l', 'e', 0, 0x00},
+		{'#', 'b', 'u', 'n', 'd', 'l', 'e', 0, 0, 0, 0, 0, 0, 0, 0, 1, 0x00, 0x00, 0x00, 0x04, 'a', 0, 0, 0},
+	} {
+		if _, err := Parse(data); err == nil {
+			t.Fatalf("expected error for % x", data)
+		}
+	}
+}
+
+func TestTimeTag(t *testing.T) {
+	if actual := timeTag(time.Time{}); actual != immediately {
+		t.Fatalf("expected: %v actual: %v", immediately, actual)
+	}
+	if actual := timeFromTag(immediately); !actual.IsZero() {
+		t.Fatalf("expected: zero actual: %v", actual)
+	}
+
+	// 1970-01-01 00:00:00.5 UTC
+	expected := uint64(2208988800)<<32 | 0x80000000
+	if actual := timeTag(time.Unix(0, 500000000)); actual != expected {
+		t.Fatalf("expected: 0x%x actual: 0x%x", expected, actual)
+	}
+}
@@ -0,0 +1,188 @@
+package osc
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// ChannelPlaceholder is replaced with the channel number from 1 to 16 in the addresses of Scheme.
+const ChannelPlaceholder = "{channel}"
+
+// statuses is the status bytes of channel 0 for the kinds of channel events.
+var statuses = map[event.Kind]byte{
+	event.NoteOff:           constant.NoteOff,
+	event.NoteOn:            constant.NoteOn,
+	event.NoteAfterTouch:    constant.NoteAfterTouch,
+	event.Controller:        constant.Controller,
+	event.ProgramChange:     constant.ProgramChange,
+	event.ChannelAfterTouch: constant.ChannelAfterTouch,
+	event.PitchBend:         constant.PitchBend,
+}
+
+// Scheme maps the kinds of channel events to OSC addresses, e.g. "/midi/ch/{channel}/note" for note on.
+//
+// The arguments are int32, i.e. note and velocity for note off, note on and note after touch, control and value for
+// controller, program for program change, velocity for channel after touch, and pitch from 0 to 16383 for pitch bend.
+// The float32 arguments are also accepted on receiving and rounded to the nearest integers.
+// The zero value maps no kinds, and NewScheme returns Scheme with the default addresses.
+type Scheme struct {
+	addresses map[event.Kind]string
+}
+
+// SetAddress sets the address of kind. The address must contain ChannelPlaceholder once and differ from the others.
+func (s *Scheme) SetAddress(kind event.Kind, address string) error {
+	if _, ok := statuses[kind]; !ok {
+		return fmt.Errorf("midi: OSC address can be set only for channel events (%v)", kind)
+	}
+	if !strings.HasPrefix(address, "/") {
+		return fmt.Errorf("midi: OSC address must begin with '/' (%q)", address)
+	}
+	if strings.Count(address, ChannelPlaceholder) != 1 {
+		return fmt.Errorf("midi: OSC address must contain %v once (%q)", ChannelPlaceholder, address)
+	}
+	for k, a := range s.addresses {
+		if k != kind && a == address {
+			return fmt.Errorf("midi: OSC address %q is already used for %v", address, k)
+		}
+	}
+
+	if s.addresses == nil {
+		s.addresses = map[event.Kind]string{}
+	}
+
+	s.addresses[kind] = address
+
+	return nil
+}
+
+// Address returns the address of kind, or empty string if the kind is not mapped.
+func (s *Scheme) Address(kind event.Kind) string {
+	return s.addresses[kind]
+}
+
+// Message converts the channel event into OSC message.
+func (s *Scheme) Message(e event.Event) (*Message, error) {
+	address, ok := s.addresses[e.Kind()]
+	if !ok {
+		return nil, fmt.Errorf("midi: no OSC address for %v", e.Kind())
+	}
+
+	data := e.Serialize()
+	channel := strconv.Itoa(int(data[0]&0x0f) + 1)
+
+	m := &Message{Address: strings.Replace(address, ChannelPlaceholder, channel, 1)}
+
+	if e.Kind() == event.PitchBend {
+		m.Arguments = []interface{}{int32(data[2])<<7 | int32(data[1])}
+		return m, nil
+	}
+	for _, b := range data[1:] {
+		m.Arguments = append(m.Arguments, int32(b))
+	}
+
+	return m, nil
+}
+
+// match returns the kind and the channel of address.
+func (s *Scheme) match(address string) (event.Kind, uint8, bool) {
+	for kind, template := range s.addresses {
+		i := strings.Index(template, ChannelPlaceholder)
+		prefix, suffix := template[:i], template[i+len(ChannelPlaceholder):]
+
+		if len(address) <= len(prefix)+len(suffix) || !strings.HasPrefix(address, prefix) || !strings.HasSuffix(address, suffix) {
+			continue
+		}
+
+		channel, err := strconv.Atoi(address[len(prefix) : len(address)-len(suffix)])
+		if err != nil || channel < 1 || channel > 16 {
+			continue
+		}
+
+		return kind, uint8(channel - 1), true
+	}
+
+	return 0, 0, false
+}
+
+// integer returns the OSC argument as integer.
+func integer(argument interface{}) (int, error) {
+	switch v := argument.(type) {
+	case int32:
+		return int(v), nil
+	case float32:
+		return int(math.Round(float64(v))), nil
+	}
+
+	return 0, fmt.Errorf("midi: OSC argument must be int32 or float32 (%T)", argument)
+}
+
+// Event converts OSC message into the channel event.
+func (s *Scheme) Event(m *Message) (event.Event, error) {
+	kind, channel, ok := s.match(m.Address)
+	if !ok {
+		return nil, fmt.Errorf("midi: no event for OSC address %q", m.Address)
+	}
+
+	size, max := 2, 0x7f
+
+	switch kind {
+	case event.ProgramChange, event.ChannelAfterTouch:
+		size = 1
+	case event.PitchBend:
+		size, max = 1, 0x3fff
+	}
+	if len(m.Arguments) != size {
+		return nil, fmt.Errorf("midi: OSC message %q must have %v arguments (%v)", m.Address, size, len(m.Arguments))
+	}
+
+	data := []byte{statuses[kind] | channel}
+
+	for _, argument := range m.Arguments {
+		v, err := integer(argument)
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 || v > max {
+			return nil, fmt.Errorf("midi: OSC argument of %q must be 0 to %v (%v)", m.Address, max, v)
+		}
+		if kind == event.PitchBend {
+			data = append(data, byte(v&0x7f), byte(v>>7))
+		} else {
+			data = append(data, byte(v))
+		}
+	}
+
+	message, err := stream.NewDecoder(bytes.NewReader(data)).Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	return message.Event, nil
+}
+
+// NewScheme returns Scheme with the default addresses, i.e. "/midi/ch/{channel}/" followed by "noteoff", "note",
+// "polytouch", "cc", "program", "aftertouch" and "pitchbend".
+func NewScheme() *Scheme {
+	s := &Scheme{addresses: map[event.Kind]string{}}
+
+	for kind, name := range map[event.Kind]string{
+		event.NoteOff:           "noteoff",
+		event.NoteOn:            "note",
+		event.NoteAfterTouch:    "polytouch",
+		event.Controller:        "cc",
+		event.ProgramChange:     "program",
+		event.ChannelAfterTouch: "aftertouch",
+		event.PitchBend:         "pitchbend",
+	} {
+		s.addresses[kind] = "/midi/ch/" + ChannelPlaceholder + "/" + name
+	}
+
+	return s
+}
@@ -0,0 +1,137 @@
+package osc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestScheme(t *testing.T) {
+	noteOn, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0x40)
+	noteOff, _ := event.NewNoteOffEvent(nil, 15, constant.C3, 0x00)
+	noteAfterTouch, _ := event.NewNoteAfterTouchEvent(nil, 1, constant.D3, 0x10)
+	controller, _ := event.NewControllerEvent(nil, 2, constant.MainVolume, 0x64)
+	program, _ := event.NewProgramChangeEvent(nil, 3, 0x05)
+	channelAfterTouch, _ := event.NewChannelAfterTouchEvent(nil, 4, 0x20)
+	pitchBend, _ := event.NewPitchBendEvent(nil, 5, 0x3fff)
+
+	s := NewScheme()
+
+	for _, c := range []struct {
+		event    event.Event
+		expected *Message
+	}{
+		{noteOn, &Message{Address: "/midi/ch/1/note", Arguments: []interface{}{int32(0x3c), int32(0x40)}}},
+		{noteOff, &Message{Address: "/midi/ch/16/noteoff", Arguments: []interface{}{int32(0x3c), int32(0x00)}}},
+		{noteAfterTouch, &Message{Address: "/midi/ch/2/polytouch", Arguments: []interface{}{int32(0x3e), int32(0x10)}}},
+		{controller, &Message{Address: "/midi/ch/3/cc", Arguments: []interface{}{int32(0x07), int32(0x64)}}},
+		{program, &Message{Address: "/midi/ch/4/program", Arguments: []interface{}{int32(0x05)}}},
+		{channelAfterTouch, &Message{Address: "/midi/ch/5/aftertouch", Arguments: []interface{}{int32(0x20)}}},
+		{pitchBend, &Message{Address: "/midi/ch/6/pitchbend", Arguments: []interface{}{int32(0x3fff)}}},
+	} {
+		actual, err := s.Message(c.event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(c.expected, actual) {
+			t.Fatalf("expected: %+v actual: %+v", c.expected, actual)
+		}
+
+		e, err := s.Event(actual)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !c.event.Equal(e) {
+			t.Fatalf("expected: %v actual: %v", c.event, e)
+		}
+	}
+}
+
+func TestScheme_SetAddress(t *testing.T) {
+	s := NewScheme()
+
+	if err := s.SetAddress(event.NoteOn, "/synth/{channel}/on"); err != nil {
+		t.Fatal(err)
+	}
+
+	noteOn, _ := event.NewNoteOnEvent(nil, 9, constant.C3, 0x40)
+
+	m, err := s.Message(noteOn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "/synth/10/on"; m.Address != expected {
+		t.Fatalf("expected: %v actual: %v", expected, m.Address)
+	}
+
+	// The float arguments are rounded.
+	e, err := s.Event(&Message{Address: "/synth/10/on", Arguments: []interface{}{float32(59.6), int32(0x40)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !noteOn.Equal(e) {
+		t.Fatalf("expected: %v actual: %v", noteOn, e)
+	}
+
+	for _, c := range []struct {
+		kind    event.Kind
+		address string
+	}{
+		{event.SystemExclusive, "/midi/{channel}/sysex"},
+		{event.NoteOff, "midi/{channel}/off"},
+		{event.NoteOff, "/midi/off"},
+		{event.NoteOff, "/midi/{channel}/{channel}"},
+		{event.NoteOff, "/synth/{channel}/on"},
+	} {
+		if err := s.SetAddress(c.kind, c.address); err == nil {
+			t.Fatalf("expected error for %v %q", c.kind, c.address)
+		}
+	}
+}
+
+func TestScheme_zeroValue(t *testing.T) {
+	var s Scheme
+
+	noteOn, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0x40)
+
+	if _, err := s.Message(noteOn); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := s.SetAddress(event.NoteOn, "/on/{channel}"); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := s.Message(noteOn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "/on/1"; m.Address != expected {
+		t.Fatalf("expected: %v actual: %v", expected, m.Address)
+	}
+}
+
+func TestScheme_Event_error(t *testing.T) {
+	s := NewScheme()
+
+	for _, m := range []*Message{
+		{Address: "/midi/ch/1/unknown", Arguments: []interface{}{int32(1), int32(1)}},
+		{Address: "/midi/ch/0/note", Arguments: []interface{}{int32(1), int32(1)}},
+		{Address: "/midi/ch/17/note", Arguments: []interface{}{int32(1), int32(1)}},
+		{Address: "/midi/ch/x/note", Arguments: []interface{}{int32(1), int32(1)}},
+		{Address: "/midi/ch/1/note", Arguments: []interface{}{int32(1)}},
+		{Address: "/midi/ch/1/note", Arguments: []interface{}{int32(1), int32(128)}},
+		{Address: "/midi/ch/1/note", Arguments: []interface{}{int32(1), "a"}},
+		{Address: "/midi/ch/1/pitchbend", Arguments: []interface{}{int32(0x4000)}},
+	} {
+		if _, err := s.Event(m); err == nil {
+			t.Fatalf("expected error for %+v", m)
+		}
+	}
+
+	sysEx := &event.SystemExclusiveEvent{}
+	if _, err := s.Message(sysEx); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,208 @@
+package parameter
+
+import (
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+type parameterKey struct {
+	registered bool
+	number     uint16
+}
+
+type channelState struct {
+	registered bool
+	rpn        uint16
+	nrpn       uint16
+	values     map[parameterKey]uint16
+	msb        [0x20]uint8
+}
+
+func (s *channelState) selected() (parameterKey, bool) {
+	key := parameterKey{registered: s.registered, number: s.nrpn}
+	if s.registered {
+		key.number = s.rpn
+	}
+
+	return key, key.number != uint16(constant.NullParameter)
+}
+
+// Decoder decodes controller events into messages. It keeps the selected parameter
+// and the MSB of 14-bit controllers for each channel. The zero value is ready to use.
+type Decoder struct {
+	channels [16]channelState
+	ready    bool
+}
+
+// Decode decodes the controller event. The second return value is false if the event
+// doesn't produce a message, e.g. the event selects a parameter.
+//
+// The MSB of 14-bit controller and the data entry MSB reset the LSB to 0 as the MIDI specification says.
+// Reset all controllers deselects the parameter and resets the MSBs as recommended practice RP-015 says.
+// The data increment and decrement change the value by 1, or by 128 for the coarse tuning.
+func (d *Decoder) Decode(e *event.ControllerEvent) (Message, bool) {
+	if !d.ready {
+		d.Reset()
+	}
+
+	channel := e.Channel()
+	state := &d.channels[channel]
+	control := e.Control()
+	value := uint16(e.Value())
+
+	switch control {
+	case constant.RegisteredParameterNumberMSB:
+		state.registered = true
+		state.rpn = value<<7 | state.rpn&0x7f
+		return Message{}, false
+	case constant.RegisteredParameterNumberLSB:
+		state.registered = true
+		state.rpn = state.rpn&0x3f80 | value
+		return Message{}, false
+	case constant.NonRegisteredParameterNumberMSB:
+		state.registered = false
+		state.nrpn = value<<7 | state.nrpn&0x7f
+		return Message{}, false
+	case constant.NonRegisteredParameterNumberLSB:
+		state.registered = false
+		state.nrpn = state.nrpn&0x3f80 | value
+		return Message{}, false
+	case constant.ResetAllControllers:
+		state.rpn = uint16(constant.NullParameter)
+		state.nrpn = uint16(constant.NullParameter)
+
+		for msb := range state.msb {
+			if value, ok := constant.Control(msb).ResetValue(); ok {
+				state.msb[msb] = value
+			}
+		}
+
+		return Message{Type: ControlChange, Channel: channel, Control: control, Value: value}, true
+	case constant.DataEntry, constant.DataEntryLSB, constant.DataIncrement, constant.DataDecrement:
+		key, ok := state.selected()
+		if !ok {
+			return Message{}, false
+		}
+
+		current := state.values[key]
+
+		switch control {
+		case constant.DataEntry:
+			current = value << 7
+		case constant.DataEntryLSB:
+			current = current&0x3f80 | value
+		case constant.DataIncrement, constant.DataDecrement:
+			step := uint16(1)
+			if key.registered && key.number == uint16(constant.CoarseTuning) {
+				step = 0x80
+			}
+			if control == constant.DataIncrement {
+				current += step
+				if current > 0x3fff {
+					current = 0x3fff
+				}
+			} else if current >= step {
+				current -= step
+			} else {
+				current = 0
+			}
+		}
+
+		state.values[key] = current
+
+		m := Message{
+			Type:    NonRegisteredParameterChange,
+			Channel: channel,
+			Number:  key.number,
+			Value:   current,
+		}
+		if key.registered {
+			m.Type = RegisteredParameterChange
+		}
+
+		return m, true
+	}
+
+	switch {
+	case control.IsMSB():
+		state.msb[control] = uint8(value)
+
+		return Message{Type: ControlChange14, Channel: channel, Control: control, Value: value << 7}, true
+	case control.IsLSB():
+		msb, _ := control.MSB()
+
+		return Message{Type: ControlChange14, Channel: channel, Control: msb, Value: uint16(state.msb[msb])<<7 | value}, true
+	}
+
+	return Message{Type: ControlChange, Channel: channel, Control: control, Value: value}, true
+}
+
+// Reset resets the state of all channels. No parameter is selected after reset.
+func (d *Decoder) Reset() {
+	for channel := range d.channels {
+		d.channels[channel] = channelState{
+			rpn:    uint16(constant.NullParameter),
+			nrpn:   uint16(constant.NullParameter),
+			values: map[parameterKey]uint16{},
+		}
+	}
+
+	d.ready = true
+}
+
+// NewDecoder returns Decoder.
+func NewDecoder() *Decoder {
+	d := &Decoder{}
+	d.Reset()
+
+	return d
+}
+
+// TimedMessage represents a message with its absolute time in ticks.
+type TimedMessage struct {
+	Tick    uint32
+	Message Message
+}
+
+// DecodeTrack decodes all controller events of the track.
+// The consecutive messages of the same controller or parameter at the same tick are folded into the last one,
+// e.g. data entry MSB followed by data entry LSB becomes a single parameter change.
+func DecodeTrack(track *midi.Track) []TimedMessage {
+	d := NewDecoder()
+	tms := []TimedMessage{}
+
+	for _, te := range track.TimedEvents() {
+		e, ok := te.Event.(*event.ControllerEvent)
+		if !ok {
+			continue
+		}
+
+		m, ok := d.Decode(e)
+		if !ok {
+			continue
+		}
+		if n := len(tms); n > 0 && tms[n-1].Tick == te.Tick && sameTarget(tms[n-1].Message, m) {
+			tms[n-1].Message = m
+			continue
+		}
+
+		tms = append(tms, TimedMessage{Tick: te.Tick, Message: m})
+	}
+
+	return tms
+}
+
+func sameTarget(a, b Message) bool {
+	if a.Type != b.Type || a.Channel != b.Channel {
+		return false
+	}
+	switch a.Type {
+	case ControlChange:
+		return false
+	case ControlChange14:
+		return a.Control == b.Control
+	}
+
+	return a.Number == b.Number
+}
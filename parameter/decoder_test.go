@@ -0,0 +1,146 @@
+package parameter
+
+import (
+	"reflect"
+	"testing"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func newControllerEvents(t *testing.T, channel uint8, pairs ...uint8) []*event.ControllerEvent {
+	es := []*event.ControllerEvent{}
+
+	for i := 0; i < len(pairs); i += 2 {
+		e, err := event.NewControllerEvent(nil, channel, constant.Control(pairs[i]), pairs[i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		es = append(es, e)
+	}
+
+	return es
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	d := NewDecoder()
+	ms := []Message{}
+
+	for _, e := range newControllerEvents(t, 2, 101, 0, 100, 0, 6, 12, 38, 50, 96, 0, 7, 100, 39, 3, 64, 127) {
+		if m, ok := d.Decode(e); ok {
+			ms = append(ms, m)
+		}
+	}
+
+	expected := []Message{
+		{Type: RegisteredParameterChange, Channel: 2, Number: 0, Value: 12 << 7},
+		{Type: RegisteredParameterChange, Channel: 2, Number: 0, Value: 12<<7 | 50},
+		{Type: RegisteredParameterChange, Channel: 2, Number: 0, Value: 12<<7 | 51},
+		{Type: ControlChange14, Channel: 2, Control: constant.MainVolume, Value: 100 << 7},
+		{Type: ControlChange14, Channel: 2, Control: constant.MainVolume, Value: 100<<7 | 3},
+		{Type: ControlChange, Channel: 2, Control: constant.Hold1, Value: 127},
+	}
+
+	if len(expected) != len(ms) {
+		t.Fatalf("expected: %v messages actual: %v messages", len(expected), len(ms))
+	}
+	for i, e := range expected {
+		if a := ms[i]; e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+}
+
+func TestDecoder_Decode_zeroValue(t *testing.T) {
+	var d Decoder
+
+	var actual Message
+
+	// No parameter is selected as NewDecoder.
+	if m, ok := d.Decode(newControllerEvents(t, 0, 6, 2)[0]); ok {
+		t.Fatalf("data entry without parameter must be ignored (%v)", m)
+	}
+	for _, e := range newControllerEvents(t, 0, 101, 0, 100, 0, 6, 2) {
+		actual, _ = d.Decode(e)
+	}
+
+	if expected := (Message{Type: RegisteredParameterChange, Value: 2 << 7}); expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestDecoder_Decode_null(t *testing.T) {
+	d := NewDecoder()
+
+	for _, e := range newControllerEvents(t, 0, 6, 12, 99, 1, 98, 8, 101, 127, 100, 127, 6, 12) {
+		if m, ok := d.Decode(e); ok {
+			t.Fatalf("data entry without parameter must be ignored (%v)", m)
+		}
+	}
+}
+
+func TestDecoder_Decode_coarseTuning(t *testing.T) {
+	d := NewDecoder()
+
+	var m Message
+	for _, e := range newControllerEvents(t, 0, 101, 0, 100, 2, 6, 64, 97, 0) {
+		m, _ = d.Decode(e)
+	}
+
+	actual, err := m.CoarseTuning()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != -1 {
+		t.Fatalf("expected: -1 actual: %v", actual)
+	}
+}
+
+func TestDecodeTrack(t *testing.T) {
+	tes := []midi.TimedEvent{}
+
+	for _, e := range newControllerEvents(t, 0, 99, 1, 98, 8, 6, 64, 38, 10) {
+		tes = append(tes, midi.TimedEvent{Tick: 0, Event: e})
+	}
+	for _, e := range newControllerEvents(t, 0, 6, 65) {
+		tes = append(tes, midi.TimedEvent{Tick: 480, Event: e})
+	}
+
+	tms := DecodeTrack(midi.NewTrackFromTimedEvents(tes))
+
+	expected := []TimedMessage{
+		{Tick: 0, Message: Message{Type: NonRegisteredParameterChange, Number: 1<<7 | 8, Value: 64<<7 | 10}},
+		{Tick: 480, Message: Message{Type: NonRegisteredParameterChange, Number: 1<<7 | 8, Value: 65 << 7}},
+	}
+
+	if len(expected) != len(tms) {
+		t.Fatalf("expected: %v messages actual: %v messages", len(expected), len(tms))
+	}
+	for i, e := range expected {
+		if a := tms[i]; e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+}
+
+func TestDecoder_Decode_resetAllControllers(t *testing.T) {
+	d := NewDecoder()
+	ms := []Message{}
+
+	for _, e := range newControllerEvents(t, 0, 101, 0, 100, 0, 1, 64, 121, 0, 33, 10, 6, 12) {
+		if m, ok := d.Decode(e); ok {
+			ms = append(ms, m)
+		}
+	}
+
+	expected := []Message{
+		{Type: ControlChange14, Channel: 0, Control: constant.Modulation, Value: 64 << 7},
+		{Type: ControlChange, Channel: 0, Control: constant.ResetAllControllers, Value: 0},
+		{Type: ControlChange14, Channel: 0, Control: constant.Modulation, Value: 10},
+	}
+
+	if !reflect.DeepEqual(expected, ms) {
+		t.Fatalf("expected: %v actual: %v", expected, ms)
+	}
+}
@@ -0,0 +1,94 @@
+package parameter
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Encoder encodes messages into controller events.
+type Encoder struct {
+	// SelectNull appends RPN null after the parameter change, so that the following data entry doesn't change the parameter accidentally.
+	SelectNull bool
+
+	// OmitLSB omits the data entry LSB and the LSB of 14-bit controller.
+	OmitLSB bool
+}
+
+// Encode returns the controller events which transmit the message. The delta times of events are 0.
+// It returns error if the message is out of range instead of masking the value.
+func (enc *Encoder) Encode(m Message) ([]*event.ControllerEvent, error) {
+	type cc struct {
+		control constant.Control
+		value   uint8
+	}
+
+	ccs := []cc{}
+
+	switch m.Type {
+	case ControlChange:
+		if m.Value > 0x7f {
+			return nil, fmt.Errorf("midi: maximum value of control change is 127 (0x7f)")
+		}
+
+		ccs = append(ccs, cc{m.Control, uint8(m.Value)})
+	case ControlChange14:
+		lsb, ok := m.Control.LSB()
+		if !ok {
+			return nil, fmt.Errorf("midi: %v is not the MSB of 14-bit controller", m.Control)
+		}
+		if m.Value > 0x3fff {
+			return nil, fmt.Errorf("midi: maximum value is 16383 (0x3fff)")
+		}
+
+		ccs = append(ccs, cc{m.Control, m.MSB()})
+
+		if !enc.OmitLSB {
+			ccs = append(ccs, cc{lsb, m.LSB()})
+		}
+	case RegisteredParameterChange, NonRegisteredParameterChange:
+		if m.Number > 0x3fff {
+			return nil, fmt.Errorf("midi: maximum parameter number is 16383 (0x3fff)")
+		}
+		if m.Value > 0x3fff {
+			return nil, fmt.Errorf("midi: maximum value is 16383 (0x3fff)")
+		}
+
+		msb, lsb := constant.RegisteredParameterNumberMSB, constant.RegisteredParameterNumberLSB
+		if m.Type == NonRegisteredParameterChange {
+			msb, lsb = constant.NonRegisteredParameterNumberMSB, constant.NonRegisteredParameterNumberLSB
+		}
+
+		ccs = append(ccs, cc{msb, uint8(m.Number >> 7 & 0x7f)})
+		ccs = append(ccs, cc{lsb, uint8(m.Number & 0x7f)})
+		ccs = append(ccs, cc{constant.DataEntry, m.MSB()})
+
+		if !enc.OmitLSB {
+			ccs = append(ccs, cc{constant.DataEntryLSB, m.LSB()})
+		}
+		if enc.SelectNull {
+			ccs = append(ccs, cc{constant.RegisteredParameterNumberMSB, 0x7f})
+			ccs = append(ccs, cc{constant.RegisteredParameterNumberLSB, 0x7f})
+		}
+	default:
+		return nil, fmt.Errorf("midi: unknown message type %v", m.Type)
+	}
+
+	es := make([]*event.ControllerEvent, len(ccs))
+
+	for i, c := range ccs {
+		e, err := event.NewControllerEvent(nil, m.Channel, c.control, c.value)
+		if err != nil {
+			return nil, err
+		}
+		es[i] = e
+	}
+
+	return es, nil
+}
+
+// Encode returns the controller events which transmit the message with the default encoder.
+func Encode(m Message) ([]*event.ControllerEvent, error) {
+	return (&Encoder{}).Encode(m)
+}
@@ -0,0 +1,136 @@
+package parameter
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+func TestEncode(t *testing.T) {
+	m, _ := NewPitchBendSensitivity(3, 12, 0)
+
+	es, err := Encode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []byte{0xb3, 101, 0, 0xb3, 100, 0, 0xb3, 6, 12, 0xb3, 38, 0}
+	actual := []byte{}
+
+	for _, e := range es {
+		actual = append(actual, e.Serialize()...)
+	}
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		if a := actual[i]; e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	enc := &Encoder{SelectNull: true, OmitLSB: true}
+	m, _ := NewNonRegisteredParameterChange(0, 0x0108, 0x2000)
+
+	es, err := enc.Encode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []constant.Control{
+		constant.NonRegisteredParameterNumberMSB,
+		constant.NonRegisteredParameterNumberLSB,
+		constant.DataEntry,
+		constant.RegisteredParameterNumberMSB,
+		constant.RegisteredParameterNumberLSB,
+	}
+
+	if len(expected) != len(es) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(es))
+	}
+	for i, e := range expected {
+		if a := es[i].Control(); e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+}
+
+func TestEncoder_Encode_table(t *testing.T) {
+	for _, c := range []struct {
+		encoder  Encoder
+		message  Message
+		expected []byte
+	}{
+		{Encoder{}, Message{Type: ControlChange, Channel: 1, Control: constant.Hold1, Value: 0x7f}, []byte{0xb1, 0x40, 0x7f}},
+		{Encoder{}, Message{Type: ControlChange14, Channel: 2, Control: constant.Modulation, Value: 0x3fff}, []byte{0xb2, 0x01, 0x7f, 0xb2, 0x21, 0x7f}},
+		{Encoder{OmitLSB: true}, Message{Type: ControlChange14, Control: constant.MainVolume, Value: 0x2001}, []byte{0xb0, 0x07, 0x40}},
+		{Encoder{}, Message{Type: RegisteredParameterChange, Number: 0x3fff, Value: 0x0081}, []byte{0xb0, 101, 0x7f, 0xb0, 100, 0x7f, 0xb0, 6, 0x01, 0xb0, 38, 0x01}},
+		{Encoder{OmitLSB: true, SelectNull: true}, Message{Type: RegisteredParameterChange, Number: 0x0002, Value: 0x2000}, []byte{0xb0, 101, 0x00, 0xb0, 100, 0x02, 0xb0, 6, 0x40, 0xb0, 101, 0x7f, 0xb0, 100, 0x7f}},
+		{Encoder{OmitLSB: true}, Message{Type: NonRegisteredParameterChange, Channel: 15, Number: 0x0108, Value: 0x0100}, []byte{0xbf, 99, 0x02, 0xbf, 98, 0x08, 0xbf, 6, 0x02}},
+	} {
+		es, err := c.encoder.Encode(c.message)
+		if err != nil {
+			t.Fatalf("%v: %v", c.message, err)
+		}
+
+		actual := []byte{}
+
+		for _, e := range es {
+			actual = append(actual, e.Serialize()...)
+		}
+		if len(c.expected) != len(actual) {
+			t.Fatalf("%v: expected: %v actual: %v", c.message, c.expected, actual)
+		}
+		for i, e := range c.expected {
+			if a := actual[i]; e != a {
+				t.Fatalf("%v: expected: %v actual: %v", c.message, c.expected, actual)
+			}
+		}
+	}
+}
+
+func TestEncode_roundTrip(t *testing.T) {
+	rpn, _ := NewRegisteredParameterChange(1, constant.FineTuning, 0x1234)
+	nrpn, _ := NewNonRegisteredParameterChange(2, 0x0203, 0x0405)
+	cc14, _ := NewControlChange14(3, constant.Modulation, 0x1fff)
+	cc := Message{Type: ControlChange, Channel: 4, Control: constant.Hold1, Value: 127}
+
+	d := NewDecoder()
+
+	for _, expected := range []Message{rpn, nrpn, cc14, cc} {
+		es, err := Encode(expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var actual Message
+
+		for _, e := range es {
+			actual, _ = d.Decode(e)
+		}
+		if expected != actual {
+			t.Fatalf("expected: %v actual: %v", expected, actual)
+		}
+	}
+}
+
+func TestEncode_error(t *testing.T) {
+	for _, m := range []Message{
+		{Type: ControlChange, Control: constant.Hold1, Value: 0x80},
+		{Type: ControlChange14, Control: constant.ModulationLSB, Value: 0x1000},
+		{Type: ControlChange14, Control: constant.Hold1, Value: 0x1000},
+		{Type: ControlChange14, Control: constant.Modulation, Value: 0x4000},
+		{Type: RegisteredParameterChange, Number: 0x4000, Value: 0x1000},
+		{Type: RegisteredParameterChange, Number: 0x0000, Value: 0x4000},
+		{Type: NonRegisteredParameterChange, Number: 0x4000, Value: 0x1000},
+		{Type: NonRegisteredParameterChange, Number: 0x0108, Value: 0x4000},
+		{Type: Type(0xff), Control: constant.Hold1, Value: 0x10},
+	} {
+		if es, err := Encode(m); err == nil {
+			t.Fatalf("expected error for %v actual: %v", m, es)
+		}
+	}
+}
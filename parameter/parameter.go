@@ -0,0 +1,173 @@
+//go:generate stringer -type=Type -output=type_string.go
+
+/*
+Package parameter decodes sequences of controller events into 14-bit controller values
+and registered (RPN) and non-registered (NRPN) parameter changes, and encodes them back.
+*/
+package parameter
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+// Type represents type of message.
+type Type uint8
+
+const (
+	// ControlChange is a 7-bit controller such as hold pedal.
+	ControlChange Type = iota
+
+	// ControlChange14 is a 14-bit controller which consists of MSB (0 to 31) and LSB (32 to 63).
+	ControlChange14
+
+	// RegisteredParameterChange is a change of registered parameter (RPN).
+	RegisteredParameterChange
+
+	// NonRegisteredParameterChange is a change of non-registered parameter (NRPN).
+	NonRegisteredParameterChange
+)
+
+// Message represents a controller value or a parameter change decoded from controller events.
+type Message struct {
+	Type    Type
+	Channel uint8
+
+	// Control is the controller for ControlChange and ControlChange14. It is the MSB for ControlChange14.
+	Control constant.Control
+
+	// Number is the parameter number for RegisteredParameterChange and NonRegisteredParameterChange.
+	Number uint16
+
+	// Value is 7-bit for ControlChange and 14-bit for the others.
+	Value uint16
+}
+
+// MSB returns the upper 7 bits of 14-bit value.
+func (m Message) MSB() uint8 {
+	if m.Type == ControlChange {
+		return uint8(m.Value & 0x7f)
+	}
+	return uint8(m.Value >> 7 & 0x7f)
+}
+
+// LSB returns the lower 7 bits of 14-bit value.
+func (m Message) LSB() uint8 {
+	if m.Type == ControlChange {
+		return 0
+	}
+	return uint8(m.Value & 0x7f)
+}
+
+// IsRegistered reports whether the message changes the registered parameter.
+func (m Message) IsRegistered(number constant.RegisteredParameter) bool {
+	return m.Type == RegisteredParameterChange && m.Number == uint16(number)
+}
+
+// PitchBendSensitivity returns the pitch bend sensitivity in semitones.
+// The MSB is semitones and the LSB is cents.
+func (m Message) PitchBendSensitivity() (float64, error) {
+	if !m.IsRegistered(constant.PitchBendSensitivity) {
+		return 0, fmt.Errorf("midi: message is not pitch bend sensitivity")
+	}
+	return float64(m.MSB()) + float64(m.LSB())/100, nil
+}
+
+// FineTuning returns the fine tuning in cents. The range is -100 to +100 cents.
+func (m Message) FineTuning() (float64, error) {
+	if !m.IsRegistered(constant.FineTuning) {
+		return 0, fmt.Errorf("midi: message is not fine tuning")
+	}
+	return (float64(m.Value) - 0x2000) * 100 / 0x2000, nil
+}
+
+// CoarseTuning returns the coarse tuning in semitones. The range is -64 to +63 semitones.
+func (m Message) CoarseTuning() (int, error) {
+	if !m.IsRegistered(constant.CoarseTuning) {
+		return 0, fmt.Errorf("midi: message is not coarse tuning")
+	}
+	return int(m.MSB()) - 0x40, nil
+}
+
+// ModulationDepthRange returns the modulation depth range in cents.
+// The MSB is semitones and the LSB is 100/128 cents.
+func (m Message) ModulationDepthRange() (float64, error) {
+	if !m.IsRegistered(constant.ModulationDepthRange) {
+		return 0, fmt.Errorf("midi: message is not modulation depth range")
+	}
+	return float64(m.MSB())*100 + float64(m.LSB())*100/128, nil
+}
+
+// String returns string representation of message.
+func (m Message) String() string {
+	switch m.Type {
+	case RegisteredParameterChange:
+		return fmt.Sprintf("&Message{type: %v, channel: %v, number: %v, value: %v}", m.Type, m.Channel, constant.RegisteredParameter(m.Number), m.Value)
+	case NonRegisteredParameterChange:
+		return fmt.Sprintf("&Message{type: %v, channel: %v, number: %v, value: %v}", m.Type, m.Channel, m.Number, m.Value)
+	default:
+		return fmt.Sprintf("&Message{type: %v, channel: %v, control: %v, value: %v}", m.Type, m.Channel, m.Control, m.Value)
+	}
+}
+
+// NewRegisteredParameterChange returns Message which changes the registered parameter.
+func NewRegisteredParameterChange(channel uint8, number constant.RegisteredParameter, value uint16) (Message, error) {
+	return newParameterChange(RegisteredParameterChange, channel, uint16(number), value)
+}
+
+// NewNonRegisteredParameterChange returns Message which changes the non-registered parameter.
+func NewNonRegisteredParameterChange(channel uint8, number, value uint16) (Message, error) {
+	return newParameterChange(NonRegisteredParameterChange, channel, number, value)
+}
+
+// NewPitchBendSensitivity returns Message which sets the pitch bend sensitivity in semitones and cents.
+func NewPitchBendSensitivity(channel, semitones, cents uint8) (Message, error) {
+	if semitones > 0x7f || cents > 99 {
+		return Message{}, fmt.Errorf("midi: pitch bend sensitivity must be 0 to 127 semitones and 0 to 99 cents")
+	}
+	return NewRegisteredParameterChange(channel, constant.PitchBendSensitivity, uint16(semitones)<<7|uint16(cents))
+}
+
+// NewControlChange14 returns Message which sets the 14-bit controller. The control must be the MSB (0 to 31).
+func NewControlChange14(channel uint8, control constant.Control, value uint16) (Message, error) {
+	if channel > 0x0f {
+		return Message{}, fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+	}
+	if !control.IsMSB() {
+		return Message{}, fmt.Errorf("midi: %v is not the MSB of 14-bit controller", control)
+	}
+	if value > 0x3fff {
+		return Message{}, fmt.Errorf("midi: maximum value is 16383 (0x3fff)")
+	}
+
+	m := Message{
+		Type:    ControlChange14,
+		Channel: channel,
+		Control: control,
+		Value:   value,
+	}
+
+	return m, nil
+}
+
+func newParameterChange(t Type, channel uint8, number, value uint16) (Message, error) {
+	if channel > 0x0f {
+		return Message{}, fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+	}
+	if number > 0x3fff {
+		return Message{}, fmt.Errorf("midi: maximum parameter number is 16383 (0x3fff)")
+	}
+	if value > 0x3fff {
+		return Message{}, fmt.Errorf("midi: maximum value is 16383 (0x3fff)")
+	}
+
+	m := Message{
+		Type:    t,
+		Channel: channel,
+		Number:  number,
+		Value:   value,
+	}
+
+	return m, nil
+}
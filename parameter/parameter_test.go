@@ -0,0 +1,105 @@
+package parameter
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+func TestMessage_PitchBendSensitivity(t *testing.T) {
+	m, err := NewPitchBendSensitivity(0, 12, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := m.PitchBendSensitivity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != 12.5 {
+		t.Fatalf("expected: 12.5 actual: %v", actual)
+	}
+
+	if _, err := m.FineTuning(); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewPitchBendSensitivity(0, 12, 100); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
+
+func TestMessage_FineTuning(t *testing.T) {
+	m, _ := NewRegisteredParameterChange(0, constant.FineTuning, 0x3000)
+
+	actual, err := m.FineTuning()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != 50 {
+		t.Fatalf("expected: 50 actual: %v", actual)
+	}
+}
+
+func TestMessage_CoarseTuning(t *testing.T) {
+	m, _ := NewRegisteredParameterChange(0, constant.CoarseTuning, 0x3e<<7)
+
+	actual, err := m.CoarseTuning()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != -2 {
+		t.Fatalf("expected: -2 actual: %v", actual)
+	}
+}
+
+func TestMessage_ModulationDepthRange(t *testing.T) {
+	m, _ := NewRegisteredParameterChange(0, constant.ModulationDepthRange, 1<<7|64)
+
+	actual, err := m.ModulationDepthRange()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != 150 {
+		t.Fatalf("expected: 150 actual: %v", actual)
+	}
+}
+
+func TestMessage_String(t *testing.T) {
+	m, _ := NewPitchBendSensitivity(1, 2, 0)
+
+	expected := "&Message{type: RegisteredParameterChange, channel: 1, number: PitchBendSensitivity, value: 256}"
+	actual := m.String()
+
+	if expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
+
+func TestNewControlChange14(t *testing.T) {
+	if _, err := NewControlChange14(0, constant.Hold1, 0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewControlChange14(0, constant.MainVolume, 0x4000); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+
+	m, err := NewControlChange14(0, constant.MainVolume, 0x3fff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MSB() != 0x7f || m.LSB() != 0x7f {
+		t.Fatalf("unexpected message %v", m)
+	}
+}
+
+func TestNewNonRegisteredParameterChange(t *testing.T) {
+	if _, err := NewNonRegisteredParameterChange(16, 0, 0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewNonRegisteredParameterChange(0, 0x4000, 0); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if _, err := NewNonRegisteredParameterChange(0, 0, 0x4000); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
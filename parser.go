@@ -14,6 +14,9 @@ type Parser struct {
 	data              []byte
 	position          int
 	previousEventType uint8
+	preserveEncoding  bool
+	zeroCopy          bool
+	registry          *event.Registry
 	logger            *log.Logger
 }
 
@@ -184,13 +187,17 @@ func (p *Parser) parseEvent() (event event.Event, err error) {
 	
 	p.debugln("start parsing delta time")
 
-	deltaTime, err := deltatime.Parse(p.data[p.position:])
+	deltaTime, err := deltatime.ParsePreserved(p.data[p.position:])
 	if err != nil {
 		return nil, err
 	}
 
-	p.position += len(deltaTime.Quantity().Value())
-	p.debugf("parsing delta time completed (%v)", deltaTime.Quantity().Uint32())
+	p.position += deltaTime.Quantity().Len()
+	p.debugf("parsing delta time completed (%v)", deltaTime.Uint32())
+
+	if !p.preserveEncoding {
+		deltaTime.Quantity().Normalize()
+	}
 
 	p.debugln("start parsing event type")
 
@@ -222,7 +229,7 @@ func (p *Parser) parseEvent() (event event.Event, err error) {
 		return nil, fmt.Errorf("Event is null")
 	}
 
-	event.DeltaTime().Quantity().SetValue(deltaTime.Quantity().Value())
+	*event.DeltaTime() = *deltaTime
 	event.SetRunningStatus(runningStatus)
 
 	return event, err
@@ -239,18 +246,39 @@ func (p *Parser) parseMetaEvent(eventType uint8) (e event.Event, err error) {
 
 	p.debugln("start parsing size of meta event")
 
-	q, err := quantity.Parse(p.data[p.position:])
+	q, err := quantity.ParsePreserved(p.data[p.position:])
 	if err != nil {
 		return nil, err
 	}
 
-	p.position += len(q.Value())
+	p.position += q.Len()
 	p.debugf("parsing size of meta event completed (%v)", q.Uint32())
 
 	sizeOfData := int(q.Uint32())
 	data := p.data[p.position : p.position+sizeOfData]
 
+	if !p.zeroCopy {
+		data = append([]byte{}, data...)
+	}
+	if p.registry != nil {
+		if v, ok := p.registry.Decode(metaEventType, data); ok {
+			p.position += sizeOfData
+			p.debugf("parsing event completed (event = %v)", v)
+
+			return v, nil
+		}
+	}
+
 	switch metaEventType {
+	case constant.SequenceNumber:
+		// The number may be omitted, which is kept as AlienEvent to be serialized as it was read.
+		if len(data) != 2 {
+			e = alienEvent(metaEventType, data)
+			break
+		}
+		v := &event.SequenceNumberEvent{}
+		v.SetNumber(uint16(data[0])<<8 | uint16(data[1]))
+		e = v
 	case constant.Text:
 		v := &event.TextEvent{}
 		v.SetText(data)
@@ -279,6 +307,21 @@ func (p *Parser) parseMetaEvent(eventType uint8) (e event.Event, err error) {
 		v := &event.CuePointEvent{}
 		v.SetText(data)
 		e = v
+	case constant.ProgramName:
+		v := &event.ProgramNameEvent{}
+		v.SetText(data)
+		e = v
+	case constant.DeviceName:
+		v := &event.DeviceNameEvent{}
+		v.SetText(data)
+		e = v
+	case constant.XMFPatchTypePrefix:
+		v := &event.XMFPatchTypePrefixEvent{}
+		if len(data) != 1 || v.SetPatchType(data[0]) != nil {
+			e = alienEvent(metaEventType, data)
+			break
+		}
+		e = v
 	case constant.MIDIPortPrefix:
 		v := &event.MIDIPortPrefixEvent{}
 		v.SetPort(data[0])
@@ -296,7 +339,8 @@ func (p *Parser) parseMetaEvent(eventType uint8) (e event.Event, err error) {
 		e = v
 	case constant.SMPTEOffset:
 		v := &event.SMPTEOffsetEvent{}
-		v.SetHour(data[0])
+		v.SetFrameRate(constant.FrameRate(data[0] >> 5 & 0x03))
+		v.SetHour(data[0] & 0x1f)
 		v.SetMinute(data[1])
 		v.SetSecond(data[2])
 		v.SetFrame(data[3])
@@ -313,6 +357,7 @@ func (p *Parser) parseMetaEvent(eventType uint8) (e event.Event, err error) {
 		v := &event.KeySignatureEvent{}
 		v.SetKey(int8(data[0]))
 		v.SetScale(data[1])
+		e = v
 	case constant.SequencerSpecific:
 		v := &event.SequencerSpecificEvent{}
 		v.SetData(data)
@@ -320,10 +365,7 @@ func (p *Parser) parseMetaEvent(eventType uint8) (e event.Event, err error) {
 	case constant.EndOfTrack:
 		e = &event.EndOfTrackEvent{}
 	default:
-		v := &event.AlienEvent{}
-		v.SetMetaEventType(metaEventType)
-		v.SetData(data)
-		e = v
+		e = alienEvent(metaEventType, data)
 	}
 
 	p.position += sizeOfData
@@ -332,21 +374,34 @@ func (p *Parser) parseMetaEvent(eventType uint8) (e event.Event, err error) {
 	return e, nil
 }
 
+// alienEvent returns AlienEvent which holds the meta event as it was read.
+func alienEvent(metaEventType uint8, data []byte) event.Event {
+	v := &event.AlienEvent{}
+	v.SetMetaEventType(metaEventType)
+	v.SetData(data)
+
+	return v
+}
+
 // parseSystemExclusiveEvent parses
 func (p *Parser) parseSystemExclusiveEvent(eventType uint8) (e event.Event, err error) {
 	p.debugln("start parsing size of system exclusive event")
 
-	q, err := quantity.Parse(p.data[p.position:])
+	q, err := quantity.ParsePreserved(p.data[p.position:])
 	if err != nil {
 		return nil, err
 	}
 
-	p.position += len(q.Value())
+	p.position += q.Len()
 	p.debugf("parsing size of system exclusive event completed (%v)", q.Uint32())
 
 	sizeOfData := int(q.Uint32())
 	data := p.data[p.position : p.position+sizeOfData]
 
+	if !p.zeroCopy {
+		data = append([]byte{}, data...)
+	}
+
 	switch eventType {
 	case constant.SystemExclusive:
 		v := &event.SystemExclusiveEvent{}
@@ -355,6 +410,7 @@ func (p *Parser) parseSystemExclusiveEvent(eventType uint8) (e event.Event, err
 	case constant.DividedSystemExclusive:
 		v := &event.DividedSystemExclusiveEvent{}
 		v.SetData(data)
+		e = v
 	}
 
 	p.position += sizeOfData
@@ -409,8 +465,8 @@ func (p *Parser) parseMIDIControlEvent(eventType uint8) (e event.Event, err erro
 		v.SetVelocity(data[0])
 		e = v
 	case constant.PitchBend:
-		pitch := uint16(data[0]&0x7f) << 7
-		pitch += uint16(data[1] & 0x7f)
+		pitch := uint16(data[1]&0x7f) << 7
+		pitch += uint16(data[0] & 0x7f)
 		v := &event.PitchBendEvent{}
 		v.SetChannel(channel)
 		v.SetPitch(pitch)
@@ -423,6 +479,32 @@ func (p *Parser) parseMIDIControlEvent(eventType uint8) (e event.Event, err erro
 	return e, nil
 }
 
+// SetPreserveEncoding sets whether the non-minimal encoding of delta times, e.g. 0x80 0x00 for 0, is kept
+// so that the parsed data is serialized byte by byte as it was read. The default is false, which means
+// that delta times are encoded in the minimal form.
+func (p *Parser) SetPreserveEncoding(preserve bool) *Parser {
+	p.preserveEncoding = preserve
+
+	return p
+}
+
+// SetZeroCopy sets whether the text and data of parsed events refer to the input buffer instead of their own copy.
+// Zero-copy parsing saves allocations, but modifying the input buffer changes the events and vice versa.
+// The default is false.
+func (p *Parser) SetZeroCopy(zeroCopy bool) *Parser {
+	p.zeroCopy = zeroCopy
+
+	return p
+}
+
+// SetRegistry sets registry of codecs which decode the vendor meta events and sequencer specific events into CustomEvent.
+// The events which are not decoded by the registry are parsed as AlienEvent or SequencerSpecificEvent.
+func (p *Parser) SetRegistry(registry *event.Registry) *Parser {
+	p.registry = registry
+
+	return p
+}
+
 // SetLogger sets logger.
 func (p *Parser) SetLogger(logger *log.Logger) *Parser {
 	p.logger = logger
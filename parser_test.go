@@ -1,10 +1,13 @@
 package midi
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"reflect"
 	"testing"
 
+	"github.com/moutend/go-midi/constant"
 	"github.com/moutend/go-midi/event"
 )
 
@@ -133,3 +136,149 @@ func TestParser_parseEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestParser_parseEvent_keySignature(t *testing.T) {
+	stream := []byte{0x00, 0xff, 0x59, 0x02, 0xfd, 0x01}
+	e, err := NewParser(stream).parseEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, ok := e.(*event.KeySignatureEvent)
+	if !ok {
+		t.Fatalf("type of event must be KeySignatureEvent")
+	}
+	if v.Key() != -3 || v.Scale() != 1 {
+		t.Fatalf("expected: key = -3, scale = 1 actual: key = %v, scale = %v", v.Key(), v.Scale())
+	}
+}
+
+func TestParser_parseEvent_smpteOffset(t *testing.T) {
+	stream := []byte{0x00, 0xff, 0x54, 0x05, 0x41, 0x02, 0x03, 0x04, 0x05}
+	e, err := NewParser(stream).parseEvent()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, ok := e.(*event.SMPTEOffsetEvent)
+	if !ok {
+		t.Fatalf("type of event must be SMPTEOffsetEvent")
+	}
+	if v.FrameRate() != constant.FPS30Drop || v.Hour() != 1 {
+		t.Fatalf("expected: frame rate = %v, hour = 1 actual: frame rate = %v, hour = %v", constant.FPS30Drop, v.FrameRate(), v.Hour())
+	}
+	if !reflect.DeepEqual(stream[1:], e.Serialize()) {
+		t.Fatalf("expected: % x actual: % x", stream[1:], e.Serialize())
+	}
+}
+
+func TestParser_SetPreserveEncoding(t *testing.T) {
+	stream := []byte{0x80, 0x81, 0x00, 0x90, 0x3c, 0x64}
+
+	for _, preserve := range []bool{false, true} {
+		e, err := NewParser(stream).SetPreserveEncoding(preserve).parseEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.DeltaTime().Uint32() != 0x80 {
+			t.Fatalf("expected: 128 actual: %v", e.DeltaTime().Uint32())
+		}
+
+		expected := []byte{0x81, 0x00}
+		if preserve {
+			expected = stream[:3]
+		}
+
+		actual := e.DeltaTime().Quantity().Value()
+
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected: % x actual: % x (preserve: %v)", expected, actual, preserve)
+		}
+	}
+}
+
+func TestParser_SetZeroCopy(t *testing.T) {
+	for _, zeroCopy := range []bool{false, true} {
+		stream := []byte{0x00, 0xff, 0x01, 0x04, 0x74, 0x65, 0x78, 0x74}
+
+		e, err := NewParser(stream).SetZeroCopy(zeroCopy).parseEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stream[4] = 'n'
+
+		expected := "text"
+		if zeroCopy {
+			expected = "next"
+		}
+
+		actual := string(e.(*event.TextEvent).Text())
+
+		if expected != actual {
+			t.Fatalf("expected: %v actual: %v (zeroCopy: %v)", expected, actual, zeroCopy)
+		}
+	}
+}
+
+func TestParser_SetRegistry(t *testing.T) {
+	registry := event.NewRegistry()
+	registry.RegisterSequencerSpecific([]byte{0x41}, textCodec{})
+
+	for _, c := range []struct {
+		stream []byte
+		custom bool
+	}{
+		{[]byte{0x00, 0xff, 0x7f, 0x03, 0x41, 0x68, 0x69}, true},
+		{[]byte{0x00, 0xff, 0x7f, 0x03, 0x43, 0x68, 0x69}, false},
+	} {
+		e, err := NewParser(c.stream).SetRegistry(registry).parseEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, custom := e.(*event.CustomEvent)
+
+		if c.custom != custom {
+			t.Fatalf("expected: %v actual: %v (event: %v)", c.custom, custom, e)
+		}
+		if !reflect.DeepEqual(c.stream[1:], e.Serialize()) {
+			t.Fatalf("expected: % x actual: % x", c.stream[1:], e.Serialize())
+		}
+	}
+}
+
+type textCodec struct{}
+
+func (textCodec) Decode(data []byte) (interface{}, error) {
+	return string(data), nil
+}
+
+func (textCodec) Encode(value interface{}) ([]byte, error) {
+	return []byte(value.(string)), nil
+}
+
+func TestParser_parseEvent_registeredMeta(t *testing.T) {
+	for _, c := range []struct {
+		stream   []byte
+		expected string
+	}{
+		{[]byte{0x00, 0xff, 0x00, 0x02, 0x00, 0x07}, "&SequenceNumberEvent{number: 7}"},
+		{[]byte{0x00, 0xff, 0x00, 0x00}, "&AlienEvent{metaEventType: 0x0, data: 0 bytes}"},
+		{[]byte{0x00, 0xff, 0x08, 0x05, 0x50, 0x69, 0x61, 0x6e, 0x6f}, "&ProgramNameEvent{text: \"Piano\"}"},
+		{[]byte{0x00, 0xff, 0x09, 0x04, 0x53, 0x79, 0x6e, 0x31}, "&DeviceNameEvent{text: \"Syn1\"}"},
+		{[]byte{0x00, 0xff, 0x60, 0x01, 0x02}, "&XMFPatchTypePrefixEvent{patchType: 2}"},
+		{[]byte{0x00, 0xff, 0x60, 0x01, 0x09}, "&AlienEvent{metaEventType: 0x60, data: 1 bytes}"},
+	} {
+		e, err := NewParser(c.stream).parseEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := fmt.Sprint(e); c.expected != actual {
+			t.Fatalf("expected: %v actual: %v", c.expected, actual)
+		}
+		if !reflect.DeepEqual(c.stream[1:], e.Serialize()) {
+			t.Fatalf("expected: % x actual: % x", c.stream[1:], e.Serialize())
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/moutend/go-midi/arpeggio"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// Arpeggiator plays the held notes one by one at the interval. The note events are consumed and the other messages pass.
+// It starts at the first note on and stops when all notes are released. The order of notes is the one of
+// arpeggio.Arpeggiator.
+type Arpeggiator struct {
+	interval time.Duration
+	gate     float64
+	notes    *arpeggio.Arpeggiator
+
+	next  time.Time
+	sound *arpeggio.Note
+	off   time.Time
+}
+
+// Process holds and releases the notes. The first note on starts the arpeggio at the time of message.
+func (a *Arpeggiator) Process(m stream.Message, emit Emit) {
+	if v, ok := noteOn(m.Event); ok {
+		a.notes.Press(v.Channel(), v.Note(), v.Velocity())
+
+		if a.next.IsZero() {
+			a.next = m.Time
+		}
+		return
+	}
+	if note, channel, ok := noteOff(m.Event); ok {
+		a.notes.Release(channel, note)
+		return
+	}
+	if _, ok := m.Event.(*event.NoteAfterTouchEvent); ok {
+		return
+	}
+
+	emit(m)
+}
+
+// Advance plays the steps and releases the notes scheduled until now.
+func (a *Arpeggiator) Advance(now time.Time, emit Emit) {
+	for {
+		if a.sound != nil && !a.off.After(now) && (a.next.IsZero() || !a.off.After(a.next)) {
+			e, _ := event.NewNoteOffEvent(nil, a.sound.Channel, a.sound.Note, 0x00)
+			emit(stream.Message{Time: a.off, Event: e})
+			a.sound = nil
+			continue
+		}
+		if a.next.IsZero() || a.next.After(now) {
+			return
+		}
+
+		n, ok := a.notes.Next()
+		if !ok {
+			a.next = time.Time{}
+			continue
+		}
+
+		e, _ := event.NewNoteOnEvent(nil, n.Channel, n.Note, n.Velocity)
+		emit(stream.Message{Time: a.next, Event: e})
+
+		a.sound = &n
+		a.off = a.next.Add(time.Duration(float64(a.interval) * a.gate))
+		a.next = a.next.Add(a.interval)
+	}
+}
+
+// Flush releases the sounding note at its scheduled time.
+func (a *Arpeggiator) Flush(emit Emit) {
+	if a.sound == nil {
+		return
+	}
+
+	e, _ := event.NewNoteOffEvent(nil, a.sound.Channel, a.sound.Note, 0x00)
+	emit(stream.Message{Time: a.off, Event: e})
+	a.sound = nil
+}
+
+// Reset forgets the held notes and stops the arpeggio. The sounding note is released by Flush, or by Advance
+// at its scheduled time if it's not flushed.
+func (a *Arpeggiator) Reset() {
+	a.notes.Reset()
+	a.next = time.Time{}
+}
+
+// SetPattern sets pattern. The default is arpeggio.Up.
+func (a *Arpeggiator) SetPattern(pattern arpeggio.Pattern) *Arpeggiator {
+	a.notes.SetPattern(pattern)
+
+	return a
+}
+
+// SetGate sets the length of notes relative to the interval, which is greater than 0 and less than or equal to 1.
+// The default is 0.5.
+func (a *Arpeggiator) SetGate(gate float64) error {
+	if gate <= 0 || gate > 1 {
+		return fmt.Errorf("midi: gate must be greater than 0 and less than or equal to 1 (%v)", gate)
+	}
+
+	a.gate = gate
+
+	return nil
+}
+
+// SetOctaves sets the number of octaves which the arpeggio spans. The default is 1.
+func (a *Arpeggiator) SetOctaves(octaves int) error {
+	return a.notes.SetOctaves(octaves)
+}
+
+// SetLatch sets latch. With latch, the released notes keep being played until a new chord is pressed.
+func (a *Arpeggiator) SetLatch(latch bool) *Arpeggiator {
+	a.notes.SetLatch(latch)
+
+	return a
+}
+
+// NewArpeggiator returns Arpeggiator which plays a note at the interval.
+func NewArpeggiator(interval time.Duration) (*Arpeggiator, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("midi: interval of arpeggiator must be greater than 0 (%v)", interval)
+	}
+
+	a := &Arpeggiator{
+		interval: interval,
+		gate:     0.5,
+		notes:    arpeggio.NewArpeggiator(),
+	}
+
+	return a, nil
+}
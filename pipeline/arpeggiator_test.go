@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/arpeggio"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+func TestArpeggiator(t *testing.T) {
+	a, err := NewArpeggiator(100 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := New(a)
+
+	var actual []stream.Message
+
+	actual = append(actual, p.Process(stream.Message{Time: start, Event: noteOnEvent(0, constant.E3, 0x40)})...)
+	actual = append(actual, p.Process(stream.Message{Time: start, Event: noteOnEvent(0, constant.C3, 0x50)})...)
+	actual = append(actual, p.Advance(start.Add(250*time.Millisecond))...)
+	actual = append(actual, p.Process(stream.Message{Time: start.Add(260 * time.Millisecond), Event: noteOffEvent(0, constant.C3)})...)
+	actual = append(actual, p.Process(stream.Message{Time: start.Add(270 * time.Millisecond), Event: noteOffEvent(0, constant.E3)})...)
+	actual = append(actual, p.Advance(start.Add(time.Second))...)
+
+	expected := []struct {
+		at    time.Duration
+		event event.Event
+	}{
+		{0, noteOnEvent(0, constant.C3, 0x50)},
+		{50, noteOffEvent(0, constant.C3)},
+		{100, noteOnEvent(0, constant.E3, 0x40)},
+		{150, noteOffEvent(0, constant.E3)},
+		{200, noteOnEvent(0, constant.C3, 0x50)},
+		{250, noteOffEvent(0, constant.C3)},
+	}
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v messages actual: %v", len(expected), actual)
+	}
+	for i, e := range expected {
+		if at := start.Add(e.at * time.Millisecond); !at.Equal(actual[i].Time) || !e.event.Equal(actual[i].Event) {
+			t.Fatalf("[%v] expected: %v %v actual: %v %v", i, at, e.event, actual[i].Time, actual[i].Event)
+		}
+	}
+}
+
+func TestArpeggiator_Flush(t *testing.T) {
+	a, _ := NewArpeggiator(100 * time.Millisecond)
+	p := New(NewTranspose(12), a)
+
+	p.Process(stream.Message{Time: start, Event: noteOnEvent(0, constant.C3, 0x40)})
+	p.Advance(start.Add(10 * time.Millisecond))
+
+	// The note sounding is released at its scheduled time by reset, and the arpeggio stops.
+	actual := p.Reset()
+
+	expectEvents(t, []event.Event{noteOffEvent(0, constant.C4)}, actual)
+
+	if expected := start.Add(50 * time.Millisecond); !actual[0].Time.Equal(expected) {
+		t.Fatalf("expected: %v actual: %v", expected, actual[0].Time)
+	}
+	if ms := p.Advance(start.Add(time.Second)); len(ms) != 0 {
+		t.Fatalf("expected: no messages actual: %v", ms)
+	}
+}
+
+func TestArpeggiator_SetLatch(t *testing.T) {
+	p, _ := Parse([]byte(`{"type": "arpeggiator", "interval": "100ms", "pattern": "random", "latch": true}`))
+
+	var actual []stream.Message
+
+	actual = append(actual, p.Process(stream.Message{Time: start, Event: noteOnEvent(0, constant.C3, 0x40)})...)
+	actual = append(actual, p.Process(stream.Message{Time: start.Add(10 * time.Millisecond), Event: noteOffEvent(0, constant.C3)})...)
+	actual = append(actual, p.Advance(start.Add(200*time.Millisecond))...)
+
+	// The released note keeps being played.
+	expectEvents(t, []event.Event{
+		noteOnEvent(0, constant.C3, 0x40),
+		noteOffEvent(0, constant.C3),
+		noteOnEvent(0, constant.C3, 0x40),
+		noteOffEvent(0, constant.C3),
+		noteOnEvent(0, constant.C3, 0x40),
+	}, actual)
+}
+
+func TestArpeggiator_sequence(t *testing.T) {
+	for _, c := range []struct {
+		pattern  arpeggio.Pattern
+		octaves  int
+		expected []constant.Note
+	}{
+		{arpeggio.Up, 1, []constant.Note{constant.C3, constant.E3, constant.G3}},
+		{arpeggio.Down, 1, []constant.Note{constant.G3, constant.E3, constant.C3}},
+		{arpeggio.UpDown, 1, []constant.Note{constant.C3, constant.E3, constant.G3, constant.E3}},
+		{arpeggio.AsPlayed, 1, []constant.Note{constant.E3, constant.C3, constant.G3}},
+		{arpeggio.Up, 2, []constant.Note{constant.C3, constant.E3, constant.G3, constant.C4, constant.E4, constant.G4}},
+	} {
+		a, _ := NewArpeggiator(time.Second)
+		a.SetPattern(c.pattern)
+		a.SetOctaves(c.octaves)
+
+		process(a, noteOnEvent(0, constant.E3, 0x40), noteOnEvent(0, constant.C3, 0x40), noteOnEvent(0, constant.G3, 0x40))
+
+		sequence := a.notes.Sequence()
+		if len(sequence) != len(c.expected) {
+			t.Fatalf("expected: %v actual: %v", c.expected, sequence)
+		}
+		for i, n := range sequence {
+			if n.Note != c.expected[i] {
+				t.Fatalf("expected: %v actual: %v", c.expected, sequence)
+			}
+		}
+	}
+}
+
+func TestArpeggiator_error(t *testing.T) {
+	if _, err := NewArpeggiator(0); err == nil {
+		t.Fatal("expected error")
+	}
+
+	a, _ := NewArpeggiator(time.Second)
+
+	if err := a.SetGate(0); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := a.SetOctaves(0); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// Chord plays a chord for each note, e.g. the intervals 0, 4 and 7 make a major triad on the played note.
+// The note shared by the overlapped chords is released when the last of them is released.
+type Chord struct {
+	intervals []int
+	counts    map[uint16]int
+}
+
+func (c *Chord) notes(note constant.Note) []constant.Note {
+	var notes []constant.Note
+
+	for _, interval := range c.intervals {
+		if n := int(note) + interval; n >= 0 && n <= 0x7f {
+			notes = append(notes, constant.Note(n))
+		}
+	}
+
+	return notes
+}
+
+// Process emits the notes of chord for note on and note off. The other messages pass.
+func (c *Chord) Process(m stream.Message, emit Emit) {
+	if v, ok := noteOn(m.Event); ok {
+		for _, note := range c.notes(v.Note()) {
+			c.counts[uint16(v.Channel())<<7|uint16(note)]++
+
+			e, _ := event.NewNoteOnEvent(nil, v.Channel(), note, v.Velocity())
+			emit(stream.Message{Time: m.Time, Event: e})
+		}
+		return
+	}
+
+	note, channel, ok := noteOff(m.Event)
+	if !ok {
+		emit(m)
+		return
+	}
+
+	for _, n := range c.notes(note) {
+		key := uint16(channel)<<7 | uint16(n)
+		if c.counts[key] == 0 {
+			continue
+		}
+
+		c.counts[key]--
+		if c.counts[key] > 0 {
+			continue
+		}
+
+		delete(c.counts, key)
+
+		e, _ := event.NewNoteOffEvent(nil, channel, n, 0x00)
+		emit(stream.Message{Time: m.Time, Event: e})
+	}
+}
+
+// Reset forgets the held notes.
+func (c *Chord) Reset() {
+	c.counts = map[uint16]int{}
+}
+
+// NewChord returns Chord of the intervals in semitones from the played note.
+func NewChord(intervals ...int) (*Chord, error) {
+	if len(intervals) == 0 {
+		return nil, fmt.Errorf("midi: chord must have at least one interval")
+	}
+	for _, interval := range intervals {
+		if interval < -0x7f || interval > 0x7f {
+			return nil, fmt.Errorf("midi: interval of chord must be -127 to 127 (%v)", interval)
+		}
+	}
+
+	c := &Chord{intervals: append([]int{}, intervals...)}
+	c.Reset()
+
+	return c, nil
+}
@@ -0,0 +1,41 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestChord(t *testing.T) {
+	c, err := NewChord(0, 4, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// C major and E major share E3, which is released with the last chord.
+	actual := process(c,
+		noteOnEvent(0, constant.C3, 0x40),
+		noteOnEvent(0, constant.E3, 0x50),
+		noteOffEvent(0, constant.C3),
+		noteOnEvent(0, constant.E3, 0x00),
+	)
+
+	expectEvents(t, []event.Event{
+		noteOnEvent(0, constant.C3, 0x40),
+		noteOnEvent(0, constant.E3, 0x40),
+		noteOnEvent(0, constant.G3, 0x40),
+		noteOnEvent(0, constant.E3, 0x50),
+		noteOnEvent(0, constant.Ab3, 0x50),
+		noteOnEvent(0, constant.B3, 0x50),
+		noteOffEvent(0, constant.C3),
+		noteOffEvent(0, constant.G3),
+		noteOffEvent(0, constant.E3),
+		noteOffEvent(0, constant.Ab3),
+		noteOffEvent(0, constant.B3),
+	}, actual)
+
+	if _, err := NewChord(); err == nil {
+		t.Fatal("expected error")
+	}
+}
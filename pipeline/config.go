@@ -0,0 +1,184 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/moutend/go-midi/arpeggio"
+	"github.com/moutend/go-midi/constant"
+)
+
+// Config is the declarative configuration of node. Type selects the node and the other fields are its parameters.
+// The zero parameters mean the defaults of node. For example, the following JSON splits the keyboard at C3 into
+// channel 0 and channel 1, and plays the major triad on the upper zone.
+//
+//	{"type": "parallel", "nodes": [
+//	  {"type": "chain", "nodes": [{"type": "keyRange", "low": 0, "high": 59}, {"type": "rechannel", "channel": 0}]},
+//	  {"type": "chain", "nodes": [{"type": "keyRange", "low": 60, "high": 127}, {"type": "rechannel", "channel": 1},
+//	    {"type": "chord", "intervals": [0, 4, 7]}]}
+//	]}
+type Config struct {
+	// Type is one of "chain", "parallel", "channelFilter", "keyRange", "rechannel", "transpose",
+	// "velocityCurve", "controllerMap", "chord" and "arpeggiator".
+	Type string `json:"type"`
+
+	// Nodes is the nodes of chain and parallel.
+	Nodes []Config `json:"nodes,omitempty"`
+
+	// Channels is the channels of channel filter, and Channel is the channel of rechannel.
+	Channels []uint8 `json:"channels,omitempty"`
+	Channel  uint8   `json:"channel,omitempty"`
+
+	// Low and High is the range of key range. The default of High is 127.
+	Low  uint8 `json:"low,omitempty"`
+	High uint8 `json:"high,omitempty"`
+
+	// Semitones is the semitones of transpose.
+	Semitones int `json:"semitones,omitempty"`
+
+	// Gamma, Min and Max is the parameters of velocity curve. The defaults are 1, 1 and 127.
+	Gamma float64 `json:"gamma,omitempty"`
+	Min   uint8   `json:"min,omitempty"`
+	Max   uint8   `json:"max,omitempty"`
+
+	// Map is the pairs of control numbers of controller map.
+	Map []ControllerMapping `json:"map,omitempty"`
+
+	// Intervals is the intervals of chord.
+	Intervals []int `json:"intervals,omitempty"`
+
+	// Interval, Pattern, Gate, Octaves and Latch is the parameters of arpeggiator. The interval is the duration
+	// such as "125ms", and the pattern is one of "up", "down", "updown", "random" and "played".
+	Interval string  `json:"interval,omitempty"`
+	Pattern  string  `json:"pattern,omitempty"`
+	Gate     float64 `json:"gate,omitempty"`
+	Octaves  int     `json:"octaves,omitempty"`
+	Latch    bool    `json:"latch,omitempty"`
+}
+
+// ControllerMapping is a pair of control numbers of controller map.
+type ControllerMapping struct {
+	From uint8 `json:"from"`
+	To   uint8 `json:"to"`
+}
+
+// patterns is the names of arpeggio patterns in configuration.
+var patterns = map[string]arpeggio.Pattern{
+	"":       arpeggio.Up,
+	"up":     arpeggio.Up,
+	"down":   arpeggio.Down,
+	"updown": arpeggio.UpDown,
+	"random": arpeggio.Random,
+	"played": arpeggio.AsPlayed,
+}
+
+// Node returns the node of configuration.
+func (c *Config) Node() (Node, error) {
+	switch c.Type {
+	case "chain", "parallel":
+		nodes := make([]Node, len(c.Nodes))
+
+		for i := range c.Nodes {
+			n, err := c.Nodes[i].Node()
+			if err != nil {
+				return nil, err
+			}
+			nodes[i] = n
+		}
+		if c.Type == "chain" {
+			return NewChain(nodes...), nil
+		}
+		return NewParallel(nodes...), nil
+	case "channelFilter":
+		return NewChannelFilter(c.Channels...)
+	case "keyRange":
+		high := c.High
+		if high == 0 {
+			high = 0x7f
+		}
+		return NewKeyRange(constant.Note(c.Low), constant.Note(high))
+	case "rechannel":
+		return NewRechannel(c.Channel)
+	case "transpose":
+		return NewTranspose(c.Semitones), nil
+	case "velocityCurve":
+		gamma, min, max := c.Gamma, c.Min, c.Max
+		if gamma == 0 {
+			gamma = 1
+		}
+		if min == 0 {
+			min = 1
+		}
+		if max == 0 {
+			max = 0x7f
+		}
+		return NewVelocityCurve(gamma, min, max)
+	case "controllerMap":
+		m := NewControllerMap()
+		for _, mapping := range c.Map {
+			if err := m.Map(constant.Control(mapping.From), constant.Control(mapping.To)); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case "chord":
+		return NewChord(c.Intervals...)
+	case "arpeggiator":
+		return c.arpeggiator()
+	}
+
+	return nil, fmt.Errorf("midi: unknown type of node %q", c.Type)
+}
+
+func (c *Config) arpeggiator() (*Arpeggiator, error) {
+	interval, err := time.ParseDuration(c.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern, ok := patterns[c.Pattern]
+	if !ok {
+		return nil, fmt.Errorf("midi: unknown pattern of arpeggiator %q", c.Pattern)
+	}
+
+	a, err := NewArpeggiator(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	a.SetPattern(pattern).SetLatch(c.Latch)
+
+	if c.Gate != 0 {
+		if err := a.SetGate(c.Gate); err != nil {
+			return nil, err
+		}
+	}
+	if c.Octaves != 0 {
+		if err := a.SetOctaves(c.Octaves); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// Parse parses JSON configuration of the root node and returns Pipeline. The unknown fields are rejected.
+func Parse(data []byte) (*Pipeline, error) {
+	var c Config
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&c); err != nil {
+		return nil, err
+	}
+
+	root, err := c.Node()
+	if err != nil {
+		return nil, err
+	}
+
+	return New(root), nil
+}
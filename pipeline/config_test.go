@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(`{"type": "chain", "nodes": [
+		{"type": "channelFilter", "channels": [0]},
+		{"type": "controllerMap", "map": [{"from": 1, "to": 11}]},
+		{"type": "velocityCurve", "min": 100},
+		{"type": "parallel", "nodes": [
+			{"type": "chain", "nodes": [{"type": "keyRange", "low": 0, "high": 59}, {"type": "transpose", "semitones": -12}]},
+			{"type": "chain", "nodes": [
+				{"type": "keyRange", "low": 60},
+				{"type": "rechannel", "channel": 1},
+				{"type": "chord", "intervals": [0, 7]}
+			]}
+		]},
+		{"type": "arpeggiator", "interval": "100ms", "pattern": "down", "gate": 0.25, "octaves": 2}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modulation, _ := event.NewControllerEvent(nil, 0, constant.Modulation, 0x40)
+	expression0, _ := event.NewControllerEvent(nil, 0, constant.Expression, 0x40)
+	expression1, _ := event.NewControllerEvent(nil, 1, constant.Expression, 0x40)
+
+	var actual []stream.Message
+
+	// The note of channel 1 is filtered. The upper zone plays C3 and G3 on channel 1, and the lower zone plays A1,
+	// then the arpeggiator plays them down in 2 octaves. The velocity 64 is mapped into 114.
+	for _, e := range []event.Event{noteOnEvent(1, constant.C3, 0x40), noteOnEvent(0, constant.C3, 0x40), noteOnEvent(0, constant.A2, 0x40), modulation} {
+		actual = append(actual, p.Process(stream.Message{Time: start, Event: e})...)
+	}
+
+	actual = append(actual, p.Advance(start.Add(100*time.Millisecond))...)
+
+	expectEvents(t, []event.Event{
+		expression0,
+		expression1,
+		noteOnEvent(1, constant.G4, 114),
+		noteOffEvent(1, constant.G4),
+		noteOnEvent(1, constant.C4, 114),
+	}, actual)
+
+	if expected := start.Add(25 * time.Millisecond); !actual[3].Time.Equal(expected) {
+		t.Fatalf("expected: %v actual: %v", expected, actual[3].Time)
+	}
+}
+
+func TestParse_error(t *testing.T) {
+	for _, data := range []string{
+		`{"type": "unknown"}`,
+		`{"type": "chain", "unknown": 1}`,
+		`{"type": "chain", "nodes": [{"type": "rechannel", "channel": 16}]}`,
+		`{"type": "parallel", "nodes": [{"type": "keyRange", "low": 60, "high": 59}]}`,
+		`{"type": "channelFilter", "channels": [16]}`,
+		`{"type": "velocityCurve", "gamma": -1}`,
+		`{"type": "controllerMap", "map": [{"from": 1, "to": 120}]}`,
+		`{"type": "chord"}`,
+		`{"type": "arpeggiator"}`,
+		`{"type": "arpeggiator", "interval": "1s", "pattern": "sideways"}`,
+		`{"type": "arpeggiator", "interval": "1s", "gate": 2}`,
+		`{"type": "arpeggiator", "interval": "1s", "octaves": -1}`,
+		`{"type": `,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Fatalf("expected error for %v", data)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// ChannelFilter passes the channel events of the selected channels. The other messages such as system exclusive pass.
+type ChannelFilter struct {
+	channels [16]bool
+}
+
+// Process emits the message unless it's the channel event of the other channels.
+func (f *ChannelFilter) Process(m stream.Message, emit Emit) {
+	if v, ok := m.Event.(event.ChannelEvent); ok && !f.channels[v.Channel()] {
+		return
+	}
+
+	emit(m)
+}
+
+// Reset does nothing because channel filter has no state.
+func (f *ChannelFilter) Reset() {}
+
+// NewChannelFilter returns ChannelFilter which passes the channels from 0 to 15.
+func NewChannelFilter(channels ...uint8) (*ChannelFilter, error) {
+	f := &ChannelFilter{}
+
+	for _, channel := range channels {
+		if channel > 0x0f {
+			return nil, fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+		}
+		f.channels[channel] = true
+	}
+
+	return f, nil
+}
+
+// KeyRange passes the note events in the range of keys, which makes a zone of keyboard split.
+// The other messages such as controllers pass, so that the sustain pedal works on all zones.
+type KeyRange struct {
+	low  constant.Note
+	high constant.Note
+}
+
+// Process emits the message unless it's the note event out of range.
+func (r *KeyRange) Process(m stream.Message, emit Emit) {
+	if v, ok := m.Event.(noteEvent); ok && (v.Note() < r.low || v.Note() > r.high) {
+		return
+	}
+
+	emit(m)
+}
+
+// Reset does nothing because key range has no state.
+func (r *KeyRange) Reset() {}
+
+// NewKeyRange returns KeyRange which passes the notes from low to high inclusive.
+func NewKeyRange(low, high constant.Note) (*KeyRange, error) {
+	if low > high || high > 0x7f {
+		return nil, fmt.Errorf("midi: invalid key range (%v to %v)", low, high)
+	}
+
+	return &KeyRange{low: low, high: high}, nil
+}
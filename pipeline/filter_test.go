@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestChannelFilter(t *testing.T) {
+	f, err := NewChannelFilter(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sysEx := &event.SystemExclusiveEvent{}
+	actual := process(f, noteOnEvent(0, constant.C3, 0x40), noteOnEvent(1, constant.C3, 0x40), sysEx, noteOffEvent(2, constant.C3))
+
+	expectEvents(t, []event.Event{noteOnEvent(1, constant.C3, 0x40), sysEx, noteOffEvent(2, constant.C3)}, actual)
+
+	if _, err := NewChannelFilter(16); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestKeyRange(t *testing.T) {
+	r, err := NewKeyRange(constant.C3, constant.E3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pitchBend, _ := event.NewPitchBendEvent(nil, 0, 0x2000)
+	afterTouch, _ := event.NewNoteAfterTouchEvent(nil, 0, constant.F3, 0x10)
+	actual := process(r, noteOnEvent(0, constant.B2, 0x40), noteOnEvent(0, constant.C3, 0x40), noteOffEvent(0, constant.E3), afterTouch, pitchBend)
+
+	expectEvents(t, []event.Event{noteOnEvent(0, constant.C3, 0x40), noteOffEvent(0, constant.E3), pitchBend}, actual)
+
+	for _, c := range [][2]constant.Note{{constant.E3, constant.C3}, {0, 0x80}} {
+		if _, err := NewKeyRange(c[0], c[1]); err == nil {
+			t.Fatalf("expected error for %v", c)
+		}
+	}
+}
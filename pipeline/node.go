@@ -0,0 +1,169 @@
+/*
+Package pipeline transforms live MIDI messages with a graph of processing nodes, e.g. channel filters, keyboard splits,
+transposers, velocity curves, controller remappers, chord generators and arpeggiators.
+
+Each node receives a message and emits zero or more messages. The nodes are composed in series with Chain and in
+parallel with Parallel, which makes keyboard splits and layers. The graph can be loaded from JSON configuration, and
+the same graph processes the events of MIDI data offline.
+*/
+package pipeline
+
+import (
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// Emit receives the messages emitted by node.
+type Emit func(m stream.Message)
+
+// Node processes messages. The node must not modify the event of received message, so that it clones the event
+// before changing it. The messages which the node doesn't handle are emitted as they are.
+type Node interface {
+	// Process processes the message and emits the resulting messages.
+	Process(m stream.Message, emit Emit)
+
+	// Reset clears the state of node, e.g. the held notes.
+	Reset()
+}
+
+// Scheduler is implemented by the nodes which emit messages by themselves as time goes by, e.g. Arpeggiator.
+// Advance emits the messages scheduled until now. Their time is the scheduled time, which may be before now.
+type Scheduler interface {
+	Advance(now time.Time, emit Emit)
+}
+
+// Flusher is implemented by the nodes which have messages to emit before reset, e.g. the note off of the note
+// sounding in Arpeggiator. Flush emits them at their scheduled time, so that reset doesn't leave stuck notes.
+type Flusher interface {
+	Flush(emit Emit)
+}
+
+// noteEvent is implemented by note off, note on and note after touch events.
+type noteEvent interface {
+	event.ChannelEvent
+
+	Note() constant.Note
+	SetNote(note constant.Note) error
+}
+
+// noteOn returns the note on event and true if the event is note on with non-zero velocity.
+func noteOn(e event.Event) (*event.NoteOnEvent, bool) {
+	v, ok := e.(*event.NoteOnEvent)
+
+	return v, ok && v.Velocity() > 0
+}
+
+// noteOff returns the note and the channel and true if the event is note off or note on with zero velocity.
+func noteOff(e event.Event) (constant.Note, uint8, bool) {
+	switch v := e.(type) {
+	case *event.NoteOffEvent:
+		return v.Note(), v.Channel(), true
+	case *event.NoteOnEvent:
+		if v.Velocity() == 0 {
+			return v.Note(), v.Channel(), true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Chain is the nodes connected in series. The messages emitted by a node are processed by the next node.
+type Chain struct {
+	nodes []Node
+}
+
+func (c *Chain) process(i int, m stream.Message, emit Emit) {
+	if i == len(c.nodes) {
+		emit(m)
+		return
+	}
+
+	c.nodes[i].Process(m, func(m stream.Message) {
+		c.process(i+1, m, emit)
+	})
+}
+
+// Process processes the message with the nodes in order.
+func (c *Chain) Process(m stream.Message, emit Emit) {
+	c.process(0, m, emit)
+}
+
+// Advance advances the schedulers in the chain. The messages emitted by a scheduler are processed by the next nodes.
+func (c *Chain) Advance(now time.Time, emit Emit) {
+	for i, n := range c.nodes {
+		if s, ok := n.(Scheduler); ok {
+			s.Advance(now, func(m stream.Message) {
+				c.process(i+1, m, emit)
+			})
+		}
+	}
+}
+
+// Flush flushes the nodes in the chain. The messages flushed by a node are processed by the next nodes.
+func (c *Chain) Flush(emit Emit) {
+	for i, n := range c.nodes {
+		if f, ok := n.(Flusher); ok {
+			f.Flush(func(m stream.Message) {
+				c.process(i+1, m, emit)
+			})
+		}
+	}
+}
+
+// Reset resets all nodes.
+func (c *Chain) Reset() {
+	for _, n := range c.nodes {
+		n.Reset()
+	}
+}
+
+// NewChain returns Chain of the nodes. The empty chain emits the messages as they are.
+func NewChain(nodes ...Node) *Chain {
+	return &Chain{nodes: nodes}
+}
+
+// Parallel is the nodes connected in parallel. Each node processes every message, e.g. the keyboard split is
+// the parallel nodes which begin with KeyRange, and the layer is the parallel nodes which receive all notes.
+type Parallel struct {
+	nodes []Node
+}
+
+// Process processes the message with all nodes and emits their messages in order of nodes.
+func (p *Parallel) Process(m stream.Message, emit Emit) {
+	for _, n := range p.nodes {
+		n.Process(m, emit)
+	}
+}
+
+// Advance advances the schedulers in parallel.
+func (p *Parallel) Advance(now time.Time, emit Emit) {
+	for _, n := range p.nodes {
+		if s, ok := n.(Scheduler); ok {
+			s.Advance(now, emit)
+		}
+	}
+}
+
+// Flush flushes the nodes in parallel.
+func (p *Parallel) Flush(emit Emit) {
+	for _, n := range p.nodes {
+		if f, ok := n.(Flusher); ok {
+			f.Flush(emit)
+		}
+	}
+}
+
+// Reset resets all nodes.
+func (p *Parallel) Reset() {
+	for _, n := range p.nodes {
+		n.Reset()
+	}
+}
+
+// NewParallel returns Parallel of the nodes.
+func NewParallel(nodes ...Node) *Parallel {
+	return &Parallel{nodes: nodes}
+}
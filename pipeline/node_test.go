@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+var start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func process(n Node, es ...event.Event) []stream.Message {
+	var ms []stream.Message
+
+	for _, e := range es {
+		n.Process(stream.Message{Time: start, Event: e}, func(m stream.Message) {
+			ms = append(ms, m)
+		})
+	}
+
+	return ms
+}
+
+func expectEvents(t *testing.T, expected []event.Event, actual []stream.Message) {
+	t.Helper()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v messages actual: %v messages %v", len(expected), len(actual), actual)
+	}
+	for i := range expected {
+		if !expected[i].Equal(actual[i].Event) {
+			t.Fatalf("[%v] expected: %v actual: %v", i, expected[i], actual[i].Event)
+		}
+	}
+}
+
+func noteOnEvent(channel uint8, note constant.Note, velocity uint8) *event.NoteOnEvent {
+	e, _ := event.NewNoteOnEvent(nil, channel, note, velocity)
+	return e
+}
+
+func noteOffEvent(channel uint8, note constant.Note) *event.NoteOffEvent {
+	e, _ := event.NewNoteOffEvent(nil, channel, note, 0x00)
+	return e
+}
+
+func TestChain(t *testing.T) {
+	rechannel, _ := NewRechannel(1)
+	chain := NewChain(NewTranspose(12), rechannel)
+
+	input := noteOnEvent(0, constant.C3, 0x40)
+	actual := process(chain, input)
+
+	expectEvents(t, []event.Event{noteOnEvent(1, constant.C4, 0x40)}, actual)
+
+	// The nodes clone the event before changing it.
+	if !input.Equal(noteOnEvent(0, constant.C3, 0x40)) {
+		t.Fatalf("input is modified: %v", input)
+	}
+
+	expectEvents(t, []event.Event{input}, process(NewChain(), input))
+}
+
+func TestParallel(t *testing.T) {
+	lower, _ := NewKeyRange(0, constant.B2)
+	upper, _ := NewKeyRange(constant.C3, 0x7f)
+	upperChannel, _ := NewRechannel(1)
+	split := NewParallel(lower, NewChain(upper, upperChannel))
+
+	controller, _ := event.NewControllerEvent(nil, 0, constant.Hold1, 0x7f)
+	controllerOn1, _ := event.NewControllerEvent(nil, 1, constant.Hold1, 0x7f)
+
+	actual := process(split, noteOnEvent(0, constant.A2, 0x40), noteOnEvent(0, constant.D3, 0x40), controller)
+
+	expectEvents(t, []event.Event{
+		noteOnEvent(0, constant.A2, 0x40),
+		noteOnEvent(1, constant.D3, 0x40),
+		controller,
+		controllerOn1,
+	}, actual)
+}
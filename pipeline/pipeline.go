@@ -0,0 +1,295 @@
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/player"
+	"github.com/moutend/go-midi/stream"
+)
+
+// DefaultInterval is the default interval of advancing the schedulers while running.
+const DefaultInterval = time.Millisecond
+
+// epoch is the time of tick 0 when MIDI data is processed offline. It's not zero time, which means idle in the nodes.
+var epoch = time.Unix(0, 0)
+
+// Pipeline runs the graph of nodes. The methods are safe for concurrent use.
+type Pipeline struct {
+	mu       sync.Mutex
+	root     Node
+	clock    player.Clock
+	interval time.Duration
+}
+
+func (p *Pipeline) advance(now time.Time) []stream.Message {
+	var ms []stream.Message
+
+	if s, ok := p.root.(Scheduler); ok {
+		s.Advance(now, func(m stream.Message) {
+			ms = append(ms, m)
+		})
+	}
+
+	// The parallel schedulers emit their messages one after another.
+	sort.SliceStable(ms, func(i, j int) bool {
+		return ms[i].Time.Before(ms[j].Time)
+	})
+
+	return ms
+}
+
+// Process returns the messages emitted for the message. The schedulers are advanced to just before the time of
+// message first, so that the messages scheduled earlier precede the ones emitted for the message, and the notes
+// pressed at the same time, e.g. a chord, start the arpeggio together.
+func (p *Pipeline) Process(m stream.Message) []stream.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ms := p.advance(m.Time.Add(-time.Nanosecond))
+
+	p.root.Process(m, func(m stream.Message) {
+		ms = append(ms, m)
+	})
+
+	return ms
+}
+
+// Advance returns the messages scheduled until now, e.g. the notes of arpeggio.
+func (p *Pipeline) Advance(now time.Time) []stream.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.advance(now)
+}
+
+// Reset resets all nodes and returns the messages flushed before reset, e.g. the note off of the note sounding in
+// arpeggio. Send them to avoid stuck notes.
+func (p *Pipeline) Reset() []stream.Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var ms []stream.Message
+
+	if f, ok := p.root.(Flusher); ok {
+		f.Flush(func(m stream.Message) {
+			ms = append(ms, m)
+		})
+	}
+
+	p.root.Reset()
+
+	return ms
+}
+
+// Run processes the messages from input and sends the results to output until input is closed.
+// The schedulers are advanced at the interval with the time of clock. The messages are sent with SendAt
+// if output implements player.TimedOutput. The meta events can't be sent.
+func (p *Pipeline) Run(input <-chan stream.Message, output player.Output) error {
+	var buf bytes.Buffer
+
+	encoder := stream.NewEncoder(&buf)
+	timed, isTimed := output.(player.TimedOutput)
+
+	send := func(ms []stream.Message) error {
+		for _, m := range ms {
+			buf.Reset()
+
+			if err := encoder.Encode(m.Event); err != nil {
+				return err
+			}
+
+			var err error
+
+			if isTimed {
+				err = timed.SendAt(buf.Bytes(), m.Time)
+			} else {
+				err = output.Send(buf.Bytes())
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	p.mu.Lock()
+	clock, interval := p.clock, p.interval
+	p.mu.Unlock()
+
+	if interval <= 0 {
+		return fmt.Errorf("midi: interval must be greater than 0 (%v)", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case m, ok := <-input:
+			if !ok {
+				return nil
+			}
+			if err := send(p.Process(m)); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := send(p.Advance(clock.Now())); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ProcessMIDI returns the copy of MIDI data whose tracks are processed one by one. The nodes are reset before each
+// track. The meta events are kept, and the notes which are still on at the end of track are turned off there.
+func (p *Pipeline) ProcessMIDI(m *midi.MIDI) (*midi.MIDI, error) {
+	tempoMap, err := m.TempoMap()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &midi.MIDI{}
+	timeDivision := *m.TimeDivision()
+
+	result.SetTimeDivision(&timeDivision)
+
+	if err := result.SetFormatType(m.FormatType()); err != nil {
+		return nil, err
+	}
+
+	timeAt := func(tick uint32) time.Time {
+		return epoch.Add(tempoMap.Duration(tick))
+	}
+	tickAt := func(t time.Time) uint32 {
+		// Duration truncates the fraction of nanosecond, so that a nanosecond is added to get the same tick back.
+		return tempoMap.Tick(t.Sub(epoch) + time.Nanosecond)
+	}
+
+	for _, track := range m.Tracks {
+		p.Reset()
+
+		var tes []midi.TimedEvent
+		var end uint32
+
+		add := func(ms []stream.Message) {
+			for _, m := range ms {
+				tick := tickAt(m.Time)
+				if tick > end {
+					tick = end
+				}
+				tes = append(tes, midi.TimedEvent{Tick: tick, Event: m.Event.Clone()})
+			}
+		}
+
+		for _, te := range track.TimedEvents() {
+			if te.Tick > end {
+				end = te.Tick
+			}
+			if _, ok := te.Event.(*event.EndOfTrackEvent); ok {
+				continue
+			}
+			if te.Event.Kind().IsMeta() {
+				add(p.Advance(timeAt(te.Tick)))
+				tes = append(tes, midi.TimedEvent{Tick: te.Tick, Event: te.Event.Clone()})
+				continue
+			}
+
+			add(p.Process(stream.Message{Time: timeAt(te.Tick), Event: te.Event}))
+		}
+
+		add(p.Advance(timeAt(end)))
+		add(p.Reset())
+
+		tes, err = closeNotes(tes, end)
+		if err != nil {
+			return nil, err
+		}
+
+		eot, err := event.NewEndOfTrackEvent(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Tracks = append(result.Tracks, midi.NewTrackFromTimedEvents(append(tes, midi.TimedEvent{Tick: end, Event: eot})))
+	}
+
+	p.Reset()
+
+	return result, nil
+}
+
+// closeNotes appends the note off events at the end for the notes which are not turned off.
+func closeNotes(tes []midi.TimedEvent, end uint32) ([]midi.TimedEvent, error) {
+	counts := map[uint16]int{}
+
+	sort.SliceStable(tes, func(i, j int) bool {
+		return tes[i].Tick < tes[j].Tick
+	})
+
+	for _, te := range tes {
+		if v, ok := noteOn(te.Event); ok {
+			counts[uint16(v.Channel())<<7|uint16(v.Note())]++
+		} else if note, channel, ok := noteOff(te.Event); ok {
+			if key := uint16(channel)<<7 | uint16(note); counts[key] > 0 {
+				counts[key]--
+			}
+		}
+	}
+
+	keys := make([]int, 0, len(counts))
+	for key, count := range counts {
+		if count > 0 {
+			keys = append(keys, int(key))
+		}
+	}
+	sort.Ints(keys)
+
+	for _, key := range keys {
+		e, err := event.NewNoteOffEvent(nil, uint8(key>>7), constant.Note(key&0x7f), 0x00)
+		if err != nil {
+			return nil, err
+		}
+
+		tes = append(tes, midi.TimedEvent{Tick: end, Event: e})
+	}
+
+	return tes, nil
+}
+
+// SetClock sets the clock which advances the schedulers while running. The default is player.SystemClock.
+func (p *Pipeline) SetClock(clock player.Clock) *Pipeline {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clock = clock
+
+	return p
+}
+
+// SetInterval sets the interval of advancing the schedulers while running, which must be greater than 0.
+// The default is DefaultInterval.
+func (p *Pipeline) SetInterval(interval time.Duration) *Pipeline {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.interval = interval
+
+	return p
+}
+
+// New returns Pipeline which runs the nodes in series.
+func New(nodes ...Node) *Pipeline {
+	return &Pipeline{
+		root:     NewChain(nodes...),
+		clock:    player.SystemClock,
+		interval: DefaultInterval,
+	}
+}
@@ -0,0 +1,178 @@
+package pipeline
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/player"
+	"github.com/moutend/go-midi/stream"
+)
+
+func TestPipeline_ProcessMIDI(t *testing.T) {
+	text, _ := event.NewTextEvent(nil, []byte("arpeggio"))
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	m := &midi.MIDI{}
+	m.SetTimeDivision(&midi.TimeDivision{})
+	m.TimeDivision().SetBPM(480)
+	m.Tracks = []*midi.Track{midi.NewTrackFromTimedEvents([]midi.TimedEvent{
+		{Tick: 0, Event: text},
+		{Tick: 0, Event: noteOnEvent(0, constant.C3, 0x40)},
+		{Tick: 0, Event: noteOnEvent(0, constant.E3, 0x40)},
+		{Tick: 720, Event: noteOffEvent(0, constant.C3)},
+		{Tick: 720, Event: noteOffEvent(0, constant.E3)},
+		{Tick: 960, Event: eot},
+	})}
+
+	original := m.Clone()
+
+	// A quarter note is 500 ms in the default tempo, so that the arpeggio plays eighth notes.
+	a, _ := NewArpeggiator(250 * time.Millisecond)
+	a.SetGate(1)
+
+	actual, err := New(NewTranspose(12), a).ProcessMIDI(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Equal(original) {
+		t.Fatal("original MIDI data is modified")
+	}
+
+	expected := []midi.TimedEvent{
+		{Tick: 0, Event: text},
+		{Tick: 0, Event: noteOnEvent(0, constant.C4, 0x40)},
+		{Tick: 240, Event: noteOffEvent(0, constant.C4)},
+		{Tick: 240, Event: noteOnEvent(0, constant.E4, 0x40)},
+		{Tick: 480, Event: noteOffEvent(0, constant.E4)},
+		{Tick: 480, Event: noteOnEvent(0, constant.C4, 0x40)},
+		{Tick: 720, Event: noteOffEvent(0, constant.C4)},
+		{Tick: 960, Event: eot},
+	}
+	tes := actual.Tracks[0].TimedEvents()
+
+	// The events are compared without delta time.
+
+	if len(expected) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v", len(expected), tes)
+	}
+	for i := range expected {
+		if expected[i].Tick != tes[i].Tick || !bytes.Equal(expected[i].Event.Serialize(), tes[i].Event.Serialize()) {
+			t.Fatalf("[%v] expected: %v %v actual: %v %v", i, expected[i].Tick, expected[i].Event, tes[i].Tick, tes[i].Event)
+		}
+	}
+}
+
+func TestPipeline_ProcessMIDI_closeNotes(t *testing.T) {
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	m := &midi.MIDI{}
+	m.SetTimeDivision(&midi.TimeDivision{})
+	m.TimeDivision().SetBPM(480)
+	m.Tracks = []*midi.Track{midi.NewTrackFromTimedEvents([]midi.TimedEvent{
+		{Tick: 0, Event: noteOnEvent(0, constant.C3, 0x40)},
+		{Tick: 480, Event: eot},
+	})}
+
+	chord, _ := NewChord(0, 12)
+
+	actual, err := New(chord).ProcessMIDI(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []midi.TimedEvent{
+		{Tick: 0, Event: noteOnEvent(0, constant.C3, 0x40)},
+		{Tick: 0, Event: noteOnEvent(0, constant.C4, 0x40)},
+		{Tick: 480, Event: noteOffEvent(0, constant.C3)},
+		{Tick: 480, Event: noteOffEvent(0, constant.C4)},
+		{Tick: 480, Event: eot},
+	}
+	tes := actual.Tracks[0].TimedEvents()
+
+	if len(expected) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v", len(expected), tes)
+	}
+	for i := range expected {
+		if expected[i].Tick != tes[i].Tick || !bytes.Equal(expected[i].Event.Serialize(), tes[i].Event.Serialize()) {
+			t.Fatalf("[%v] expected: %v %v actual: %v %v", i, expected[i].Tick, expected[i].Event, tes[i].Tick, tes[i].Event)
+		}
+	}
+}
+
+// timedOutput records the messages with the scheduled time.
+type timedOutput struct {
+	records []player.Record
+}
+
+func (o *timedOutput) Send(message []byte) error {
+	return o.SendAt(message, time.Time{})
+}
+
+func (o *timedOutput) SendAt(message []byte, at time.Time) error {
+	o.records = append(o.records, player.Record{Time: at, Message: append([]byte{}, message...)})
+
+	return nil
+}
+
+func TestPipeline_Run(t *testing.T) {
+	a, _ := NewArpeggiator(10 * time.Millisecond)
+	clock := player.NewFakeClock(start)
+	p := New(NewTranspose(12), a).SetClock(clock)
+
+	input := make(chan stream.Message)
+	output := &timedOutput{}
+	done := make(chan error)
+
+	go func() {
+		done <- p.Run(input, output)
+	}()
+
+	input <- stream.Message{Time: start, Event: noteOnEvent(0, constant.C3, 0x40)}
+
+	// The fake clock is advanced by the test, and the pipeline advances the arpeggiator at the interval.
+	clock.Sleep(25 * time.Millisecond)
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		p.mu.Lock()
+		next := a.next
+		p.mu.Unlock()
+
+		if next.Equal(start.Add(30 * time.Millisecond)) {
+			break
+		}
+	}
+
+	input <- stream.Message{Time: start.Add(25 * time.Millisecond), Event: noteOffEvent(0, constant.C3)}
+	close(input)
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []player.Record{
+		{Time: start, Message: []byte{0x90, 0x48, 0x40}},
+		{Time: start.Add(5 * time.Millisecond), Message: []byte{0x80, 0x48, 0x00}},
+		{Time: start.Add(10 * time.Millisecond), Message: []byte{0x90, 0x48, 0x40}},
+		{Time: start.Add(15 * time.Millisecond), Message: []byte{0x80, 0x48, 0x00}},
+		{Time: start.Add(20 * time.Millisecond), Message: []byte{0x90, 0x48, 0x40}},
+		{Time: start.Add(25 * time.Millisecond), Message: []byte{0x80, 0x48, 0x00}},
+	}
+	if len(expected) != len(output.records) {
+		t.Fatalf("expected: %v records actual: %v", len(expected), output.records)
+	}
+	for i := range expected {
+		if !expected[i].Time.Equal(output.records[i].Time) || string(expected[i].Message) != string(output.records[i].Message) {
+			t.Fatalf("[%v] expected: %v actual: %v", i, expected[i], output.records[i])
+		}
+	}
+}
+
+func TestPipeline_SetInterval(t *testing.T) {
+	if err := New().SetInterval(0).Run(make(chan stream.Message), &timedOutput{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
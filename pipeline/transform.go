@@ -0,0 +1,148 @@
+package pipeline
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// Rechannel moves the channel events to the channel.
+type Rechannel struct {
+	channel uint8
+}
+
+// Process emits the message with the channel changed.
+func (r *Rechannel) Process(m stream.Message, emit Emit) {
+	if _, ok := m.Event.(event.ChannelEvent); ok {
+		e := m.Event.Clone().(event.ChannelEvent)
+		e.SetChannel(r.channel)
+		m.Event = e
+	}
+
+	emit(m)
+}
+
+// Reset does nothing because rechannel has no state.
+func (r *Rechannel) Reset() {}
+
+// NewRechannel returns Rechannel which moves the channel events to the channel from 0 to 15.
+func NewRechannel(channel uint8) (*Rechannel, error) {
+	if channel > 0x0f {
+		return nil, fmt.Errorf("midi: maximum channel number is 15 (0x0f)")
+	}
+
+	return &Rechannel{channel: channel}, nil
+}
+
+// Transpose transposes the note events by semitones. The notes out of range after transposition are dropped.
+type Transpose struct {
+	semitones int
+}
+
+// Process emits the message with the note transposed.
+func (t *Transpose) Process(m stream.Message, emit Emit) {
+	v, ok := m.Event.(noteEvent)
+	if !ok {
+		emit(m)
+		return
+	}
+
+	note := int(v.Note()) + t.semitones
+	if note < 0 || note > 0x7f {
+		return
+	}
+
+	e := m.Event.Clone().(noteEvent)
+	e.SetNote(constant.Note(note))
+	m.Event = e
+
+	emit(m)
+}
+
+// Reset does nothing because transpose has no state.
+func (t *Transpose) Reset() {}
+
+// NewTranspose returns Transpose which transposes the notes by semitones, e.g. -12 for an octave lower.
+func NewTranspose(semitones int) *Transpose {
+	return &Transpose{semitones: semitones}
+}
+
+// VelocityCurve maps the velocity of note on events with a curve. The velocity v from 1 to 127 is mapped into
+// min + (max - min) * ((v - 1) / 126) ^ gamma, so that gamma less than 1 makes soft playing louder.
+type VelocityCurve struct {
+	table [128]uint8
+}
+
+// Process emits the message with the velocity mapped. The note on with velocity 0, i.e. note off, is kept.
+func (c *VelocityCurve) Process(m stream.Message, emit Emit) {
+	if v, ok := noteOn(m.Event); ok {
+		e := v.Clone().(*event.NoteOnEvent)
+		e.SetVelocity(c.table[v.Velocity()])
+		m.Event = e
+	}
+
+	emit(m)
+}
+
+// Reset does nothing because velocity curve has no state.
+func (c *VelocityCurve) Reset() {}
+
+// NewVelocityCurve returns VelocityCurve with gamma greater than 0 and the range of velocity from min to max.
+// The gamma 1 with min 1 and max 127 keeps the velocity.
+func NewVelocityCurve(gamma float64, min, max uint8) (*VelocityCurve, error) {
+	if gamma <= 0 || math.IsInf(gamma, 0) || math.IsNaN(gamma) {
+		return nil, fmt.Errorf("midi: gamma of velocity curve must be greater than 0 (%v)", gamma)
+	}
+	if min < 1 || min > max || max > 0x7f {
+		return nil, fmt.Errorf("midi: invalid range of velocity (%v to %v)", min, max)
+	}
+
+	c := &VelocityCurve{}
+
+	for v := 1; v < len(c.table); v++ {
+		x := math.Pow(float64(v-1)/126, gamma)
+		c.table[v] = uint8(math.Round(float64(min) + float64(max-min)*x))
+	}
+
+	return c, nil
+}
+
+// ControllerMap changes the control numbers of controller events, e.g. modulation wheel into expression.
+type ControllerMap struct {
+	controls map[constant.Control]constant.Control
+}
+
+// Process emits the message with the control number mapped.
+func (c *ControllerMap) Process(m stream.Message, emit Emit) {
+	if v, ok := m.Event.(*event.ControllerEvent); ok {
+		if to, ok := c.controls[v.Control()]; ok {
+			e := v.Clone().(*event.ControllerEvent)
+			e.SetControl(to)
+			m.Event = e
+		}
+	}
+
+	emit(m)
+}
+
+// Reset does nothing because controller map has no state.
+func (c *ControllerMap) Reset() {}
+
+// Map maps the control number from into to.
+func (c *ControllerMap) Map(from, to constant.Control) error {
+	if from > 0x77 || to > 0x77 {
+		return fmt.Errorf("midi: control number of controller map must be less than 120 (0x78)")
+	}
+
+	c.controls[from] = to
+
+	return nil
+}
+
+// NewControllerMap returns ControllerMap which maps nothing.
+func NewControllerMap() *ControllerMap {
+	return &ControllerMap{controls: map[constant.Control]constant.Control{}}
+}
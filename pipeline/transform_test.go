@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestRechannel(t *testing.T) {
+	r, err := NewRechannel(9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	program, _ := event.NewProgramChangeEvent(nil, 0, 0x05)
+	expected, _ := event.NewProgramChangeEvent(nil, 9, 0x05)
+	clock := &event.TimingClockEvent{}
+
+	expectEvents(t, []event.Event{expected, clock}, process(r, program, clock))
+
+	if _, err := NewRechannel(16); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	actual := process(NewTranspose(-12), noteOnEvent(0, constant.C3, 0x40), noteOnEvent(0, constant.Bminus2, 0x40), noteOffEvent(0, constant.C3))
+
+	expectEvents(t, []event.Event{noteOnEvent(0, constant.C2, 0x40), noteOffEvent(0, constant.C2)}, actual)
+}
+
+func TestVelocityCurve(t *testing.T) {
+	c, err := NewVelocityCurve(1, 1, 127)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for velocity := uint8(1); velocity < 128; velocity++ {
+		actual := process(c, noteOnEvent(0, constant.C3, velocity))
+		expectEvents(t, []event.Event{noteOnEvent(0, constant.C3, velocity)}, actual)
+	}
+
+	c, err = NewVelocityCurve(0.5, 32, 96)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := process(c, noteOnEvent(0, constant.C3, 1), noteOnEvent(0, constant.C3, 32), noteOnEvent(0, constant.C3, 127), noteOnEvent(0, constant.C3, 0))
+
+	// 32 + 64 * sqrt(31 / 126) = 63.7
+	expectEvents(t, []event.Event{
+		noteOnEvent(0, constant.C3, 32),
+		noteOnEvent(0, constant.C3, 64),
+		noteOnEvent(0, constant.C3, 96),
+		noteOnEvent(0, constant.C3, 0),
+	}, actual)
+
+	for _, c := range []struct {
+		gamma    float64
+		min, max uint8
+	}{
+		{0, 1, 127},
+		{1, 0, 127},
+		{1, 64, 32},
+		{1, 1, 128},
+	} {
+		if _, err := NewVelocityCurve(c.gamma, c.min, c.max); err == nil {
+			t.Fatalf("expected error for %+v", c)
+		}
+	}
+}
+
+func TestControllerMap(t *testing.T) {
+	m := NewControllerMap()
+
+	if err := m.Map(constant.Modulation, constant.Expression); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Map(constant.Modulation, constant.AllNotesOff); err == nil {
+		t.Fatal("expected error")
+	}
+
+	modulation, _ := event.NewControllerEvent(nil, 0, constant.Modulation, 0x40)
+	expression, _ := event.NewControllerEvent(nil, 0, constant.Expression, 0x40)
+	hold, _ := event.NewControllerEvent(nil, 0, constant.Hold1, 0x7f)
+
+	expectEvents(t, []event.Event{expression, hold}, process(m, modulation, hold))
+}
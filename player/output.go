@@ -0,0 +1,70 @@
+package player
+
+import (
+	"sync"
+	"time"
+)
+
+// Output receives MIDI messages from Player, e.g. a MIDI port or a software synthesizer.
+// Each message is a complete MIDI message without running status.
+type Output interface {
+	Send(message []byte) error
+}
+
+// TimedOutput is implemented by the outputs which schedule the messages by themselves.
+// Player sends the messages to TimedOutput ahead of time within the lookahead, so that the timing doesn't depend on
+// the wake-up latency of player.
+type TimedOutput interface {
+	Output
+
+	SendAt(message []byte, at time.Time) error
+}
+
+// Record represents a message received by RecordingOutput.
+type Record struct {
+	Time    time.Time
+	Message []byte
+}
+
+// RecordingOutput is the Output which records the messages in memory with the time of clock.
+type RecordingOutput struct {
+	mu      sync.Mutex
+	clock   Clock
+	records []Record
+}
+
+// Send records the copy of message.
+func (o *RecordingOutput) Send(message []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.records = append(o.records, Record{
+		Time:    o.clock.Now(),
+		Message: append([]byte{}, message...),
+	})
+
+	return nil
+}
+
+// Records returns the recorded messages.
+func (o *RecordingOutput) Records() []Record {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]Record{}, o.records...)
+}
+
+// Reset discards the recorded messages.
+func (o *RecordingOutput) Reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.records = nil
+}
+
+// NewRecordingOutput returns RecordingOutput which timestamps the messages with clock.
+func NewRecordingOutput(clock Clock) *RecordingOutput {
+	return &RecordingOutput{
+		clock: clock,
+	}
+}
@@ -0,0 +1,459 @@
+/*
+Package player plays MIDI data to an output in real time.
+
+Player converts the ticks into the wall-clock time with the tempo map, and sends the channel messages and the system
+exclusive messages to Output. The clock is injectable, so that the playback can be tested with FakeClock without sleeping.
+*/
+package player
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/chase"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/stream"
+)
+
+const (
+	// DefaultLookahead is the default duration of the messages sent ahead of time to TimedOutput.
+	DefaultLookahead = 100 * time.Millisecond
+
+	// DefaultInterval is the default maximum duration of sleep between the wake-ups of player.
+	DefaultInterval = 10 * time.Millisecond
+)
+
+type message struct {
+	tick uint32
+	data []byte
+}
+
+// Player plays MIDI data. The methods are safe for concurrent use, e.g. Stop can be called while Play is running.
+type Player struct {
+	mu sync.Mutex
+
+	midi     *midi.MIDI
+	output   Output
+	clock    Clock
+	tempoMap *midi.TempoMap
+	messages []message
+	length   uint32
+
+	lookahead time.Duration
+	interval  time.Duration
+	scale     float64
+
+	loop      bool
+	loopStart uint32
+	loopEnd   uint32
+
+	playing bool
+	index   int
+
+	// The messages until sentTick have been sent, and the last of them is played at sentAt. For TimedOutput,
+	// sentAt can be ahead of the current time by the lookahead.
+	sentTick uint32
+	sentAt   time.Time
+
+	// The tick anchorTick is played at anchorTime. While the player is stopped, anchorTick is the position.
+	anchorTick uint32
+	anchorTime time.Time
+}
+
+// timeAt returns the wall-clock time of the tick.
+func (p *Player) timeAt(tick uint32) time.Time {
+	d := p.tempoMap.Duration(tick) - p.tempoMap.Duration(p.anchorTick)
+
+	return p.anchorTime.Add(time.Duration(float64(d) / p.scale))
+}
+
+// tickAt returns the tick played at the wall-clock time.
+func (p *Player) tickAt(now time.Time) uint32 {
+	d := time.Duration(float64(now.Sub(p.anchorTime)) * p.scale)
+	if d < 0 {
+		d = 0
+	}
+
+	tick := p.tempoMap.Tick(p.tempoMap.Duration(p.anchorTick) + d)
+	if end := p.end(); tick > end {
+		tick = end
+	}
+
+	return tick
+}
+
+// end returns the tick where the playback ends or goes back to the beginning of loop.
+func (p *Player) end() uint32 {
+	if p.loop {
+		return p.loopEnd
+	}
+
+	return p.length
+}
+
+// anchor makes the tick played at the time and moves to the first message at the tick.
+func (p *Player) anchor(tick uint32, at time.Time) {
+	p.anchorTick = tick
+	p.anchorTime = at
+	p.sentTick = tick
+	p.sentAt = at
+	p.index = sort.Search(len(p.messages), func(i int) bool {
+		return p.messages[i].tick >= tick
+	})
+}
+
+// send sends the message at the time.
+func (p *Player) send(data []byte, at time.Time) error {
+	if timed, ok := p.output.(TimedOutput); ok {
+		return timed.SendAt(data, at)
+	}
+
+	return p.output.Send(data)
+}
+
+// allNotesOff sends all notes off to 16 channels.
+func (p *Player) allNotesOff(at time.Time) error {
+	for channel := byte(0); channel < 16; channel++ {
+		if err := p.send([]byte{constant.Controller + channel, byte(constant.AllNotesOff), 0x00}, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chase sends the messages which recreate the state of channels at the tick, e.g. programs and controllers.
+func (p *Player) chase(tick uint32, at time.Time) error {
+	if tick == 0 {
+		return nil
+	}
+
+	es, err := chase.At(p.midi, tick).Events()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	encoder := stream.NewEncoder(&buf)
+
+	for _, e := range es {
+		buf.Reset()
+
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+		if err := p.send(buf.Bytes(), at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dispatch sends the messages scheduled until now, or until now plus lookahead for TimedOutput.
+// It returns the time to wake up next, and true when the playback reached the end.
+func (p *Player) dispatch(now time.Time) (time.Time, bool, error) {
+	lookahead := time.Duration(0)
+	if _, ok := p.output.(TimedOutput); ok {
+		lookahead = p.lookahead
+	}
+
+	horizon := now.Add(lookahead)
+
+	for {
+		end := p.end()
+
+		if p.index < len(p.messages) && (!p.loop || p.messages[p.index].tick < end) {
+			m := p.messages[p.index]
+			at := p.timeAt(m.tick)
+
+			if at.After(horizon) {
+				return at.Add(-lookahead), false, nil
+			}
+			if err := p.send(m.data, at); err != nil {
+				return time.Time{}, false, err
+			}
+
+			p.index++
+			p.sentTick, p.sentAt = m.tick, at
+			continue
+		}
+
+		at := p.timeAt(end)
+
+		if at.After(horizon) {
+			return at.Add(-lookahead), false, nil
+		}
+		if !p.loop {
+			p.anchor(end, at)
+			return at, true, nil
+		}
+		if err := p.allNotesOff(at); err != nil {
+			return time.Time{}, false, err
+		}
+
+		p.anchor(p.loopStart, at)
+
+		if err := p.chase(p.loopStart, at); err != nil {
+			return time.Time{}, false, err
+		}
+	}
+}
+
+// Play plays from the current position until the end of song or Stop is called. It blocks while playing.
+// The messages which recreate the state of channels are sent first when it starts from the middle of song.
+// The position stays at the end of song after the playback, so that call Seek(0) to play again.
+func (p *Player) Play() error {
+	p.mu.Lock()
+
+	if p.playing {
+		p.mu.Unlock()
+		return fmt.Errorf("midi: player is already playing")
+	}
+
+	now := p.clock.Now()
+
+	p.playing = true
+	p.anchorTime = now
+
+	err := p.chase(p.anchorTick, now)
+
+	p.mu.Unlock()
+
+	for err == nil {
+		var next time.Time
+		var done bool
+
+		p.mu.Lock()
+
+		if !p.playing {
+			p.mu.Unlock()
+			return nil
+		}
+
+		now := p.clock.Now()
+
+		next, done, err = p.dispatch(now)
+		if done || err != nil {
+			p.playing = false
+			p.mu.Unlock()
+			break
+		}
+
+		clock, interval := p.clock, p.interval
+
+		p.mu.Unlock()
+
+		wait := next.Sub(now)
+		if interval > 0 && wait > interval {
+			wait = interval
+		}
+
+		clock.Sleep(wait)
+	}
+
+	return err
+}
+
+// Stop stops the playback and sends all notes off to 16 channels. The position is kept, so that Play resumes from it.
+// The messages which have already been sent to TimedOutput are not canceled, so that the playback stops after them
+// and all notes off is scheduled at that time.
+func (p *Player) Stop() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.playing {
+		return nil
+	}
+
+	now := p.clock.Now()
+
+	p.playing = false
+
+	// The index is kept, so that the messages sent before stopping are not sent again. The position is the last
+	// message sent when it's ahead of now, so that Play resumes from the first message which isn't sent.
+	if p.sentAt.After(now) {
+		p.anchorTick, p.anchorTime = p.sentTick, p.sentAt
+
+		return p.allNotesOff(p.sentAt)
+	}
+
+	p.anchorTick, p.anchorTime = p.tickAt(now), now
+
+	return p.allNotesOff(now)
+}
+
+// Seek moves the position to the tick. While playing, it sends all notes off and the messages which recreate
+// the state of channels at the tick, then continues playing from the tick.
+func (p *Player) Seek(tick uint32) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if tick > p.length {
+		return fmt.Errorf("midi: tick %v is beyond the end of song (%v)", tick, p.length)
+	}
+
+	now := p.clock.Now()
+
+	p.anchor(tick, now)
+
+	if !p.playing {
+		return nil
+	}
+	if err := p.allNotesOff(now); err != nil {
+		return err
+	}
+
+	return p.chase(tick, now)
+}
+
+// Position returns the current position in ticks.
+func (p *Player) Position() uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.playing {
+		return p.anchorTick
+	}
+
+	return p.tickAt(p.clock.Now())
+}
+
+// Playing reports whether the player is playing.
+func (p *Player) Playing() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.playing
+}
+
+// Length returns the length of song in ticks, i.e. the tick of the last event.
+func (p *Player) Length() uint32 {
+	return p.length
+}
+
+// SetLoop sets the loop from start to end in ticks. The player goes back to start when it reaches end,
+// and sends all notes off and the messages which recreate the state of channels at start.
+func (p *Player) SetLoop(start, end uint32) error {
+	if start >= end {
+		return fmt.Errorf("midi: end of loop must be greater than start (%v >= %v)", start, end)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loop = true
+	p.loopStart = start
+	p.loopEnd = end
+
+	return nil
+}
+
+// ClearLoop clears the loop. The player plays until the end of song.
+func (p *Player) ClearLoop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.loop = false
+}
+
+// SetTempoScale sets the scale of tempo, e.g. 2 plays twice as fast. The scale less than or equal to 0 is treated as 1.
+// The default is 1.
+func (p *Player) SetTempoScale(scale float64) *Player {
+	if scale <= 0 {
+		scale = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.playing {
+		now := p.clock.Now()
+		p.anchorTick, p.anchorTime = p.tickAt(now), now
+	}
+
+	p.scale = scale
+
+	return p
+}
+
+// SetLookahead sets the duration of the messages sent ahead of time to TimedOutput. The default is DefaultLookahead.
+// It's ignored for the outputs which don't implement TimedOutput.
+func (p *Player) SetLookahead(lookahead time.Duration) *Player {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lookahead = lookahead
+
+	return p
+}
+
+// SetInterval sets the maximum duration of sleep between the wake-ups of player, which limits the latency of
+// Stop, Seek and the other changes during playback. The default is DefaultInterval.
+func (p *Player) SetInterval(interval time.Duration) *Player {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.interval = interval
+
+	return p
+}
+
+// SetClock sets clock. The default is SystemClock.
+func (p *Player) SetClock(clock Clock) *Player {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clock = clock
+
+	return p
+}
+
+// NewPlayer returns Player which plays m to output. The meta events are not sent.
+func NewPlayer(m *midi.MIDI, output Output) (*Player, error) {
+	tempoMap, err := m.TempoMap()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Player{
+		midi:      m,
+		output:    output,
+		clock:     SystemClock,
+		tempoMap:  tempoMap,
+		lookahead: DefaultLookahead,
+		interval:  DefaultInterval,
+		scale:     1,
+	}
+
+	var buf bytes.Buffer
+
+	encoder := stream.NewEncoder(&buf)
+
+	for _, te := range m.TimedEvents() {
+		if te.Tick > p.length {
+			p.length = te.Tick
+		}
+		if te.Event.Kind().IsMeta() {
+			continue
+		}
+
+		buf.Reset()
+
+		if err := encoder.Encode(te.Event); err != nil {
+			return nil, err
+		}
+
+		p.messages = append(p.messages, message{
+			tick: te.Tick,
+			data: append([]byte{}, buf.Bytes()...),
+		})
+	}
+
+	return p, nil
+}
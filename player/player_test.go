@@ -0,0 +1,327 @@
+package player
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// newTestMIDI returns a song which plays a note every quarter note at 120 BPM, i.e. every 500 ms.
+func newTestMIDI(t *testing.T) *midi.MIDI {
+	tes := []midi.TimedEvent{}
+	at := func(tick uint32) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			tes = append(tes, midi.TimedEvent{Tick: tick, Event: e})
+		}
+	}
+
+	at(0)(event.NewSetTempoEvent(nil, midi.DefaultTempo))
+	at(0)(event.NewProgramChangeEvent(nil, 0, constant.ElectricPiano1))
+	at(0)(event.NewNoteOnEvent(nil, 0, constant.C3, 100))
+	at(480)(event.NewNoteOffEvent(nil, 0, constant.C3, 0))
+	at(480)(event.NewNoteOnEvent(nil, 0, constant.D3, 100))
+	at(960)(event.NewNoteOffEvent(nil, 0, constant.D3, 0))
+	at(960)(event.NewNoteOnEvent(nil, 0, constant.E3, 100))
+	at(1440)(event.NewNoteOffEvent(nil, 0, constant.E3, 0))
+	at(1440)(event.NewEndOfTrackEvent(nil))
+
+	m := &midi.MIDI{Tracks: []*midi.Track{midi.NewTrackFromTimedEvents(tes)}}
+	m.TimeDivision().SetBPM(480)
+
+	return m
+}
+
+func format(rs []Record) []string {
+	ss := make([]string, len(rs))
+
+	for i, r := range rs {
+		ss[i] = fmt.Sprintf("%v % x", r.Time.Sub(epoch), r.Message)
+	}
+
+	return ss
+}
+
+func assertRecords(t *testing.T, expected []string, rs []Record) {
+	t.Helper()
+
+	actual := format(rs)
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v records actual: %v records (%q)", len(expected), len(actual), actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected[i], i, actual[i])
+		}
+	}
+}
+
+func newTestPlayer(t *testing.T) (*Player, *FakeClock, *RecordingOutput) {
+	clock := NewFakeClock(epoch)
+	output := NewRecordingOutput(clock)
+
+	p, err := NewPlayer(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p.SetClock(clock), clock, output
+}
+
+func TestPlayer_Play(t *testing.T) {
+	p, clock, output := newTestPlayer(t)
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	assertRecords(t, []string{
+		"0s c0 04",
+		"0s 90 3c 64",
+		"500ms 80 3c 00",
+		"500ms 90 3e 64",
+		"1s 80 3e 00",
+		"1s 90 40 64",
+		"1.5s 80 40 00",
+	}, output.Records())
+
+	if p.Position() != 1440 {
+		t.Fatalf("expected: 1440 actual: %v", p.Position())
+	}
+	if clock.Now().Sub(epoch) != 1500*time.Millisecond {
+		t.Fatalf("expected: 1.5s actual: %v", clock.Now().Sub(epoch))
+	}
+}
+
+func TestPlayer_SetTempoScale(t *testing.T) {
+	p, _, output := newTestPlayer(t)
+
+	p.SetTempoScale(2)
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := output.Records()
+	if actual := rs[len(rs)-1].Time.Sub(epoch); actual != 750*time.Millisecond {
+		t.Fatalf("expected: 750ms actual: %v", actual)
+	}
+}
+
+func TestPlayer_Seek(t *testing.T) {
+	p, _, output := newTestPlayer(t)
+
+	if err := p.Seek(960); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Seek(1441); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The program change is chased.
+	assertRecords(t, []string{
+		"0s c0 04",
+		"0s 80 3e 00",
+		"0s 90 40 64",
+		"500ms 80 40 00",
+	}, output.Records())
+}
+
+// stopClock calls f once when the time reaches at.
+type stopClock struct {
+	*FakeClock
+	at time.Time
+	f  func()
+}
+
+func (c *stopClock) Sleep(d time.Duration) {
+	c.FakeClock.Sleep(d)
+
+	if c.f != nil && !c.Now().Before(c.at) {
+		f := c.f
+		c.f = nil
+		f()
+	}
+}
+
+func TestPlayer_Stop(t *testing.T) {
+	fake := NewFakeClock(epoch)
+	clock := &stopClock{FakeClock: fake, at: epoch.Add(700 * time.Millisecond)}
+	output := NewRecordingOutput(fake)
+
+	p, err := NewPlayer(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.SetClock(clock)
+	clock.f = func() {
+		if err := p.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := output.Records()
+
+	// 4 messages and all notes off to 16 channels.
+	if len(rs) != 4+16 {
+		t.Fatalf("expected: 20 records actual: %v records (%q)", len(rs), format(rs))
+	}
+	for channel, r := range rs[4:] {
+		expected := fmt.Sprintf("700ms b%x 7b 00", channel)
+		if actual := format([]Record{r})[0]; expected != actual {
+			t.Fatalf("expected: %v actual: %v", expected, actual)
+		}
+	}
+	if p.Playing() {
+		t.Fatalf("player must be stopped")
+	}
+	if p.Position() != 672 {
+		t.Fatalf("expected: 672 actual: %v", p.Position())
+	}
+}
+
+func TestPlayer_SetLoop(t *testing.T) {
+	fake := NewFakeClock(epoch)
+	clock := &stopClock{FakeClock: fake, at: epoch.Add(1250 * time.Millisecond)}
+	output := NewRecordingOutput(fake)
+
+	p, err := NewPlayer(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.SetLoop(480, 480); err == nil {
+		t.Fatalf("err must not be nil")
+	}
+	if err := p.SetLoop(480, 960); err != nil {
+		t.Fatal(err)
+	}
+
+	p.SetClock(clock)
+	clock.f = func() {
+		p.Stop()
+	}
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"0s c0 04",
+		"0s 90 3c 64",
+		"500ms 80 3c 00",
+		"500ms 90 3e 64",
+	}
+	for channel := 0; channel < 16; channel++ {
+		expected = append(expected, fmt.Sprintf("1s b%x 7b 00", channel))
+	}
+	expected = append(expected, "1s c0 04", "1s 80 3c 00", "1s 90 3e 64")
+	for channel := 0; channel < 16; channel++ {
+		expected = append(expected, fmt.Sprintf("1.25s b%x 7b 00", channel))
+	}
+
+	assertRecords(t, expected, output.Records())
+}
+
+type timedOutput struct {
+	*RecordingOutput
+	sent []time.Time
+	at   []time.Time
+}
+
+func (o *timedOutput) SendAt(message []byte, at time.Time) error {
+	o.sent = append(o.sent, o.clock.Now())
+	o.at = append(o.at, at)
+
+	return o.RecordingOutput.Send(message)
+}
+
+func TestPlayer_SetLookahead(t *testing.T) {
+	clock := NewFakeClock(epoch)
+	output := &timedOutput{RecordingOutput: NewRecordingOutput(clock)}
+
+	p, err := NewPlayer(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.SetClock(clock).SetLookahead(200 * time.Millisecond)
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []time.Duration{0, 0, 300, 300, 800, 800, 1300}
+	for i, e := range expected {
+		if actual := output.sent[i].Sub(epoch); e*time.Millisecond != actual {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e*time.Millisecond, i, actual)
+		}
+	}
+}
+
+func TestPlayer_Stop_lookahead(t *testing.T) {
+	fake := NewFakeClock(epoch)
+	clock := &stopClock{FakeClock: fake, at: epoch.Add(850 * time.Millisecond)}
+	output := &timedOutput{RecordingOutput: NewRecordingOutput(fake)}
+
+	p, err := NewPlayer(newTestMIDI(t), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.SetClock(clock).SetLookahead(200 * time.Millisecond)
+	clock.f = func() {
+		if err := p.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The messages at 1s have been sent ahead of time, so that all notes off follows them.
+	rs := output.Records()
+	if len(rs) != 6+16 {
+		t.Fatalf("expected: 22 records actual: %v records (%q)", len(rs), format(rs))
+	}
+	for i, at := range output.at[6:] {
+		if expected := epoch.Add(time.Second); !expected.Equal(at) {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected, i, at)
+		}
+	}
+	if p.Position() != 960 {
+		t.Fatalf("expected: 960 actual: %v", p.Position())
+	}
+
+	// Play resumes from the first message which isn't sent.
+	resumed := clock.Now()
+
+	if err := p.Play(); err != nil {
+		t.Fatal(err)
+	}
+
+	rs = output.Records()
+	if expected, actual := "80 40 00", fmt.Sprintf("% x", rs[len(rs)-1].Message); expected != actual {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+	if expected, actual := resumed.Add(500*time.Millisecond), output.at[len(output.at)-1]; !expected.Equal(actual) {
+		t.Fatalf("expected: %v actual: %v", expected, actual)
+	}
+}
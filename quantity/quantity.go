@@ -1,65 +1,153 @@
 package quantity
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+)
+
+// Max is the maximum value of variable length quantity.
+const Max = 0x0fffffff
 
 // Quantity represents variable length quantity which used in delta time and length of meta event.
+//
+// Quantity holds the value as uint32 and is encoded in the minimal form. The non-minimal encoding,
+// e.g. 0x80 0x00 for 0, is kept only when it's explicitly requested with SetValuePreserved or ParsePreserved,
+// so that the data can be serialized byte by byte as it was read.
 type Quantity struct {
-	value []byte
+	value uint32
+	raw   []byte
 }
 
-// SetUint32 sets value.
-func (q *Quantity) SetUint32(u32 uint32) error {
-	if u32 > 0x0fffffff {
-		return fmt.Errorf("midi: 0x%x is larger than 0xffffff", u32)
+// AppendVLQ appends the value encoded as variable length quantity to dst and returns the extended buffer.
+// The value larger than 0x0fffffff is truncated to 28 bits.
+func AppendVLQ(dst []byte, value uint32) []byte {
+	var buf [4]byte
+
+	value &= Max
+
+	i := len(buf) - 1
+	buf[i] = byte(value & 0x7f)
+
+	for value >>= 7; value > 0; value >>= 7 {
+		i--
+		buf[i] = byte(value&0x7f) | 0x80
 	}
 
-	q.value = []byte{}
-	mask := uint32(0xfe00000)
+	return append(dst, buf[i:]...)
+}
+
+// ReadVLQ reads variable length quantity from r.
+func ReadVLQ(r io.ByteReader) (uint32, error) {
+	var value uint32
 
-	for i := uint32(21); i >= 7; i -= 7 {
-		b := byte((u32&mask)>>i) + 0x80
-		mask = mask >> 7
-		if b > 0x80 {
-			q.value = append(q.value, byte(b))
+	for i := 0; i < 4; i++ {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return 0, fmt.Errorf("midi: missing next byte while parsing variable length quantity")
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		value = value<<7 | uint32(b&0x7f)
+
+		if b < 0x80 {
+			return value, nil
 		}
 	}
 
-	q.value = append(q.value, byte(u32&0x7f))
+	return 0, fmt.Errorf("midi: maximum value of variable length quantity is 0x0fff ffff")
+}
+
+// Len returns the length of minimal encoding of value in bytes.
+func Len(value uint32) int {
+	n := 1
+
+	for value >>= 7; value > 0; value >>= 7 {
+		n++
+	}
+
+	return n
+}
+
+// SetUint32 sets value. The non-minimal encoding is discarded.
+func (q *Quantity) SetUint32(u32 uint32) error {
+	if u32 > Max {
+		return fmt.Errorf("midi: 0x%x is larger than 0x%x", u32, Max)
+	}
+
+	q.value = u32
+	q.raw = nil
 
 	return nil
 }
 
 // Uint32 returns value as uint32.
 func (q *Quantity) Uint32() uint32 {
-	var u32 uint32
-
-	for i, b := range q.Value() {
-		u := uint32(b) & 0x7f
-		j := len(q.value) - i - 1
-		shift := (uint32(j * 8)) - uint32(j)
-		u = u << shift
-		u32 += u
+	return q.value
+}
+
+// SetValue sets value from the encoded bytes. The value is encoded in the minimal form.
+func (q *Quantity) SetValue(value []byte) error {
+	if err := q.SetValuePreserved(value); err != nil {
+		return err
 	}
 
-	return u32
+	q.Normalize()
+
+	return nil
 }
 
-// SetValue sets value.
-func (q *Quantity) SetValue(value []byte) error {
+// SetValuePreserved sets value from the encoded bytes. The encoding is kept if it's not minimal.
+func (q *Quantity) SetValuePreserved(value []byte) error {
 	if len(value) > 4 {
 		return fmt.Errorf("midi: maximum length of byte slice is 4, but len(value) = %v", len(value))
 	}
-	q.value = value
+
+	parsed, err := ParsePreserved(value)
+	if err != nil {
+		return err
+	}
+	if len(parsed.Value()) != len(value) {
+		return fmt.Errorf("midi: % x is not a variable length quantity", value)
+	}
+
+	*q = *parsed
 
 	return nil
 }
 
-// Value returns value.
+// Value returns the encoded bytes.
 func (q *Quantity) Value() []byte {
-	if q.value == nil {
-		q.value = make([]byte, 1)
+	return q.AppendTo(nil)
+}
+
+// Len returns the length of encoded bytes.
+func (q *Quantity) Len() int {
+	if q.raw != nil {
+		return len(q.raw)
 	}
-	return q.value
+
+	return Len(q.value)
+}
+
+// Minimal reports whether the value is encoded in the minimal form.
+func (q *Quantity) Minimal() bool {
+	return q.raw == nil
+}
+
+// Normalize discards the non-minimal encoding.
+func (q *Quantity) Normalize() {
+	q.raw = nil
+}
+
+// AppendTo appends the encoded bytes to dst and returns the extended buffer.
+func (q *Quantity) AppendTo(dst []byte) []byte {
+	if q.raw != nil {
+		return append(dst, q.raw...)
+	}
+
+	return AppendVLQ(dst, q.value)
 }
 
 // Serialize serializes value of variable length quantity.
@@ -67,13 +155,28 @@ func (q *Quantity) Serialize() []byte {
 	return q.Value()
 }
 
+// Parse parses variable length quantity at the beginning of stream. The value is encoded in the minimal form,
+// so that use ParsePreserved to know the number of bytes read.
 func Parse(stream []byte) (*Quantity, error) {
+	q, err := ParsePreserved(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	q.Normalize()
+
+	return q, nil
+}
+
+// ParsePreserved parses variable length quantity at the beginning of stream. The encoding is kept if it's not
+// minimal, so that Len returns the number of bytes read.
+func ParsePreserved(stream []byte) (*Quantity, error) {
 	if len(stream) == 0 {
 		return nil, fmt.Errorf("midi: stream is empty")
 	}
 
 	var i int
-	q := &Quantity{}
+	var value uint32
 
 	for {
 		if i > 3 {
@@ -82,14 +185,21 @@ func Parse(stream []byte) (*Quantity, error) {
 		if len(stream) < (i + 1) {
 			return nil, fmt.Errorf("midi: missing next byte while parsing variable length quantity")
 		}
+
+		value = value<<7 | uint32(stream[i]&0x7f)
+
 		if stream[i] < 0x80 {
 			break
 		}
 		i++
 	}
 
-	q.value = make([]byte, i+1)
-	copy(q.value, stream)
+	q := &Quantity{value: value}
+
+	if i+1 != Len(value) {
+		q.raw = make([]byte, i+1)
+		copy(q.raw, stream)
+	}
 
 	return q, nil
 }
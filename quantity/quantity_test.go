@@ -1,263 +1,173 @@
 package quantity
 
-import "testing"
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
 
 func TestQuantity_SetUint32(t *testing.T) {
 	q := &Quantity{}
 
-	var err error
-	var expected, actual []byte
-
-	err = q.SetUint32(0xffffffff)
+	err := q.SetUint32(0xffffffff)
 	if err == nil {
 		t.Fatalf("err must not be nil")
 	}
 
-	err = q.SetUint32(0xfffffff)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	expected = []byte{0xff, 0xff, 0xff, 0x7f}
-	actual = q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+	for value, expected := range map[uint32][]byte{
+		0x00:      {0x00},
+		0x7f:      {0x7f},
+		0x80:      {0x81, 0x00},
+		0xff:      {0x81, 0x7f},
+		0x2000:    {0xc0, 0x00},
+		0x3fff:    {0xff, 0x7f},
+		0x4000:    {0x81, 0x80, 0x00},
+		0x7fff:    {0x81, 0xff, 0x7f},
+		0x100000:  {0xc0, 0x80, 0x00},
+		0x1fffff:  {0xff, 0xff, 0x7f},
+		0x200000:  {0x81, 0x80, 0x80, 0x00},
+		0x3fffff:  {0x81, 0xff, 0xff, 0x7f},
+		0xfffffff: {0xff, 0xff, 0xff, 0x7f},
+	} {
+		if err := q.SetUint32(value); err != nil {
+			t.Fatal(err)
 		}
-	}
-
-	err = q.SetUint32(0x3fffff)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	expected = []byte{0x81, 0xff, 0xff, 0x7f}
-	actual = q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		if actual := q.Value(); !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected: % x actual: % x (value: 0x%x)", expected, actual, value)
 		}
-	}
-
-	err = q.SetUint32(0x1fffff)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	expected = []byte{0xff, 0xff, 0x7f}
-	actual = q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+		if q.Len() != len(expected) {
+			t.Fatalf("expected: %v actual: %v (value: 0x%x)", len(expected), q.Len(), value)
 		}
 	}
+}
 
-	err = q.SetUint32(0x7fff)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	expected = []byte{0x81, 0xff, 0x7f}
-	actual = q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+func TestQuantity_Uint32(t *testing.T) {
+	for _, c := range []struct {
+		value    []byte
+		expected uint32
+	}{
+		{[]byte{0xff, 0xff, 0xff, 0x7f}, 0xfffffff},
+		{[]byte{0x81, 0xff, 0xff, 0x7f}, 0x3fffff},
+		{[]byte{0xff, 0xff, 0x7f}, 0x1fffff},
+		{[]byte{0x81, 0x80, 0x00}, 0x4000},
+		{[]byte{0x81, 0xff, 0x7f}, 0x7fff},
+		{[]byte{0xff, 0x7f}, 0x3fff},
+		{[]byte{0x81, 0x7f}, 0xff},
+		{[]byte{0x7f}, 0x7f},
+		{[]byte{0x80, 0x80, 0x7f}, 0x7f},
+	} {
+		q := &Quantity{}
+
+		if err := q.SetValue(c.value); err != nil {
+			t.Fatal(err)
+		}
+		if actual := q.Uint32(); c.expected != actual {
+			t.Fatalf("expected: 0x%x actual: 0x%x", c.expected, actual)
 		}
 	}
+}
 
-	err = q.SetUint32(0x3fff)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	expected = []byte{0xff, 0x7f}
-	actual = q.value
+func TestQuantity_SetValue(t *testing.T) {
+	q := &Quantity{}
 
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	invalidValues := [][]byte{
+		{},
+		{0x80},
+		{0x12, 0x34},
+		{0x81, 0x80, 0x80, 0x80, 0x00},
 	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+	for _, value := range invalidValues {
+		if err := q.SetValue(value); err == nil {
+			t.Fatalf("err must not be nil (value=% x)", value)
 		}
 	}
 
-	err = q.SetUint32(0xff)
-	if err != nil {
+	if err := q.SetValue([]byte{0x80, 0x80, 0x00}); err != nil {
 		t.Fatal(err)
 	}
-
-	expected = []byte{0x81, 0x7f}
-	actual = q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
-		}
+	if !q.Minimal() || q.Uint32() != 0 {
+		t.Fatalf("non-minimal encoding must be normalized by default")
 	}
 
-	err = q.SetUint32(0x7f)
+	err := q.SetValuePreserved([]byte{0x80, 0x80, 0x00})
 	if err != nil {
 		t.Fatal(err)
 	}
-
-	expected = []byte{0x7f}
-	actual = q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
-		}
-	}
-}
-
-func TestQuantity_Uint32(t *testing.T) {
-	var expected, actual uint32
-
-	q := &Quantity{}
-
-	q.value = []byte{0xff, 0xff, 0xff, 0x7f}
-	expected = uint32(0xfffffff)
-	actual = q.Uint32()
-
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
+	if q.Minimal() {
+		t.Fatalf("non-minimal encoding must be kept")
 	}
 
-	q.value = []byte{0x81, 0xff, 0xff, 0x7f}
-	expected = uint32(0x3fffff)
-	actual = q.Uint32()
+	expected := []byte{0x80, 0x80, 0x00}
+	actual := q.Value()
 
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
 	}
 
-	q.value = []byte{0xff, 0xff, 0x7f}
-	expected = uint32(0x1fffff)
-	actual = q.Uint32()
+	q.Normalize()
 
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
-	}
-
-	q.value = []byte{0x81, 0xff, 0x7f}
-	expected = uint32(0x7fff)
-	actual = q.Uint32()
+	expected = []byte{0x00}
+	actual = q.Value()
 
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
 	}
+}
 
-	q.value = []byte{0xff, 0x7f}
-	expected = uint32(0x3fff)
-	actual = q.Uint32()
-
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
-	}
+func TestQuantity_Value(t *testing.T) {
+	q := Quantity{}
 
-	q.value = []byte{0x81, 0x7f}
-	expected = uint32(0xff)
-	actual = q.Uint32()
+	expected := []byte{0x0}
+	actual := q.Value()
 
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
 	}
 
-	q.value = []byte{0x7f}
-	expected = uint32(0x7f)
-	actual = q.Uint32()
+	copied := q
+	copied.SetUint32(0x80)
 
-	if expected != actual {
-		t.Fatalf("expected: %v actual: %v", expected, actual)
+	if q.Uint32() != 0 {
+		t.Fatalf("expected: 0 actual: %v", q.Uint32())
 	}
 }
 
-func TestQuantity_SetValue(t *testing.T) {
-	q := &Quantity{}
-
-	err := q.SetValue([]byte{0x12, 0x34, 0x56, 0x78, 0x90})
-	if err == nil {
-		t.Fatalf("err must not be nil")
-	}
-
-	err = q.SetValue([]byte{0x12, 0x34, 0x56, 0x78})
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	expected := []byte{0x12, 0x34, 0x56, 0x78}
-	actual := q.value
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
+func TestAppendVLQ(t *testing.T) {
+	for value, expected := range map[uint32][]byte{
+		0x00:       {0x00},
+		0x7f:       {0x7f},
+		0x80:       {0x81, 0x00},
+		0x2000:     {0xc0, 0x00},
+		0x4000:     {0x81, 0x80, 0x00},
+		0x100000:   {0xc0, 0x80, 0x00},
+		0x0fffffff: {0xff, 0xff, 0xff, 0x7f},
+	} {
+		if actual := AppendVLQ([]byte{0xff}, value); !reflect.DeepEqual(append([]byte{0xff}, expected...), actual) {
+			t.Fatalf("expected: %x actual: %x (value: 0x%x)", expected, actual[1:], value)
+		}
+		if Len(value) != len(expected) {
+			t.Fatalf("expected: %v actual: %v (value: 0x%x)", len(expected), Len(value), value)
 		}
 	}
 }
 
-func TestQuantity_Value(t *testing.T) {
-	q := &Quantity{}
+func TestReadVLQ(t *testing.T) {
+	r := bytes.NewReader([]byte{0x00, 0x81, 0x80, 0x00, 0x80, 0x7f, 0xff, 0xff, 0xff, 0x7f, 0x81})
 
-	expected := []byte{0x0}
-	actual := q.Value()
-
-	if actual == nil {
-		t.Fatalf("value must not be nil")
-	}
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
-	}
-	if expected[0] != actual[0] {
-		t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", 0, expected[0], 0, actual[0])
+	for _, expected := range []uint32{0x00, 0x4000, 0x7f, 0xfffffff} {
+		actual, err := ReadVLQ(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected != actual {
+			t.Fatalf("expected: 0x%x actual: 0x%x", expected, actual)
+		}
 	}
-
-	q = &Quantity{[]byte{0x12, 0x34, 0x56, 0x78}}
-
-	expected = []byte{0x12, 0x34, 0x56, 0x78}
-	actual = q.Value()
-
-	if len(expected) != len(actual) {
-		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	if _, err := ReadVLQ(r); err == nil {
+		t.Fatalf("err must not be nil")
 	}
-	for i, e := range expected {
-		a := actual[i]
-		if e != a {
-			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, a)
-		}
+	if _, err := ReadVLQ(bytes.NewReader([]byte{0x80, 0x80, 0x80, 0x80, 0x00})); err == nil {
+		t.Fatalf("err must not be nil")
 	}
 }
 
@@ -322,12 +232,12 @@ func TestParse(t *testing.T) {
 		},
 	}
 	for _, stream := range validStreams {
-		q, err := Parse(stream.value)
+		q, err := ParsePreserved(stream.value)
 		if err != nil {
 			t.Fatal(err)
 		}
 		expected := stream.expected
-		actual := q.value
+		actual := q.Value()
 		if len(expected) != len(actual) {
 			t.Fatalf("expected:%+v actual: %+v", expected, actual)
 		}
@@ -338,3 +248,23 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+func TestParsePreserved(t *testing.T) {
+	stream := []byte{0x80, 0x81, 0x00, 0xff}
+
+	q, err := Parse(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Minimal() || q.Len() != 2 || q.Uint32() != 0x80 {
+		t.Fatalf("expected: minimal encoding of 0x80 actual: % x", q.Value())
+	}
+
+	q, err = ParsePreserved(stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := stream[:3], q.Value(); !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Package recorder records live MIDI messages into MIDI data.
+*/
+package recorder
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+// Recorder records timestamped messages and converts them into MIDI data with a fixed tempo.
+//
+// The channel messages and the system exclusive messages are recorded. The system common and system real-time
+// messages, e.g. timing clock and active sensing, are discarded.
+type Recorder struct {
+	mu sync.Mutex
+
+	clock         func() time.Time
+	tempo         uint32
+	timeDivision  *midi.TimeDivision
+	splitChannels bool
+
+	start    time.Time
+	end      time.Time
+	messages []stream.Message
+}
+
+// Record records the message. The messages must be recorded in chronological order.
+func (r *Recorder) Record(m stream.Message) {
+	if m.Event == nil {
+		return
+	}
+
+	kind := m.Event.Kind()
+	if !kind.IsChannel() && kind != event.SystemExclusive {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.start.IsZero() {
+		r.start = m.Time
+	}
+
+	r.messages = append(r.messages, m)
+}
+
+// RecordReader records the messages decoded from the wire bytes read from rd until it reaches EOF.
+// The messages are timestamped with the clock of recorder.
+func (r *Recorder) RecordReader(rd io.Reader) error {
+	r.mu.Lock()
+	clock := r.clock
+	r.mu.Unlock()
+
+	d := stream.NewDecoder(rd).SetClock(clock)
+
+	for {
+		m, err := d.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		r.Record(m)
+	}
+}
+
+// RecordChannel records the messages received from messages until it's closed.
+func (r *Recorder) RecordChannel(messages <-chan stream.Message) {
+	for m := range messages {
+		r.Record(m)
+	}
+}
+
+// Stop marks the end of recording with the clock of recorder. The notes still held are closed at the end.
+// Without Stop, the recording ends at the last message.
+func (r *Recorder) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.end = r.clock()
+}
+
+// tick converts the wall-clock time into ticks from the start of recording.
+func (r *Recorder) tick(t time.Time, ticksPerQuarterNote uint16) uint32 {
+	elapsed := t.Sub(r.start)
+	if elapsed <= 0 {
+		return 0
+	}
+
+	// Round to the nearest tick.
+	unit := uint64(r.tempo) * uint64(time.Microsecond)
+	tick := (uint64(elapsed)*uint64(ticksPerQuarterNote) + unit/2) / unit
+
+	return uint32(tick)
+}
+
+// MIDI returns the recorded MIDI data. It can be called during recording to take the MIDI data recorded so far.
+//
+// By default, the result is format 0 which has a single track. With SetSplitChannels, the result is format 1 whose first
+// track holds the tempo and the system exclusive messages, followed by one track per channel in ascending order.
+func (r *Recorder) MIDI() (*midi.MIDI, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ticksPerQuarterNote, err := r.timeDivision.BPM()
+	if err != nil {
+		return nil, err
+	}
+	if r.tempo == 0 {
+		return nil, fmt.Errorf("midi: tempo must be greater than 0")
+	}
+
+	var end uint32
+
+	held := [16][128]int{}
+	conductor := []midi.TimedEvent{}
+	channels := [16][]midi.TimedEvent{}
+
+	tempo, err := event.NewSetTempoEvent(nil, r.tempo)
+	if err != nil {
+		return nil, err
+	}
+
+	conductor = append(conductor, midi.TimedEvent{Tick: 0, Event: tempo})
+
+	for _, m := range r.messages {
+		tick := r.tick(m.Time, ticksPerQuarterNote)
+		if tick > end {
+			end = tick
+		}
+
+		e := m.Event.Clone()
+		e.SetRunningStatus(false)
+
+		switch v := e.(type) {
+		case *event.NoteOnEvent:
+			if v.Velocity() > 0 {
+				held[v.Channel()][v.Note()]++
+			} else if held[v.Channel()][v.Note()] > 0 {
+				held[v.Channel()][v.Note()]--
+			}
+		case *event.NoteOffEvent:
+			if held[v.Channel()][v.Note()] > 0 {
+				held[v.Channel()][v.Note()]--
+			}
+		}
+
+		te := midi.TimedEvent{Tick: tick, Event: e}
+
+		if c, ok := e.(event.ChannelEvent); ok && r.splitChannels {
+			channels[c.Channel()] = append(channels[c.Channel()], te)
+		} else {
+			conductor = append(conductor, te)
+		}
+	}
+
+	if !r.end.IsZero() {
+		if tick := r.tick(r.end, ticksPerQuarterNote); tick > end {
+			end = tick
+		}
+	}
+
+	// Close the notes still held at the end of recording.
+	for channel := range held {
+		for note, count := range held[channel] {
+			for i := 0; i < count; i++ {
+				e, err := event.NewNoteOffEvent(nil, uint8(channel), constant.Note(note), 0)
+				if err != nil {
+					return nil, err
+				}
+				te := midi.TimedEvent{Tick: end, Event: e}
+
+				if r.splitChannels {
+					channels[channel] = append(channels[channel], te)
+				} else {
+					conductor = append(conductor, te)
+				}
+			}
+		}
+	}
+
+	groups := [][]midi.TimedEvent{conductor}
+
+	for _, tes := range channels {
+		if len(tes) > 0 {
+			groups = append(groups, tes)
+		}
+	}
+
+	m := &midi.MIDI{}
+	m.SetTimeDivision(&midi.TimeDivision{})
+	m.TimeDivision().SetBPM(int(ticksPerQuarterNote))
+
+	if r.splitChannels {
+		m.SetFormatType(1)
+	}
+
+	for _, tes := range groups {
+		e, err := event.NewEndOfTrackEvent(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		tes = append(tes, midi.TimedEvent{Tick: end, Event: e})
+		m.Tracks = append(m.Tracks, midi.NewTrackFromTimedEvents(tes))
+	}
+
+	return m, nil
+}
+
+// Reset discards the recorded messages and the end of recording, so that the next message starts a new recording.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.start = time.Time{}
+	r.end = time.Time{}
+	r.messages = nil
+}
+
+// SetStart sets the time of tick 0. The default is the time of the first recorded message.
+func (r *Recorder) SetStart(start time.Time) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.start = start
+
+	return r
+}
+
+// SetTempo sets the tempo in microseconds per quarter note used to convert the wall-clock time into ticks.
+// The default is midi.DefaultTempo, i.e. 120 BPM.
+func (r *Recorder) SetTempo(tempo uint32) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tempo = tempo
+
+	return r
+}
+
+// SetTimeDivision sets time division of the recorded MIDI data. The default is 480 ticks per quarter note.
+func (r *Recorder) SetTimeDivision(timeDivision *midi.TimeDivision) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timeDivision = timeDivision
+
+	return r
+}
+
+// SetSplitChannels sets whether the messages are recorded into one track per channel. The default is false.
+func (r *Recorder) SetSplitChannels(split bool) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.splitChannels = split
+
+	return r
+}
+
+// SetClock sets the function which returns the current time. It's used to timestamp the messages read by RecordReader
+// and the end of recording. The default is time.Now.
+func (r *Recorder) SetClock(clock func() time.Time) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clock = clock
+
+	return r
+}
+
+// NewRecorder returns Recorder.
+func NewRecorder() *Recorder {
+	timeDivision := &midi.TimeDivision{}
+	timeDivision.SetBPM(480)
+
+	return &Recorder{
+		clock:        time.Now,
+		tempo:        midi.DefaultTempo,
+		timeDivision: timeDivision,
+	}
+}
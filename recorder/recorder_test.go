@@ -0,0 +1,129 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	midi "github.com/moutend/go-midi"
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+var epoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func format(track *midi.Track) []string {
+	ss := []string{}
+
+	for _, te := range track.TimedEvents() {
+		ss = append(ss, fmt.Sprintf("%v % x", te.Tick, te.Event.Serialize()))
+	}
+
+	return ss
+}
+
+func assertTrack(t *testing.T, expected []string, track *midi.Track) {
+	t.Helper()
+
+	actual := format(track)
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v events actual: %v events (%q)", len(expected), len(actual), actual)
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expected[i], i, actual[i])
+		}
+	}
+}
+
+func TestRecorder_RecordReader(t *testing.T) {
+	times := []time.Duration{0, 250, 300, 500, 1000}
+	clock := func() time.Time {
+		d := times[0]
+		times = times[1:]
+		return epoch.Add(d * time.Millisecond)
+	}
+
+	r := NewRecorder().SetClock(clock)
+
+	input := []byte{
+		0x90, 0x3c, 0x64,
+		0x3e, 0x64, // Running status.
+		0xf8, // Timing clock is discarded.
+		0x80, 0x3c, 0x00,
+	}
+	if err := r.RecordReader(bytes.NewReader(input)); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Stop()
+
+	m, err := r.MIDI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tracks) != 1 || m.FormatType() != 0 {
+		t.Fatalf("expected: 1 track of format 0 actual: %v tracks of format %v", len(m.Tracks), m.FormatType())
+	}
+
+	// The note held at the end is closed.
+	assertTrack(t, []string{
+		"0 ff 51 03 07 a1 20",
+		"0 90 3c 64",
+		"240 90 3e 64",
+		"480 80 3c 00",
+		"960 80 3e 00",
+		"960 ff 2f 00",
+	}, m.Tracks[0])
+}
+
+func TestRecorder_SetSplitChannels(t *testing.T) {
+	timeDivision := &midi.TimeDivision{}
+	timeDivision.SetBPM(96)
+
+	r := NewRecorder().SetSplitChannels(true).SetTempo(1000000).SetTimeDivision(timeDivision).SetStart(epoch)
+
+	record := func(d time.Duration) func(event.Event, error) {
+		return func(e event.Event, err error) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Record(stream.Message{Time: epoch.Add(d * time.Millisecond), Event: e})
+		}
+	}
+
+	sysEx := &event.SystemExclusiveEvent{}
+	sysEx.SetData([]byte{0x7e, 0x7f, 0x09, 0x01, 0xf7})
+
+	record(0)(sysEx, nil)
+	record(500)(event.NewNoteOnEvent(nil, 1, constant.C3, 100))
+	record(750)(event.NewProgramChangeEvent(nil, 0, constant.ElectricPiano1))
+	record(1000)(event.NewNoteOnEvent(nil, 1, constant.C3, 0))
+	record(1000)(&event.ActiveSensingEvent{}, nil)
+
+	m, err := r.MIDI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Tracks) != 3 || m.FormatType() != 1 {
+		t.Fatalf("expected: 3 tracks of format 1 actual: %v tracks of format %v", len(m.Tracks), m.FormatType())
+	}
+
+	assertTrack(t, []string{
+		"0 ff 51 03 0f 42 40",
+		"0 f0 05 7e 7f 09 01 f7",
+		"96 ff 2f 00",
+	}, m.Tracks[0])
+	assertTrack(t, []string{
+		"72 c0 04",
+		"96 ff 2f 00",
+	}, m.Tracks[1])
+	assertTrack(t, []string{
+		"48 91 3c 64",
+		"96 91 3c 00",
+		"96 ff 2f 00",
+	}, m.Tracks[2])
+}
@@ -0,0 +1,163 @@
+package rtpmidi
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The commands of AppleMIDI session protocol.
+const (
+	commandInvitation = "IN"
+	commandAccepted   = "OK"
+	commandRejected   = "NO"
+	commandEnd        = "BY"
+	commandClockSync  = "CK"
+	commandFeedback   = "RS"
+)
+
+const (
+	signature       = 0xffff
+	protocolVersion = 2
+)
+
+// sessionPacket represents invitation, invitation accepted, invitation rejected and end session packets.
+type sessionPacket struct {
+	command string
+	token   uint32
+	ssrc    uint32
+	name    string
+}
+
+// clockSyncPacket represents clock synchronization packet. The timestamps are in 100 microseconds.
+type clockSyncPacket struct {
+	ssrc       uint32
+	count      uint8
+	timestamps [3]uint64
+}
+
+// feedbackPacket represents receiver feedback packet, which tells the sequence number of the last received packet.
+type feedbackPacket struct {
+	ssrc     uint32
+	sequence uint16
+}
+
+// isSessionPacket reports whether the data is AppleMIDI session protocol packet rather than RTP packet.
+func isSessionPacket(data []byte) bool {
+	return len(data) >= 4 && binary.BigEndian.Uint16(data) == signature
+}
+
+func appendHeader(dst []byte, command string) []byte {
+	return append(dst, 0xff, 0xff, command[0], command[1])
+}
+
+func (p *sessionPacket) AppendTo(dst []byte) []byte {
+	dst = appendHeader(dst, p.command)
+	dst = appendUint32(dst, protocolVersion)
+	dst = appendUint32(dst, p.token)
+	dst = appendUint32(dst, p.ssrc)
+
+	if p.command != commandEnd {
+		dst = append(dst, p.name...)
+		dst = append(dst, 0x00)
+	}
+
+	return dst
+}
+
+func (p *clockSyncPacket) AppendTo(dst []byte) []byte {
+	dst = appendHeader(dst, commandClockSync)
+	dst = appendUint32(dst, p.ssrc)
+	dst = append(dst, p.count, 0x00, 0x00, 0x00)
+
+	for _, timestamp := range p.timestamps {
+		dst = appendUint64(dst, timestamp)
+	}
+
+	return dst
+}
+
+func (p *feedbackPacket) AppendTo(dst []byte) []byte {
+	dst = appendHeader(dst, commandFeedback)
+	dst = appendUint32(dst, p.ssrc)
+	dst = appendUint16(dst, p.sequence)
+	dst = append(dst, 0x00, 0x00)
+
+	return dst
+}
+
+// parseSessionPacket parses AppleMIDI session protocol packet. It returns *sessionPacket, *clockSyncPacket or *feedbackPacket.
+func parseSessionPacket(data []byte) (interface{}, error) {
+	if !isSessionPacket(data) {
+		return nil, fmt.Errorf("midi: missing signature of AppleMIDI packet")
+	}
+
+	command := string(data[2:4])
+	body := data[4:]
+
+	switch command {
+	case commandInvitation, commandAccepted, commandRejected, commandEnd:
+		if len(body) < 12 {
+			return nil, fmt.Errorf("midi: %v packet is too short (%v bytes)", command, len(data))
+		}
+		if version := binary.BigEndian.Uint32(body); version != protocolVersion {
+			return nil, fmt.Errorf("midi: unsupported AppleMIDI protocol version %v", version)
+		}
+
+		p := &sessionPacket{
+			command: command,
+			token:   binary.BigEndian.Uint32(body[4:]),
+			ssrc:    binary.BigEndian.Uint32(body[8:]),
+		}
+
+		name := body[12:]
+		for i, b := range name {
+			if b == 0x00 {
+				name = name[:i]
+				break
+			}
+		}
+
+		p.name = string(name)
+
+		return p, nil
+	case commandClockSync:
+		if len(body) < 32 {
+			return nil, fmt.Errorf("midi: CK packet is too short (%v bytes)", len(data))
+		}
+
+		p := &clockSyncPacket{
+			ssrc:  binary.BigEndian.Uint32(body),
+			count: body[4],
+		}
+		for i := range p.timestamps {
+			p.timestamps[i] = binary.BigEndian.Uint64(body[8+i*8:])
+		}
+
+		return p, nil
+	case commandFeedback:
+		if len(body) < 6 {
+			return nil, fmt.Errorf("midi: RS packet is too short (%v bytes)", len(data))
+		}
+
+		p := &feedbackPacket{
+			ssrc:     binary.BigEndian.Uint32(body),
+			sequence: binary.BigEndian.Uint16(body[4:]),
+		}
+
+		return p, nil
+	}
+
+	return nil, fmt.Errorf("midi: unknown AppleMIDI command %q", command)
+}
+
+func appendUint16(dst []byte, v uint16) []byte {
+	return append(dst, byte(v>>8), byte(v))
+}
+
+func appendUint32(dst []byte, v uint32) []byte {
+	return append(dst, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	return appendUint32(appendUint32(dst, uint32(v>>32)), uint32(v))
+}
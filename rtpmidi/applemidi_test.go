@@ -0,0 +1,61 @@
+package rtpmidi
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseSessionPacket(t *testing.T) {
+	for _, expected := range []interface{}{
+		&sessionPacket{command: commandInvitation, token: 0x12345678, ssrc: 0x9abcdef0, name: "session"},
+		&sessionPacket{command: commandAccepted, token: 1, ssrc: 2, name: ""},
+		&sessionPacket{command: commandEnd, token: 0, ssrc: 3},
+		&clockSyncPacket{ssrc: 4, count: 1, timestamps: [3]uint64{1, 0x0102030405060708, 0}},
+		&feedbackPacket{ssrc: 5, sequence: 0xfffe},
+	} {
+		var data []byte
+
+		switch p := expected.(type) {
+		case *sessionPacket:
+			data = p.AppendTo(nil)
+		case *clockSyncPacket:
+			data = p.AppendTo(nil)
+		case *feedbackPacket:
+			data = p.AppendTo(nil)
+		}
+
+		actual, err := parseSessionPacket(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected: %+v actual: %+v", expected, actual)
+		}
+	}
+}
+
+func TestSessionPacket_AppendTo(t *testing.T) {
+	p := &sessionPacket{command: commandInvitation, token: 0x01020304, ssrc: 0x05060708, name: "a"}
+
+	expected := []byte{0xff, 0xff, 'I', 'N', 0x00, 0x00, 0x00, 0x02, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 'a', 0x00}
+	actual := p.AppendTo(nil)
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+}
+
+func TestParseSessionPacket_error(t *testing.T) {
+	for _, data := range [][]byte{
+		{0x80, 0x61, 0x00, 0x00},
+		{0xff, 0xff, 'I', 'N', 0x00},
+		{0xff, 0xff, 'C', 'K', 0x00},
+		{0xff, 0xff, 'X', 'X', 0x00},
+		{0xff, 0xff, 'I', 'N', 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	} {
+		if _, err := parseSessionPacket(data); err == nil {
+			t.Fatalf("expected error for % x", data)
+		}
+	}
+}
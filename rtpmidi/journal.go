@@ -0,0 +1,440 @@
+package rtpmidi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/moutend/go-midi/constant"
+)
+
+// The table of contents of channel journal.
+const (
+	chapterP = 0x80
+	chapterC = 0x40
+	chapterM = 0x20
+	chapterW = 0x10
+	chapterN = 0x08
+)
+
+// maxNoteLogs is the maximum number of note logs in chapter N.
+const maxNoteLogs = 127
+
+// journalEntry is a channel message sent in the packet of sequence number.
+type journalEntry struct {
+	sequence uint16
+	data     []byte
+
+	// bank is the bank select MSB and LSB in effect when the program change is sent, or -1 if it's unknown.
+	bank [2]int16
+}
+
+// channelJournal is the state of channel changed since the checkpoint.
+type channelJournal struct {
+	program     int16
+	bank        [2]int16
+	controllers map[uint8]uint8
+	pitch       int32
+	notesOn     map[uint8]uint8
+	notesOff    map[uint8]bool
+}
+
+func newChannelJournal() *channelJournal {
+	return &channelJournal{
+		program:     -1,
+		bank:        [2]int16{-1, -1},
+		controllers: map[uint8]uint8{},
+		pitch:       -1,
+		notesOn:     map[uint8]uint8{},
+		notesOff:    map[uint8]bool{},
+	}
+}
+
+func (j *channelJournal) apply(e journalEntry) {
+	data := e.data
+
+	switch data[0] & 0xf0 {
+	case constant.NoteOn:
+		if data[2] > 0 {
+			j.notesOn[data[1]] = data[2]
+			delete(j.notesOff, data[1])
+			break
+		}
+		fallthrough
+	case constant.NoteOff:
+		delete(j.notesOn, data[1])
+		j.notesOff[data[1]] = true
+	case constant.Controller:
+		j.controllers[data[1]] = data[2]
+	case constant.ProgramChange:
+		j.program = int16(data[1])
+		j.bank = e.bank
+	case constant.PitchBend:
+		j.pitch = int32(data[2])<<7 | int32(data[1])
+	}
+}
+
+func (j *channelJournal) AppendTo(dst []byte, channel uint8) []byte {
+	var toc byte
+	var body []byte
+
+	if j.program >= 0 {
+		toc |= chapterP
+
+		var b byte
+		var msb, lsb byte
+		if j.bank[0] >= 0 && j.bank[1] >= 0 {
+			b, msb, lsb = 0x80, byte(j.bank[0]), byte(j.bank[1])
+		}
+
+		body = append(body, byte(j.program), b|msb, lsb)
+	}
+	if len(j.controllers) > 0 {
+		toc |= chapterC
+
+		numbers := make([]int, 0, len(j.controllers))
+		for number := range j.controllers {
+			numbers = append(numbers, int(number))
+		}
+		sort.Ints(numbers)
+
+		body = append(body, byte(len(numbers)-1))
+		for _, number := range numbers {
+			body = append(body, byte(number), j.controllers[uint8(number)])
+		}
+	}
+	if j.pitch >= 0 {
+		toc |= chapterW
+		body = append(body, byte(j.pitch&0x7f), byte(j.pitch>>7))
+	}
+	if len(j.notesOn) > 0 || len(j.notesOff) > 0 {
+		toc |= chapterN
+		body = j.appendChapterN(body)
+	}
+
+	length := 3 + len(body)
+
+	dst = append(dst, channel<<3|byte(length>>8&0x03), byte(length), toc)
+	dst = append(dst, body...)
+
+	return dst
+}
+
+func (j *channelJournal) appendChapterN(dst []byte) []byte {
+	notes := make([]int, 0, len(j.notesOn))
+	for note := range j.notesOn {
+		notes = append(notes, int(note))
+	}
+	sort.Ints(notes)
+
+	if len(notes) > maxNoteLogs {
+		notes = notes[len(notes)-maxNoteLogs:]
+	}
+
+	low, high := 15, 0
+	for note := range j.notesOff {
+		if int(note)/8 < low {
+			low = int(note) / 8
+		}
+		if int(note)/8 > high {
+			high = int(note) / 8
+		}
+	}
+
+	// LOW 15 and HIGH 0 with 127 note logs means 128 note logs, so that the empty bit field is encoded as LOW 1.
+	if low > high {
+		low, high = 1, 0
+	}
+
+	dst = append(dst, byte(len(notes)), byte(low<<4|high))
+
+	for _, note := range notes {
+		// The Y bit asks the receiver to play the note.
+		dst = append(dst, byte(note), 0x80|j.notesOn[uint8(note)])
+	}
+	if low > high {
+		return dst
+	}
+
+	bits := make([]byte, high-low+1)
+	for note := range j.notesOff {
+		bits[int(note)/8-low] |= 0x80 >> (note % 8)
+	}
+
+	return append(dst, bits...)
+}
+
+// encodeJournal encodes the recovery journal of the entries. It returns nil if there is nothing to journal.
+func encodeJournal(checkpoint uint16, entries []journalEntry) []byte {
+	channels := map[uint8]*channelJournal{}
+
+	for _, e := range entries {
+		channel := e.data[0] & 0x0f
+		if channels[channel] == nil {
+			channels[channel] = newChannelJournal()
+		}
+		channels[channel].apply(e)
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	// A flag tells that the channel journals follow.
+	journal := []byte{0x20 | byte(len(channels)-1), byte(checkpoint >> 8), byte(checkpoint)}
+
+	for channel := uint8(0); channel < 16; channel++ {
+		if j, ok := channels[channel]; ok {
+			journal = j.AppendTo(journal, channel)
+		}
+	}
+
+	return journal
+}
+
+// receiverChannel is the state of channel known to receiver.
+type receiverChannel struct {
+	program     int16
+	bank        [2]int16
+	controllers [128]int16
+	pitch       int32
+	notes       [128]bool
+}
+
+// receiver keeps the state of channels to repair them with the recovery journal.
+type receiver struct {
+	channels [16]receiverChannel
+	started  bool
+	sequence uint16
+}
+
+func newReceiver() *receiver {
+	r := &receiver{}
+
+	for i := range r.channels {
+		c := &r.channels[i]
+		c.program = -1
+		c.bank = [2]int16{-1, -1}
+		c.pitch = -1
+		for j := range c.controllers {
+			c.controllers[j] = -1
+		}
+	}
+
+	return r
+}
+
+// apply updates the state with the command.
+func (r *receiver) apply(data []byte) {
+	if data[0] >= 0xf0 {
+		return
+	}
+
+	c := &r.channels[data[0]&0x0f]
+
+	switch data[0] & 0xf0 {
+	case constant.NoteOn:
+		c.notes[data[1]] = data[2] > 0
+	case constant.NoteOff:
+		c.notes[data[1]] = false
+	case constant.Controller:
+		c.controllers[data[1]] = int16(data[2])
+		switch constant.Control(data[1]) {
+		case constant.BankSelect:
+			c.bank[0] = int16(data[2])
+		case constant.BankSelectLSB:
+			c.bank[1] = int16(data[2])
+		}
+	case constant.ProgramChange:
+		c.program = int16(data[1])
+	case constant.PitchBend:
+		c.pitch = int32(data[2])<<7 | int32(data[1])
+	}
+}
+
+// accept checks the sequence number of the packet. It returns false for the duplicated or the reordered packet,
+// and true with the number of lost packets otherwise.
+func (r *receiver) accept(sequence uint16) (bool, int) {
+	if !r.started {
+		r.started = true
+		r.sequence = sequence
+		return true, 0
+	}
+
+	gap := int16(sequence - r.sequence)
+	if gap <= 0 {
+		return false, 0
+	}
+
+	r.sequence = sequence
+
+	return true, int(gap) - 1
+}
+
+// recover returns the commands which repair the state with the recovery journal.
+func (r *receiver) recover(journal []byte) ([][]byte, error) {
+	if len(journal) < 3 {
+		return nil, fmt.Errorf("midi: recovery journal is too short (%v bytes)", len(journal))
+	}
+
+	header := journal[0]
+	rest := journal[3:]
+
+	// Skip the system journal.
+	if header&0x40 != 0 {
+		if len(rest) < 2 {
+			return nil, fmt.Errorf("midi: system journal is too short")
+		}
+
+		length := int(rest[0]&0x03)<<8 | int(rest[1])
+		if length < 2 || len(rest) < length {
+			return nil, fmt.Errorf("midi: invalid length of system journal (%v)", length)
+		}
+		rest = rest[length:]
+	}
+	if header&0x20 == 0 {
+		return nil, nil
+	}
+
+	var commands [][]byte
+
+	for i := 0; i <= int(header&0x0f); i++ {
+		if len(rest) < 3 {
+			return nil, fmt.Errorf("midi: channel journal is too short")
+		}
+
+		channel := rest[0] >> 3 & 0x0f
+		length := int(rest[0]&0x03)<<8 | int(rest[1])
+		if length < 3 || len(rest) < length {
+			return nil, fmt.Errorf("midi: invalid length of channel journal (%v)", length)
+		}
+
+		cs, err := r.recoverChannel(channel, rest[2], rest[3:length])
+		if err != nil {
+			return nil, err
+		}
+
+		commands = append(commands, cs...)
+		rest = rest[length:]
+	}
+
+	return commands, nil
+}
+
+// recoverChannel returns the commands which repair the channel with chapters P, C, W and N.
+// The chapters after chapter M are ignored because chapter M is not supported.
+func (r *receiver) recoverChannel(channel, toc byte, body []byte) ([][]byte, error) {
+	c := &r.channels[channel]
+	short := fmt.Errorf("midi: chapter of channel journal is too short")
+
+	var commands [][]byte
+
+	// The state is updated as soon as the command is recovered, so that the later chapters see it.
+	emit := func(data ...byte) {
+		r.apply(data)
+		commands = append(commands, data)
+	}
+	controller := func(number, value byte) {
+		emit(constant.Controller|channel, number, value)
+	}
+
+	if toc&chapterP != 0 {
+		if len(body) < 3 {
+			return nil, short
+		}
+
+		program, hasBank := body[0]&0x7f, body[1]&0x80 != 0
+		msb, lsb := body[1]&0x7f, body[2]&0x7f
+		bankChanged := hasBank && (c.bank[0] != int16(msb) || c.bank[1] != int16(lsb))
+
+		if c.program != int16(program) || bankChanged {
+			if hasBank {
+				controller(byte(constant.BankSelect), msb)
+				controller(byte(constant.BankSelectLSB), lsb)
+			}
+			emit(constant.ProgramChange|channel, program)
+		}
+
+		body = body[3:]
+	}
+	if toc&chapterC != 0 {
+		if len(body) < 1 {
+			return nil, short
+		}
+
+		n := int(body[0]&0x7f) + 1
+		if len(body) < 1+n*2 {
+			return nil, short
+		}
+
+		for i := 0; i < n; i++ {
+			number, value := body[1+i*2]&0x7f, body[2+i*2]
+
+			// The alternative encoding for toggle and count tools is not supported.
+			if value&0x80 != 0 {
+				continue
+			}
+			if c.controllers[number] != int16(value) {
+				controller(number, value)
+			}
+		}
+
+		body = body[1+n*2:]
+	}
+	if toc&chapterM != 0 {
+		return commands, nil
+	}
+	if toc&chapterW != 0 {
+		if len(body) < 2 {
+			return nil, short
+		}
+
+		lsb, msb := body[0]&0x7f, body[1]&0x7f
+		if c.pitch != int32(msb)<<7|int32(lsb) {
+			emit(constant.PitchBend|channel, lsb, msb)
+		}
+
+		body = body[2:]
+	}
+	if toc&chapterN != 0 {
+		if len(body) < 2 {
+			return nil, short
+		}
+
+		n, low, high := int(body[0]&0x7f), int(body[1]>>4), int(body[1]&0x0f)
+		if n == 127 && low == 15 && high == 0 {
+			n = 128
+		}
+
+		body = body[2:]
+		if len(body) < n*2 {
+			return nil, short
+		}
+
+		for i := 0; i < n; i++ {
+			note, velocity := body[i*2]&0x7f, body[i*2+1]&0x7f
+			play := body[i*2+1]&0x80 != 0
+
+			if play && velocity > 0 && !c.notes[note] {
+				emit(constant.NoteOn|channel, note, velocity)
+			}
+		}
+
+		body = body[n*2:]
+
+		if low <= high {
+			if len(body) < high-low+1 {
+				return nil, short
+			}
+
+			for i := 0; i <= high-low; i++ {
+				for bit := 0; bit < 8; bit++ {
+					note := (low+i)*8 + bit
+					if body[i]&(0x80>>uint(bit)) != 0 && c.notes[note] {
+						emit(constant.NoteOff|channel, byte(note), 0x00)
+					}
+				}
+			}
+		}
+	}
+
+	return commands, nil
+}
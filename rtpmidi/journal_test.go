@@ -0,0 +1,94 @@
+package rtpmidi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReceiver_recover(t *testing.T) {
+	r := newReceiver()
+
+	// The receiver knows that C3 and D3 are playing.
+	r.apply([]byte{0x90, 0x3c, 0x40})
+	r.apply([]byte{0x90, 0x3e, 0x40})
+	r.apply([]byte{0xb0, 0x07, 0x64})
+
+	// The sender sent these messages in the lost packets.
+	entries := []journalEntry{
+		{sequence: 10, data: []byte{0xb0, 0x00, 0x01}, bank: [2]int16{-1, -1}},
+		{sequence: 10, data: []byte{0xb0, 0x20, 0x02}, bank: [2]int16{1, -1}},
+		{sequence: 11, data: []byte{0xc0, 0x05}, bank: [2]int16{1, 2}},
+		{sequence: 11, data: []byte{0xb0, 0x07, 0x64}, bank: [2]int16{1, 2}},
+		{sequence: 11, data: []byte{0xe0, 0x00, 0x50}, bank: [2]int16{1, 2}},
+		{sequence: 12, data: []byte{0x80, 0x3c, 0x00}, bank: [2]int16{1, 2}},
+		{sequence: 12, data: []byte{0x91, 0x40, 0x70}, bank: [2]int16{-1, -1}},
+	}
+
+	journal := encodeJournal(10, entries)
+
+	actual, err := r.recover(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]byte{
+		{0xb0, 0x00, 0x01},
+		{0xb0, 0x20, 0x02},
+		{0xc0, 0x05},
+		{0xe0, 0x00, 0x50},
+		{0x80, 0x3c, 0x00},
+		{0x91, 0x40, 0x70},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+
+	// The state is repaired, so that the same journal recovers nothing.
+	actual, err = r.recover(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actual) != 0 {
+		t.Fatalf("expected: [] actual: % x", actual)
+	}
+}
+
+func TestEncodeJournal(t *testing.T) {
+	if journal := encodeJournal(1, nil); journal != nil {
+		t.Fatalf("expected: nil actual: % x", journal)
+	}
+
+	journal := encodeJournal(0x0102, []journalEntry{
+		{sequence: 0x0102, data: []byte{0x92, 0x3c, 0x40}},
+	})
+
+	expected := []byte{
+		0x20, 0x01, 0x02,
+		0x10, 0x07, chapterN,
+		0x01, 0x10, 0x3c, 0xc0,
+	}
+	if !reflect.DeepEqual(expected, journal) {
+		t.Fatalf("expected: % x actual: % x", expected, journal)
+	}
+}
+
+func TestReceiver_accept(t *testing.T) {
+	r := newReceiver()
+
+	for _, c := range []struct {
+		sequence uint16
+		accepted bool
+		lost     int
+	}{
+		{0xfffe, true, 0},
+		{0xffff, true, 0},
+		{0xffff, false, 0},
+		{0x0002, true, 2},
+		{0x0001, false, 0},
+	} {
+		accepted, lost := r.accept(c.sequence)
+		if accepted != c.accepted || lost != c.lost {
+			t.Fatalf("sequence 0x%x expected: %v %v actual: %v %v", c.sequence, c.accepted, c.lost, accepted, lost)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package rtpmidi
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/quantity"
+)
+
+// payloadType is the dynamic RTP payload type used by AppleMIDI.
+const payloadType = 0x61
+
+// command represents a MIDI command in the MIDI list of RTP-MIDI payload.
+type command struct {
+	// delta is the delta time from the previous command in the timestamp units.
+	delta uint32
+
+	// data is a complete MIDI message including its status byte.
+	data []byte
+}
+
+// rtpPacket represents RTP packet whose payload is RTP-MIDI defined by RFC 6295.
+type rtpPacket struct {
+	sequence  uint16
+	timestamp uint32
+	ssrc      uint32
+	commands  []command
+	journal   []byte
+}
+
+func (p *rtpPacket) AppendTo(dst []byte) ([]byte, error) {
+	var list []byte
+
+	for i, c := range p.commands {
+		if i > 0 || c.delta > 0 {
+			list = quantity.AppendVLQ(list, c.delta)
+		}
+		list = append(list, c.data...)
+	}
+	if len(list) > 0x0fff {
+		return nil, fmt.Errorf("midi: MIDI list of RTP-MIDI payload is too long (%v bytes)", len(list))
+	}
+
+	var flags byte
+
+	if p.journal != nil {
+		flags |= 0x40
+	}
+	if len(p.commands) > 0 && p.commands[0].delta > 0 {
+		flags |= 0x20
+	}
+
+	dst = append(dst, 0x80, payloadType)
+	dst = appendUint16(dst, p.sequence)
+	dst = appendUint32(dst, p.timestamp)
+	dst = appendUint32(dst, p.ssrc)
+
+	if len(list) > 0x0f {
+		dst = append(dst, 0x80|flags|byte(len(list)>>8), byte(len(list)))
+	} else {
+		dst = append(dst, flags|byte(len(list)))
+	}
+
+	dst = append(dst, list...)
+	dst = append(dst, p.journal...)
+
+	return dst, nil
+}
+
+// commandLength returns the length of MIDI command at the beginning of data, which doesn't contain the status byte.
+func commandLength(status byte, data []byte) (int, error) {
+	switch {
+	case status < 0xf0:
+		switch status & 0xf0 {
+		case constant.ProgramChange, constant.ChannelAfterTouch:
+			return 1, nil
+		}
+		return 2, nil
+	case status == constant.SystemExclusive:
+		for i, b := range data {
+			if b == constant.EndOfExclusive {
+				return i + 1, nil
+			}
+		}
+		return 0, fmt.Errorf("midi: missing end of system exclusive in RTP-MIDI payload")
+	case status == constant.MTCQuarterFrame, status == constant.SongSelect:
+		return 1, nil
+	case status == constant.SongPositionPointer:
+		return 2, nil
+	}
+
+	return 0, nil
+}
+
+// parseRTPPacket parses RTP packet of RTP-MIDI payload.
+func parseRTPPacket(data []byte) (*rtpPacket, error) {
+	if len(data) < 13 {
+		return nil, fmt.Errorf("midi: RTP packet is too short (%v bytes)", len(data))
+	}
+	if data[0]>>6 != 2 {
+		return nil, fmt.Errorf("midi: unsupported RTP version %v", data[0]>>6)
+	}
+	if data[1]&0x7f != payloadType {
+		return nil, fmt.Errorf("midi: unsupported RTP payload type 0x%x", data[1]&0x7f)
+	}
+
+	p := &rtpPacket{
+		sequence:  binary.BigEndian.Uint16(data[2:]),
+		timestamp: binary.BigEndian.Uint32(data[4:]),
+		ssrc:      binary.BigEndian.Uint32(data[8:]),
+	}
+
+	// Skip the contributing sources.
+	payload := data[12+int(data[0]&0x0f)*4:]
+	if len(payload) < 1 {
+		return nil, fmt.Errorf("midi: missing RTP-MIDI payload")
+	}
+
+	flags := payload[0]
+	size := int(flags & 0x0f)
+	payload = payload[1:]
+
+	if flags&0x80 != 0 {
+		if len(payload) < 1 {
+			return nil, fmt.Errorf("midi: missing length of MIDI list")
+		}
+		size = size<<8 | int(payload[0])
+		payload = payload[1:]
+	}
+	if len(payload) < size {
+		return nil, fmt.Errorf("midi: MIDI list is shorter than its length (%v < %v)", len(payload), size)
+	}
+
+	list := payload[:size]
+	hasDelta := flags&0x20 != 0
+
+	var running byte
+
+	for len(list) > 0 {
+		var c command
+
+		if hasDelta {
+			q, err := quantity.ParsePreserved(list)
+			if err != nil {
+				return nil, err
+			}
+			c.delta = q.Uint32()
+			list = list[q.Len():]
+		}
+
+		hasDelta = true
+
+		if len(list) == 0 {
+			return nil, fmt.Errorf("midi: missing MIDI command after delta time")
+		}
+
+		status := list[0]
+
+		if status >= 0x80 {
+			list = list[1:]
+		} else if running == 0 {
+			return nil, fmt.Errorf("midi: missing status byte of MIDI command")
+		} else {
+			status = running
+		}
+
+		n, err := commandLength(status, list)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) < n {
+			return nil, fmt.Errorf("midi: MIDI command 0x%x is truncated", status)
+		}
+
+		switch {
+		case status < 0xf0:
+			running = status
+		case status < 0xf8:
+			running = 0
+		}
+
+		c.data = append([]byte{status}, list[:n]...)
+		list = list[n:]
+		p.commands = append(p.commands, c)
+	}
+
+	if flags&0x40 != 0 {
+		p.journal = append([]byte{}, payload[size:]...)
+	}
+
+	return p, nil
+}
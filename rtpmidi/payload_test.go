@@ -0,0 +1,108 @@
+package rtpmidi
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestParseRTPPacket(t *testing.T) {
+	expected := &rtpPacket{
+		sequence:  0x1234,
+		timestamp: 0x56789abc,
+		ssrc:      0xdef01234,
+		commands: []command{
+			{delta: 0, data: []byte{0x90, 0x3c, 0x40}},
+			{delta: 10, data: []byte{0xc0, 0x05}},
+			{delta: 0x200, data: []byte{0xf0, 0x7e, 0x7f, 0xf7}},
+			{delta: 0, data: []byte{0xf8}},
+			{delta: 1, data: []byte{0xe0, 0x00, 0x40}},
+		},
+		journal: []byte{0x20, 0x12, 0x30, 0x00, 0x05, 0x80, 0x05, 0x00, 0x00},
+	}
+
+	data, err := expected.AppendTo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The long header is used because MIDI list is longer than 15 bytes.
+	if data[12]&0xc0 != 0xc0 {
+		t.Fatalf("expected: B and J flags actual: 0x%x", data[12])
+	}
+
+	actual, err := parseRTPPacket(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: %+v actual: %+v", expected, actual)
+	}
+}
+
+func TestRTPPacket_AppendTo(t *testing.T) {
+	p := &rtpPacket{
+		sequence:  1,
+		timestamp: 2,
+		ssrc:      3,
+		commands: []command{
+			{delta: 4, data: []byte{0x90, 0x3c, 0x40}},
+		},
+	}
+
+	expected := []byte{
+		0x80, 0x61, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x02,
+		0x00, 0x00, 0x00, 0x03,
+		0x24, 0x04, 0x90, 0x3c, 0x40,
+	}
+	actual, err := p.AppendTo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+}
+
+func TestParseRTPPacket_runningStatus(t *testing.T) {
+	data := []byte{
+		0x80, 0x61, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x01,
+		0x08,
+		0x90, 0x3c, 0x40,
+		0x00, 0xf8,
+		0x00, 0x3e, 0x40,
+	}
+
+	p, err := parseRTPPacket(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []command{
+		{data: []byte{0x90, 0x3c, 0x40}},
+		{data: []byte{0xf8}},
+		{data: []byte{0x90, 0x3e, 0x40}},
+	}
+	if !reflect.DeepEqual(expected, p.commands) {
+		t.Fatalf("expected: %+v actual: %+v", expected, p.commands)
+	}
+	if p.journal != nil {
+		t.Fatalf("expected: nil actual: % x", p.journal)
+	}
+}
+
+func TestParseRTPPacket_error(t *testing.T) {
+	for _, data := range [][]byte{
+		{0x80, 0x61, 0x00, 0x01},
+		{0x80, 0x21, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00},
+		{0x80, 0x61, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x03, 0x90, 0x3c},
+		{0x80, 0x61, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x3c, 0x40},
+	} {
+		if _, err := parseRTPPacket(data); err == nil {
+			t.Fatalf("expected error for % x", data)
+		}
+	}
+}
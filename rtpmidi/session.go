@@ -0,0 +1,735 @@
+/*
+Package rtpmidi sends and receives MIDI messages over the network with RTP-MIDI defined by RFC 6295.
+
+Session implements the AppleMIDI session protocol, i.e. invitation, clock synchronization and receiver feedback,
+on a pair of UDP ports. The control port is the given port and the data port is the next one. The lost packets are
+repaired with the recovery journal, which journals program changes, controllers, pitch bends and notes.
+*/
+package rtpmidi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+	"github.com/moutend/go-midi/stream"
+)
+
+const (
+	// DefaultTimeout is the default timeout of invitation and clock synchronization.
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultFeedbackInterval is the default minimum interval of receiver feedback sent to each peer.
+	DefaultFeedbackInterval = time.Second
+
+	// timestampUnit is the unit of RTP timestamp and the timestamps of clock synchronization.
+	timestampUnit = 100 * time.Microsecond
+
+	// maxHistory is the maximum number of commands kept for the recovery journal.
+	maxHistory = 1024
+
+	// retryInterval is the interval of resending invitation and clock synchronization.
+	retryInterval = 500 * time.Millisecond
+)
+
+// Peer represents a remote participant of session.
+type Peer struct {
+	session *Session
+
+	name        string
+	ssrc        uint32
+	controlAddr *net.UDPAddr
+	dataAddr    *net.UDPAddr
+
+	latency time.Duration
+	offset  time.Duration
+
+	acked        bool
+	ack          uint16
+	receiver     *receiver
+	lastFeedback time.Time
+}
+
+// Name returns the name of peer.
+func (p *Peer) Name() string {
+	return p.name
+}
+
+// SSRC returns the synchronization source identifier of peer.
+func (p *Peer) SSRC() uint32 {
+	return p.ssrc
+}
+
+// Latency returns the round trip time measured by the last clock synchronization.
+func (p *Peer) Latency() time.Duration {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	return p.latency
+}
+
+// Offset returns the difference of the clock of peer from the one of session measured by the last clock synchronization.
+func (p *Peer) Offset() time.Duration {
+	p.session.mu.Lock()
+	defer p.session.mu.Unlock()
+
+	return p.offset
+}
+
+// Session represents RTP-MIDI session. The methods are safe for concurrent use.
+type Session struct {
+	mu sync.Mutex
+
+	name    string
+	ssrc    uint32
+	control *net.UDPConn
+	data    *net.UDPConn
+	start   time.Time
+
+	timeout          time.Duration
+	feedbackInterval time.Duration
+	accept           func(name string, addr net.Addr) bool
+
+	peers   map[uint32]*Peer
+	pending map[uint32]*Peer
+	replies map[uint32]chan *sessionPacket
+	syncs   map[uint32]chan struct{}
+
+	// The recovery journal covers the packets from checkpoint to sequence.
+	sequence   uint16
+	checkpoint uint16
+	history    []journalEntry
+	bank       [16][2]int16
+
+	messages chan stream.Message
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// randomUint32 returns random number used for SSRC, initiator token and initial sequence number.
+func randomUint32() uint32 {
+	var b [4]byte
+
+	rand.Read(b[:])
+
+	return binary.BigEndian.Uint32(b[:])
+}
+
+// listenPair listens on the control port and the data port next to it.
+// When the port is 0, it retries with another port until the pair of ports is available.
+func listenPair(addr *net.UDPAddr) (*net.UDPConn, *net.UDPConn, error) {
+	for attempt := 0; attempt < 16; attempt++ {
+		control, err := net.ListenUDP("udp", addr)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		local := control.LocalAddr().(*net.UDPAddr)
+		data, err := net.ListenUDP("udp", &net.UDPAddr{IP: addr.IP, Port: local.Port + 1, Zone: addr.Zone})
+		if err == nil {
+			return control, data, nil
+		}
+
+		control.Close()
+
+		if addr.Port != 0 {
+			return nil, nil, err
+		}
+	}
+
+	return nil, nil, fmt.Errorf("midi: failed to find a pair of UDP ports")
+}
+
+// now returns the timestamp of session in 100 microseconds.
+func (s *Session) now() uint64 {
+	return uint64(time.Since(s.start) / timestampUnit)
+}
+
+// Addr returns the address of control port. The data port is the next port.
+func (s *Session) Addr() *net.UDPAddr {
+	return s.control.LocalAddr().(*net.UDPAddr)
+}
+
+// Name returns the name of session.
+func (s *Session) Name() string {
+	return s.name
+}
+
+// SSRC returns the synchronization source identifier of session.
+func (s *Session) SSRC() uint32 {
+	return s.ssrc
+}
+
+// Peers returns the participants of session ordered by SSRC.
+func (s *Session) Peers() []*Peer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peers := make([]*Peer, 0, len(s.peers))
+	for _, p := range s.peers {
+		peers = append(peers, p)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].ssrc < peers[j].ssrc
+	})
+
+	return peers
+}
+
+func (s *Session) write(conn *net.UDPConn, addr *net.UDPAddr, packet []byte) error {
+	_, err := conn.WriteToUDP(packet, addr)
+
+	return err
+}
+
+// request sends the invitation repeatedly until the reply arrives or it times out.
+func (s *Session) request(conn *net.UDPConn, addr *net.UDPAddr, token uint32) (*sessionPacket, error) {
+	reply := make(chan *sessionPacket, 1)
+
+	s.mu.Lock()
+	s.replies[token] = reply
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.replies, token)
+		s.mu.Unlock()
+	}()
+
+	invitation := &sessionPacket{command: commandInvitation, token: token, ssrc: s.ssrc, name: s.name}
+	timeout := time.After(s.timeout)
+
+	for {
+		if err := s.write(conn, addr, invitation.AppendTo(nil)); err != nil {
+			return nil, err
+		}
+
+		select {
+		case p := <-reply:
+			if p.command == commandRejected {
+				return nil, fmt.Errorf("midi: invitation is rejected by %v", addr)
+			}
+			return p, nil
+		case <-time.After(retryInterval):
+		case <-timeout:
+			return nil, fmt.Errorf("midi: invitation to %v timed out", addr)
+		case <-s.done:
+			return nil, fmt.Errorf("midi: session is closed")
+		}
+	}
+}
+
+// Invite invites the session at the address of control port, then synchronizes the clock with it.
+func (s *Session) Invite(address string) (*Peer, error) {
+	controlAddr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	dataAddr := &net.UDPAddr{IP: controlAddr.IP, Port: controlAddr.Port + 1, Zone: controlAddr.Zone}
+	token := randomUint32()
+
+	reply, err := s.request(s.control, controlAddr, token)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.request(s.data, dataAddr, token); err != nil {
+		return nil, err
+	}
+
+	p := &Peer{
+		session:     s,
+		name:        reply.name,
+		ssrc:        reply.ssrc,
+		controlAddr: controlAddr,
+		dataAddr:    dataAddr,
+		receiver:    newReceiver(),
+	}
+
+	s.mu.Lock()
+	s.peers[p.ssrc] = p
+	s.mu.Unlock()
+
+	if err := s.SyncClock(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// SyncClock synchronizes the clock with the peer, so that the latency and the offset of clock are measured.
+func (s *Session) SyncClock(p *Peer) error {
+	done := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	s.syncs[p.ssrc] = done
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.syncs, p.ssrc)
+		s.mu.Unlock()
+	}()
+
+	timeout := time.After(s.timeout)
+
+	for {
+		ck := &clockSyncPacket{ssrc: s.ssrc, count: 0}
+		ck.timestamps[0] = s.now()
+
+		if err := s.write(s.data, p.dataAddr, ck.AppendTo(nil)); err != nil {
+			return err
+		}
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(retryInterval):
+		case <-timeout:
+			return fmt.Errorf("midi: clock synchronization with %v timed out", p.name)
+		case <-s.done:
+			return fmt.Errorf("midi: session is closed")
+		}
+	}
+}
+
+// Send sends the events to all peers in a packet. The meta events can't be sent.
+func (s *Session) Send(es ...event.Event) error {
+	var buf bytes.Buffer
+
+	encoder := stream.NewEncoder(&buf)
+	commands := make([]command, 0, len(es))
+
+	for _, e := range es {
+		buf.Reset()
+
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+
+		commands = append(commands, command{data: append([]byte{}, buf.Bytes()...)})
+	}
+
+	s.mu.Lock()
+
+	s.sequence++
+
+	p := &rtpPacket{
+		sequence:  s.sequence,
+		timestamp: uint32(s.now()),
+		ssrc:      s.ssrc,
+		commands:  commands,
+	}
+	if len(s.history) > 0 {
+		p.journal = encodeJournal(s.checkpoint, s.history)
+	}
+
+	s.journal(p.sequence, commands)
+
+	peers := make([]*net.UDPAddr, 0, len(s.peers))
+	for _, peer := range s.peers {
+		peers = append(peers, peer.dataAddr)
+	}
+
+	s.mu.Unlock()
+
+	packet, err := p.AppendTo(nil)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range peers {
+		if err := s.write(s.data, addr, packet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// journal records the channel messages for the recovery journal of the following packets.
+func (s *Session) journal(sequence uint16, commands []command) {
+	for _, c := range commands {
+		status := c.data[0]
+		if status >= 0xf0 {
+			continue
+		}
+
+		channel := status & 0x0f
+
+		if status&0xf0 == constant.Controller {
+			switch constant.Control(c.data[1]) {
+			case constant.BankSelect:
+				s.bank[channel][0] = int16(c.data[2])
+			case constant.BankSelectLSB:
+				s.bank[channel][1] = int16(c.data[2])
+			}
+		}
+
+		s.history = append(s.history, journalEntry{sequence: sequence, data: c.data, bank: s.bank[channel]})
+	}
+
+	if len(s.history) > maxHistory {
+		s.history = s.history[len(s.history)-maxHistory:]
+		s.checkpoint = s.history[0].sequence
+	}
+}
+
+// prune discards the history which all peers have received.
+func (s *Session) prune() {
+	if len(s.peers) == 0 {
+		return
+	}
+
+	var oldest uint16
+
+	first := true
+
+	for _, p := range s.peers {
+		if !p.acked {
+			return
+		}
+		if first || int16(p.ack-oldest) < 0 {
+			oldest = p.ack
+			first = false
+		}
+	}
+
+	i := 0
+	for i < len(s.history) && int16(s.history[i].sequence-oldest) <= 0 {
+		i++
+	}
+
+	s.history = s.history[i:]
+	s.checkpoint = oldest + 1
+}
+
+// Receive returns the message received from any peer. It returns io.EOF after the session is closed.
+// The time of message is the arrival time of packet plus the delta time of the command.
+func (s *Session) Receive() (stream.Message, error) {
+	select {
+	case m := <-s.messages:
+		return m, nil
+	case <-s.done:
+		return stream.Message{}, io.EOF
+	}
+}
+
+// Close sends end session to all peers and closes the session.
+func (s *Session) Close() error {
+	s.mu.Lock()
+
+	select {
+	case <-s.done:
+		s.mu.Unlock()
+		return nil
+	default:
+	}
+
+	for _, p := range s.peers {
+		by := &sessionPacket{command: commandEnd, ssrc: s.ssrc}
+		s.write(s.control, p.controlAddr, by.AppendTo(nil))
+	}
+
+	close(s.done)
+	s.mu.Unlock()
+
+	s.control.Close()
+	s.data.Close()
+	s.wg.Wait()
+
+	return nil
+}
+
+// serve reads the packets from the port until the session is closed.
+func (s *Session) serve(conn *net.UDPConn, isData bool) {
+	defer s.wg.Done()
+
+	buf := make([]byte, 65536)
+
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				continue
+			}
+			return
+		}
+
+		data := append([]byte{}, buf[:n]...)
+
+		if !isSessionPacket(data) {
+			if isData {
+				s.handleRTP(data, time.Now())
+			}
+			continue
+		}
+
+		packet, err := parseSessionPacket(data)
+		if err != nil {
+			continue
+		}
+
+		switch p := packet.(type) {
+		case *sessionPacket:
+			s.handleSession(conn, addr, isData, p)
+		case *clockSyncPacket:
+			s.handleClockSync(addr, p)
+		case *feedbackPacket:
+			s.handleFeedback(p)
+		}
+	}
+}
+
+func (s *Session) handleSession(conn *net.UDPConn, addr *net.UDPAddr, isData bool, p *sessionPacket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch p.command {
+	case commandInvitation:
+		reply := &sessionPacket{command: commandAccepted, token: p.token, ssrc: s.ssrc, name: s.name}
+
+		if !isData {
+			if s.accept != nil && !s.accept(p.name, addr) {
+				reply.command = commandRejected
+			} else {
+				s.pending[p.ssrc] = &Peer{
+					session:     s,
+					name:        p.name,
+					ssrc:        p.ssrc,
+					controlAddr: addr,
+					receiver:    newReceiver(),
+				}
+			}
+		} else if peer, ok := s.pending[p.ssrc]; ok {
+			peer.dataAddr = addr
+			s.peers[p.ssrc] = peer
+			delete(s.pending, p.ssrc)
+		} else if _, ok := s.peers[p.ssrc]; !ok {
+			reply.command = commandRejected
+		}
+
+		s.write(conn, addr, reply.AppendTo(nil))
+	case commandAccepted, commandRejected:
+		if reply, ok := s.replies[p.token]; ok {
+			select {
+			case reply <- p:
+			default:
+			}
+		}
+	case commandEnd:
+		delete(s.peers, p.ssrc)
+		delete(s.pending, p.ssrc)
+		s.prune()
+	}
+}
+
+func (s *Session) handleClockSync(addr *net.UDPAddr, p *clockSyncPacket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peer, ok := s.peers[p.ssrc]
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	ts := p.timestamps
+
+	switch p.count {
+	case 0:
+		reply := &clockSyncPacket{ssrc: s.ssrc, count: 1, timestamps: [3]uint64{ts[0], now}}
+		s.write(s.data, addr, reply.AppendTo(nil))
+	case 1:
+		reply := &clockSyncPacket{ssrc: s.ssrc, count: 2, timestamps: [3]uint64{ts[0], ts[1], now}}
+		s.write(s.data, addr, reply.AppendTo(nil))
+
+		// The peer read its clock at ts[1], which is the middle of the round trip.
+		peer.latency = time.Duration(now-ts[0]) * timestampUnit
+		peer.offset = time.Duration(int64(ts[1])-int64(ts[0]+now)/2) * timestampUnit
+
+		if done, ok := s.syncs[p.ssrc]; ok {
+			select {
+			case done <- struct{}{}:
+			default:
+			}
+		}
+	case 2:
+		peer.latency = time.Duration(ts[2]-ts[0]) * timestampUnit
+		peer.offset = time.Duration(int64(ts[0]+ts[2])/2-int64(ts[1])) * timestampUnit
+	}
+}
+
+func (s *Session) handleFeedback(p *feedbackPacket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peer, ok := s.peers[p.ssrc]
+	if !ok {
+		return
+	}
+
+	peer.acked = true
+	peer.ack = p.sequence
+
+	s.prune()
+}
+
+func (s *Session) handleRTP(data []byte, arrival time.Time) {
+	p, err := parseRTPPacket(data)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+
+	peer, ok := s.peers[p.ssrc]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+
+	accepted, lost := peer.receiver.accept(p.sequence)
+	if !accepted {
+		s.mu.Unlock()
+		return
+	}
+
+	var commands []command
+
+	if lost > 0 && len(p.journal) >= 3 {
+		checkpoint := binary.BigEndian.Uint16(p.journal[1:])
+		firstLost := p.sequence - uint16(lost)
+
+		// The journal repairs the loss only when it covers the first lost packet.
+		if int16(firstLost-checkpoint) >= 0 {
+			recovered, err := peer.receiver.recover(p.journal)
+			if err == nil {
+				for _, data := range recovered {
+					commands = append(commands, command{data: data})
+				}
+			}
+		}
+	}
+	for _, c := range p.commands {
+		peer.receiver.apply(c.data)
+		commands = append(commands, c)
+	}
+
+	if arrival.Sub(peer.lastFeedback) >= s.feedbackInterval {
+		peer.lastFeedback = arrival
+
+		rs := &feedbackPacket{ssrc: s.ssrc, sequence: p.sequence}
+		s.write(s.control, peer.controlAddr, rs.AppendTo(nil))
+	}
+
+	s.mu.Unlock()
+
+	var delta time.Duration
+
+	for _, c := range commands {
+		delta += time.Duration(c.delta) * timestampUnit
+
+		at := arrival.Add(delta)
+		m, err := stream.NewDecoder(bytes.NewReader(c.data)).SetClock(func() time.Time { return at }).Decode()
+		if err != nil {
+			continue
+		}
+
+		select {
+		case s.messages <- m:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// SetAccept sets the function which decides whether to accept the invitation from the session of name at addr.
+// The default accepts all invitations.
+func (s *Session) SetAccept(accept func(name string, addr net.Addr) bool) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accept = accept
+
+	return s
+}
+
+// SetTimeout sets the timeout of invitation and clock synchronization. The default is DefaultTimeout.
+func (s *Session) SetTimeout(timeout time.Duration) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.timeout = timeout
+
+	return s
+}
+
+// SetFeedbackInterval sets the minimum interval of receiver feedback sent to each peer.
+// The frequent feedback shortens the recovery journal. The default is DefaultFeedbackInterval.
+func (s *Session) SetFeedbackInterval(interval time.Duration) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.feedbackInterval = interval
+
+	return s
+}
+
+// Listen starts the session of name on the address of control port, e.g. "127.0.0.1:5004".
+// The data port is the next port. When the port is 0, a free pair of ports is chosen.
+func Listen(name, address string) (*Session, error) {
+	addr, err := net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	control, data, err := listenPair(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Session{
+		name:             name,
+		ssrc:             randomUint32(),
+		control:          control,
+		data:             data,
+		start:            time.Now(),
+		timeout:          DefaultTimeout,
+		feedbackInterval: DefaultFeedbackInterval,
+		peers:            map[uint32]*Peer{},
+		pending:          map[uint32]*Peer{},
+		replies:          map[uint32]chan *sessionPacket{},
+		syncs:            map[uint32]chan struct{}{},
+		sequence:         uint16(randomUint32()),
+		messages:         make(chan stream.Message, 256),
+		done:             make(chan struct{}),
+	}
+
+	s.checkpoint = s.sequence + 1
+
+	for i := range s.bank {
+		s.bank[i] = [2]int16{-1, -1}
+	}
+
+	s.wg.Add(2)
+
+	go s.serve(control, false)
+	go s.serve(data, true)
+
+	return s, nil
+}
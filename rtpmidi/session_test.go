@@ -0,0 +1,168 @@
+package rtpmidi
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func listen(t *testing.T, name string) *Session {
+	t.Helper()
+
+	s, err := Listen(name, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s.SetTimeout(2 * time.Second).SetFeedbackInterval(0)
+}
+
+// waitFor polls the condition because the packets are handled by the goroutines of session.
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if condition() {
+			return
+		}
+	}
+
+	t.Fatal("timed out")
+}
+
+func TestSession(t *testing.T) {
+	a := listen(t, "a")
+	defer a.Close()
+
+	b := listen(t, "b")
+	defer b.Close()
+
+	peer, err := a.Invite(b.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peer.Name() != "b" || peer.SSRC() != b.SSRC() {
+		t.Fatalf("expected: b %v actual: %v %v", b.SSRC(), peer.Name(), peer.SSRC())
+	}
+	if peer.Latency() < 0 || peer.Latency() > time.Second {
+		t.Fatalf("expected: latency of loopback actual: %v", peer.Latency())
+	}
+
+	waitFor(t, func() bool {
+		return len(b.Peers()) == 1
+	})
+
+	if actual := b.Peers()[0].Name(); actual != "a" {
+		t.Fatalf("expected: a actual: %v", actual)
+	}
+
+	noteOn, _ := event.NewNoteOnEvent(nil, 1, constant.C3, 0x40)
+	program, _ := event.NewProgramChangeEvent(nil, 1, 0x05)
+
+	if err := a.Send(noteOn, &event.TimingClockEvent{}, program); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []event.Event{noteOn, &event.TimingClockEvent{}, program} {
+		m, err := b.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(m.Event) {
+			t.Fatalf("expected: %v actual: %v", expected, m.Event)
+		}
+	}
+
+	// The receiver feedback lets the sender discard the journaled history.
+	waitFor(t, func() bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		return len(a.history) == 0
+	})
+
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Receive(); err != io.EOF {
+		t.Fatalf("expected: %v actual: %v", io.EOF, err)
+	}
+
+	waitFor(t, func() bool {
+		return len(a.Peers()) == 0
+	})
+}
+
+func TestSession_recoveryJournal(t *testing.T) {
+	a := listen(t, "a")
+	defer a.Close()
+
+	b := listen(t, "b")
+	defer b.Close()
+
+	if _, err := a.Invite(b.Addr().String()); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool {
+		return len(b.Peers()) == 1
+	})
+
+	// Stop the feedback, so that the journal keeps everything sent after the first packet.
+	b.SetFeedbackInterval(time.Hour)
+
+	noteOn, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0x40)
+	if err := a.Send(noteOn); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Receive(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate the lost packet by skipping the sequence number.
+	program, _ := event.NewProgramChangeEvent(nil, 0, 0x05)
+	noteOff, _ := event.NewNoteOffEvent(nil, 0, constant.C3, 0x00)
+
+	a.mu.Lock()
+	a.sequence++
+	a.journal(a.sequence, []command{{data: program.Serialize()}, {data: noteOff.Serialize()}})
+	a.mu.Unlock()
+
+	noteOn2, _ := event.NewNoteOnEvent(nil, 0, constant.D3, 0x40)
+	if err := a.Send(noteOn2); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []event.Event{program, noteOff, noteOn2} {
+		m, err := b.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(m.Event) {
+			t.Fatalf("expected: %v actual: %v", expected, m.Event)
+		}
+	}
+}
+
+func TestSession_Invite_rejected(t *testing.T) {
+	a := listen(t, "a")
+	defer a.Close()
+
+	b := listen(t, "b")
+	defer b.Close()
+
+	b.SetAccept(func(name string, addr net.Addr) bool {
+		return name != "a"
+	})
+
+	if _, err := a.Invite(b.Addr().String()); err == nil {
+		t.Fatal("expected error")
+	}
+	if actual := len(b.Peers()); actual != 0 {
+		t.Fatalf("expected: 0 actual: %v", actual)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Package stream reads and writes raw MIDI 1.0 messages sent on the wire, e.g. through a serial port or a virtual port.
+
+Unlike standard MIDI file, the stream has neither delta times nor meta events. The messages are timestamped on arrival,
+and the system real-time messages such as timing clock may appear even in the middle of other messages.
+*/
+package stream
+
+import (
+	"bufio"
+	"io"
+	"time"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Message represents a MIDI message received at Time.
+type Message struct {
+	Time  time.Time
+	Event event.Event
+}
+
+// Decoder decodes MIDI messages from byte stream.
+//
+// The decoder handles running status, the system real-time messages interleaved inside other messages and
+// the system exclusive messages framed by 0xf0 and 0xf7. The system exclusive message which is interrupted by
+// another status byte is returned without the trailing 0xf7. The undefined status bytes and the data bytes
+// without status are skipped.
+type Decoder struct {
+	r     *bufio.Reader
+	clock func() time.Time
+
+	// status is the status byte of the message being decoded. It's kept as running status after channel message.
+	status    byte
+	hasStatus bool
+	data      []byte
+
+	inSysEx bool
+	sysEx   []byte
+}
+
+// dataLength returns the number of data bytes follows the status byte.
+func dataLength(status byte) int {
+	switch {
+	case status < 0xf0:
+		switch status & 0xf0 {
+		case constant.ProgramChange, constant.ChannelAfterTouch:
+			return 1
+		}
+		return 2
+	case status == constant.MTCQuarterFrame, status == constant.SongSelect:
+		return 1
+	case status == constant.SongPositionPointer:
+		return 2
+	}
+
+	return 0
+}
+
+// Decode reads bytes until a message is completed and returns the message.
+// It returns io.EOF when the stream ends. The incomplete message at the end of stream is discarded.
+func (d *Decoder) Decode() (Message, error) {
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return Message{}, err
+		}
+
+		var e event.Event
+
+		switch {
+		case b >= constant.TimingClock:
+			e = realTimeEvent(b)
+		case b == constant.EndOfExclusive:
+			if d.inSysEx {
+				e = d.endSysEx(b)
+			} else {
+				d.status = 0
+			}
+		case d.inSysEx && b >= 0x80:
+			// Any status byte except real-time terminates the system exclusive message.
+			d.r.UnreadByte()
+			e = d.endSysEx(0)
+		case b == constant.SystemExclusive:
+			d.inSysEx = true
+			d.sysEx = d.sysEx[:0]
+			d.status = 0
+		case b >= 0x80:
+			e = d.startMessage(b)
+		case d.inSysEx:
+			d.sysEx = append(d.sysEx, b)
+		case d.status != 0:
+			e = d.appendData(b)
+		}
+		if e != nil {
+			return Message{Time: d.clock(), Event: e}, nil
+		}
+	}
+}
+
+// startMessage starts decoding message with status byte. It returns the message which has no data bytes.
+func (d *Decoder) startMessage(status byte) event.Event {
+	d.status = status
+	d.hasStatus = true
+	d.data = d.data[:0]
+
+	if status >= 0xf0 {
+		// The system common messages cancel running status.
+		d.status = 0
+
+		switch status {
+		case constant.MTCQuarterFrame, constant.SongPositionPointer, constant.SongSelect:
+			d.status = status
+		case constant.TuneRequest:
+			return &event.TuneRequestEvent{}
+		}
+	}
+
+	return nil
+}
+
+// appendData appends data byte and returns the message when it's completed.
+func (d *Decoder) appendData(b byte) event.Event {
+	d.data = append(d.data, b)
+
+	if len(d.data) < dataLength(d.status) {
+		return nil
+	}
+
+	e := decodeMessage(d.status, d.data)
+
+	if d.status < 0xf0 {
+		e.SetRunningStatus(!d.hasStatus)
+	} else {
+		d.status = 0
+	}
+
+	d.hasStatus = false
+	d.data = d.data[:0]
+
+	return e
+}
+
+// endSysEx returns the system exclusive message collected so far. The terminator is appended unless it's 0.
+func (d *Decoder) endSysEx(terminator byte) event.Event {
+	data := append([]byte{}, d.sysEx...)
+	if terminator != 0 {
+		data = append(data, terminator)
+	}
+
+	d.inSysEx = false
+	d.sysEx = d.sysEx[:0]
+
+	e := &event.SystemExclusiveEvent{}
+	e.SetData(data)
+
+	return e
+}
+
+// realTimeEvent returns system real-time event. It returns nil for the undefined status bytes 0xf9 and 0xfd.
+func realTimeEvent(status byte) event.Event {
+	switch status {
+	case constant.TimingClock:
+		return &event.TimingClockEvent{}
+	case constant.Start:
+		return &event.StartEvent{}
+	case constant.Continue:
+		return &event.ContinueEvent{}
+	case constant.Stop:
+		return &event.StopEvent{}
+	case constant.ActiveSensing:
+		return &event.ActiveSensingEvent{}
+	case constant.SystemReset:
+		return &event.SystemResetEvent{}
+	}
+
+	return nil
+}
+
+// decodeMessage decodes channel message or system common message. The data bytes must be less than 0x80.
+func decodeMessage(status byte, data []byte) event.Event {
+	channel := status & 0x0f
+
+	switch status {
+	case constant.MTCQuarterFrame:
+		e, _ := event.NewMTCQuarterFrameEvent(nil, data[0]>>4, data[0]&0x0f)
+		return e
+	case constant.SongPositionPointer:
+		e, _ := event.NewSongPositionPointerEvent(nil, uint16(data[1])<<7|uint16(data[0]))
+		return e
+	case constant.SongSelect:
+		e, _ := event.NewSongSelectEvent(nil, data[0])
+		return e
+	}
+
+	switch status & 0xf0 {
+	case constant.NoteOff:
+		e, _ := event.NewNoteOffEvent(nil, channel, constant.Note(data[0]), data[1])
+		return e
+	case constant.NoteOn:
+		e, _ := event.NewNoteOnEvent(nil, channel, constant.Note(data[0]), data[1])
+		return e
+	case constant.NoteAfterTouch:
+		e, _ := event.NewNoteAfterTouchEvent(nil, channel, constant.Note(data[0]), data[1])
+		return e
+	case constant.Controller:
+		e, _ := event.NewControllerEvent(nil, channel, constant.Control(data[0]), data[1])
+		return e
+	case constant.ProgramChange:
+		e, _ := event.NewProgramChangeEvent(nil, channel, constant.GM(data[0]))
+		return e
+	case constant.ChannelAfterTouch:
+		e, _ := event.NewChannelAfterTouchEvent(nil, channel, data[0])
+		return e
+	}
+
+	e, _ := event.NewPitchBendEvent(nil, channel, uint16(data[1])<<7|uint16(data[0]))
+	return e
+}
+
+// SetClock sets the function which returns the time of received message. The default is time.Now.
+func (d *Decoder) SetClock(clock func() time.Time) *Decoder {
+	d.clock = clock
+
+	return d
+}
+
+// NewDecoder returns Decoder which reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		r:     bufio.NewReader(r),
+		clock: time.Now,
+	}
+}
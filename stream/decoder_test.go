@@ -0,0 +1,106 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/event"
+)
+
+func decodeAll(t *testing.T, input []byte) []Message {
+	t.Helper()
+
+	d := NewDecoder(bytes.NewReader(input))
+
+	var ms []Message
+
+	for {
+		m, err := d.Decode()
+		if err == io.EOF {
+			return ms
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ms = append(ms, m)
+	}
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	input := []byte{
+		0x90, 0x3c, 0x40,
+		0x3e, 0xf8, 0x40, // Running status with timing clock inside.
+		0xf0, 0x7e, 0xfe, 0x7f, 0xf7, // Active sensing inside system exclusive.
+		0x40, 0x00, // Data without status is skipped after system exclusive.
+		0xf2, 0x10, 0x01,
+		0xe0, 0x00, 0x40,
+		0xf6,
+		0xf9, 0xfd, 0xf4, 0x01, // Undefined status and its data are skipped.
+		0xc1, 0x05, 0x06,
+		0xf0, 0x01, 0x02, 0xb0, 0x07, 0x64, // Unterminated system exclusive.
+		0x80, 0x3c, // Incomplete message at the end.
+	}
+
+	expected := []string{
+		"90 3c 40",
+		"f8",
+		"90 3e 40",
+		"fe",
+		"7e 7f f7",
+		"f2 10 01",
+		"e0 00 40",
+		"f6",
+		"c1 05",
+		"c1 06",
+		"01 02",
+		"b0 07 64",
+	}
+	runningStatus := []bool{false, false, true, false, false, false, false, false, false, true, false, false}
+
+	ms := decodeAll(t, input)
+
+	if len(ms) != len(expected) {
+		t.Fatalf("expected: %v messages actual: %v messages (%v)", len(expected), len(ms), ms)
+	}
+	for i, m := range ms {
+		var actual string
+
+		if sysEx, ok := m.Event.(*event.SystemExclusiveEvent); ok {
+			actual = fmt.Sprintf("% x", sysEx.Data())
+		} else {
+			actual = fmt.Sprintf("% x", m.Event.Serialize())
+		}
+		if expected[i] != actual {
+			t.Fatalf("[%v] expected: %v actual: %v", i, expected[i], actual)
+		}
+		if runningStatus[i] != m.Event.RunningStatus() {
+			t.Fatalf("[%v] expected: %v actual: %v", i, runningStatus[i], m.Event.RunningStatus())
+		}
+	}
+}
+
+func TestDecoder_SetClock(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	d := NewDecoder(bytes.NewReader([]byte{0xfa, 0xfc})).SetClock(clock)
+
+	for i := 1; i <= 2; i++ {
+		m, err := d.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := time.Date(2020, 1, 1, 0, 0, 0, i*int(time.Millisecond), time.UTC)
+		if !expected.Equal(m.Time) {
+			t.Fatalf("expected: %v actual: %v", expected, m.Time)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+// Encoder writes MIDI messages to byte stream.
+type Encoder struct {
+	w             io.Writer
+	runningStatus bool
+	status        byte
+	buf           []byte
+}
+
+// Encode writes the event as MIDI message. The delta time and the running status of event are ignored.
+//
+// The system exclusive event is written with 0xf0, and 0xf7 is appended if the data doesn't end with it.
+// The data of divided system exclusive event is written as is. The meta events can't be written.
+func (e *Encoder) Encode(ev event.Event) error {
+	kind := ev.Kind()
+	b := e.buf[:0]
+
+	switch {
+	case kind.IsMeta():
+		return fmt.Errorf("midi: meta event can't be sent as MIDI message (%v)", kind)
+	case kind == event.SystemExclusive:
+		data := ev.(event.SysExEvent).Data()
+
+		b = append(b, constant.SystemExclusive)
+		b = append(b, data...)
+
+		if len(data) == 0 || data[len(data)-1] != constant.EndOfExclusive {
+			b = append(b, constant.EndOfExclusive)
+		}
+	case kind == event.DividedSystemExclusive:
+		b = append(b, ev.(event.SysExEvent).Data()...)
+	default:
+		b = ev.AppendTo(b)
+	}
+
+	e.buf = b
+
+	switch {
+	case kind.IsSystemRealTime():
+		// The system real-time messages don't affect running status.
+	case kind.IsChannel():
+		status := b[0]
+		if e.runningStatus && status == e.status {
+			b = b[1:]
+		}
+		e.status = status
+	default:
+		e.status = 0
+	}
+
+	_, err := e.w.Write(b)
+
+	return err
+}
+
+// Reset forgets the running status, so that the next channel message is written with its status byte.
+// Call Reset when the receiver may have missed the previous messages, e.g. after reconnection.
+func (e *Encoder) Reset() {
+	e.status = 0
+}
+
+// SetRunningStatus sets whether the status byte is omitted when it's the same as the previous channel message.
+// The default is false.
+func (e *Encoder) SetRunningStatus(enabled bool) *Encoder {
+	e.runningStatus = enabled
+
+	return e
+}
+
+// NewEncoder returns Encoder which writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		w: w,
+	}
+}
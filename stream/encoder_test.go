@@ -0,0 +1,58 @@
+package stream
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/moutend/go-midi/constant"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	var buf bytes.Buffer
+
+	noteOn1, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0x40)
+	noteOn2, _ := event.NewNoteOnEvent(nil, 0, constant.D3, 0x40)
+	noteOff, _ := event.NewNoteOffEvent(nil, 0, constant.C3, 0x00)
+	sysEx := &event.SystemExclusiveEvent{}
+	sysEx.SetData([]byte{0x7e, 0x7f})
+	position, _ := event.NewSongPositionPointerEvent(nil, 0x90)
+
+	e := NewEncoder(&buf).SetRunningStatus(true)
+
+	for _, ev := range []event.Event{noteOn1, &event.TimingClockEvent{}, noteOn2, sysEx, noteOn1, position, noteOff, noteOff} {
+		if err := e.Encode(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []byte{
+		0x90, 0x3c, 0x40,
+		0xf8,
+		0x3e, 0x40,
+		0xf0, 0x7e, 0x7f, 0xf7,
+		0x90, 0x3c, 0x40,
+		0xf2, 0x10, 0x01,
+		0x80, 0x3c, 0x00,
+		0x3c, 0x00,
+	}
+	actual := buf.Bytes()
+
+	if !bytes.Equal(expected, actual) {
+		t.Fatalf("expected: % x actual: % x", expected, actual)
+	}
+
+	ms := decodeAll(t, actual)
+	if len(ms) != 8 {
+		t.Fatalf("expected: 8 messages actual: %v messages", len(ms))
+	}
+}
+
+func TestEncoder_Encode_meta(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := NewEncoder(&buf).Encode(&event.EndOfTrackEvent{})
+	if err == nil {
+		t.Fatalf("err must not be nil")
+	}
+}
@@ -0,0 +1,111 @@
+package midi
+
+import (
+	"sort"
+	"time"
+
+	"github.com/moutend/go-midi/event"
+)
+
+// DefaultTempo is the tempo in microseconds per quarter note assumed until a set tempo event appears.
+const DefaultTempo = 500000
+
+type tempoSegment struct {
+	tick  uint32
+	start time.Duration
+	tempo uint32
+}
+
+// TempoMap converts between ticks and durations from the beginning of song by following the set tempo events.
+type TempoMap struct {
+	ticksPerQuarterNote uint32
+	segments            []tempoSegment
+}
+
+// TempoMap returns the tempo map of the set tempo events in all tracks.
+func (m *MIDI) TempoMap() (*TempoMap, error) {
+	ticksPerQuarterNote, err := m.TimeDivision().BPM()
+	if err != nil {
+		return nil, err
+	}
+	if ticksPerQuarterNote == 0 {
+		ticksPerQuarterNote = 1
+	}
+
+	t := &TempoMap{
+		ticksPerQuarterNote: uint32(ticksPerQuarterNote),
+		segments:            []tempoSegment{{tempo: DefaultTempo}},
+	}
+
+	for _, te := range m.TimedEvents() {
+		e, ok := te.Event.(*event.SetTempoEvent)
+		if !ok {
+			continue
+		}
+
+		tempo := e.Tempo()
+		if tempo == 0 {
+			// A zero tempo would stop the time, so that it's treated as the fastest tempo.
+			tempo = 1
+		}
+
+		next := tempoSegment{
+			tick:  te.Tick,
+			start: t.Duration(te.Tick),
+			tempo: tempo,
+		}
+
+		last := &t.segments[len(t.segments)-1]
+		if last.tick == te.Tick {
+			*last = next
+			continue
+		}
+
+		t.segments = append(t.segments, next)
+	}
+
+	return t, nil
+}
+
+// segment returns the tempo segment which contains the tick.
+func (t *TempoMap) segment(tick uint32) tempoSegment {
+	i := sort.Search(len(t.segments), func(i int) bool {
+		return t.segments[i].tick > tick
+	}) - 1
+
+	return t.segments[i]
+}
+
+// TicksPerQuarterNote returns the resolution of tempo map.
+func (t *TempoMap) TicksPerQuarterNote() uint32 {
+	return t.ticksPerQuarterNote
+}
+
+// Tempo returns the tempo in microseconds per quarter note at the tick.
+func (t *TempoMap) Tempo(tick uint32) uint32 {
+	return t.segment(tick).tempo
+}
+
+// Duration returns the duration from the beginning of song to the tick.
+func (t *TempoMap) Duration(tick uint32) time.Duration {
+	s := t.segment(tick)
+	offset := uint64(tick-s.tick) * uint64(s.tempo) * uint64(time.Microsecond) / uint64(t.ticksPerQuarterNote)
+
+	return s.start + time.Duration(offset)
+}
+
+// Tick returns the tick at the duration from the beginning of song. The fraction of tick is truncated.
+func (t *TempoMap) Tick(d time.Duration) uint32 {
+	if d <= 0 {
+		return 0
+	}
+
+	i := sort.Search(len(t.segments), func(i int) bool {
+		return t.segments[i].start > d
+	}) - 1
+	s := t.segments[i]
+
+	offset := uint64(d-s.start) * uint64(t.ticksPerQuarterNote) / (uint64(s.tempo) * uint64(time.Microsecond))
+
+	return s.tick + uint32(offset)
+}
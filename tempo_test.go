@@ -0,0 +1,53 @@
+package midi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moutend/go-midi/event"
+)
+
+func TestMIDI_TempoMap(t *testing.T) {
+	tempo1, _ := event.NewSetTempoEvent(nil, 1000000)
+	tempo2, _ := event.NewSetTempoEvent(nil, 250000)
+	end, _ := event.NewEndOfTrackEvent(nil)
+
+	m := &MIDI{
+		Tracks: []*Track{
+			NewTrackFromTimedEvents([]TimedEvent{
+				{Tick: 480, Event: tempo1},
+				{Tick: 960, Event: tempo2},
+				{Tick: 1920, Event: end},
+			}),
+		},
+	}
+	m.TimeDivision().SetBPM(480)
+
+	tm, err := m.TempoMap()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		tick     uint32
+		duration time.Duration
+		tempo    uint32
+	}{
+		{0, 0, DefaultTempo},
+		{240, 250 * time.Millisecond, DefaultTempo},
+		{480, 500 * time.Millisecond, 1000000},
+		{720, 1000 * time.Millisecond, 1000000},
+		{960, 1500 * time.Millisecond, 250000},
+		{1920, 2000 * time.Millisecond, 250000},
+	} {
+		if actual := tm.Duration(c.tick); c.duration != actual {
+			t.Fatalf("expected: %v actual: %v", c.duration, actual)
+		}
+		if actual := tm.Tick(c.duration); c.tick != actual {
+			t.Fatalf("expected: %v actual: %v", c.tick, actual)
+		}
+		if actual := tm.Tempo(c.tick); c.tempo != actual {
+			t.Fatalf("expected: %v actual: %v", c.tempo, actual)
+		}
+	}
+}
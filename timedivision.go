@@ -22,17 +22,18 @@ func (t *TimeDivision) String() string {
 	return fmt.Sprintf("&TimeDivision{frames: %v, ticks: %v}", frames, ticks)
 }
 
-// Serialize serializes time division.
-func (t *TimeDivision) Serialize() []byte {
+// AppendTo appends serialized time division to dst and returns the extended buffer.
+func (t *TimeDivision) AppendTo(dst []byte) []byte {
 	if t.value == 0 {
-		return []byte{0x00, 0x78}
+		return append(dst, 0x00, 0x78)
 	}
 
-	bs := make([]byte, 2)
-	bs[0] = byte(t.value >> 8)
-	bs[1] = byte(t.value & 0xff)
+	return append(dst, byte(t.value>>8), byte(t.value&0xff))
+}
 
-	return bs
+// Serialize serializes time division.
+func (t *TimeDivision) Serialize() []byte {
+	return t.AppendTo(make([]byte, 0, 2))
 }
 
 // SetBPM sets time division value as BPM.
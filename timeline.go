@@ -0,0 +1,82 @@
+package midi
+
+import (
+	"sort"
+
+	"github.com/moutend/go-midi/event"
+)
+
+// TimedEvent represents an event with its absolute time in ticks.
+type TimedEvent struct {
+	Tick  uint32
+	Event event.Event
+}
+
+// TimedEvents returns events of the track with absolute time in ticks.
+func (t *Track) TimedEvents() []TimedEvent {
+	tes := make([]TimedEvent, len(t.Events))
+
+	var tick uint32
+
+	for i, e := range t.Events {
+		tick += e.DeltaTime().Uint32()
+		tes[i] = TimedEvent{
+			Tick:  tick,
+			Event: e,
+		}
+	}
+
+	return tes
+}
+
+// TimedEvents returns events of all tracks merged in chronological order.
+// The events at the same tick are ordered by track, then by position in the track.
+func (m *MIDI) TimedEvents() []TimedEvent {
+	tes := []TimedEvent{}
+
+	for _, track := range m.Tracks {
+		tes = append(tes, track.TimedEvents()...)
+	}
+
+	sort.SliceStable(tes, func(i, j int) bool {
+		return tes[i].Tick < tes[j].Tick
+	})
+
+	return tes
+}
+
+// NewTrackFromTimedEvents returns Track which contains the given events.
+// The events are sorted by tick and the delta times are recalculated.
+// The end of track event is always placed at the end of track.
+func NewTrackFromTimedEvents(tes []TimedEvent) *Track {
+	sorted := make([]TimedEvent, len(tes))
+	copy(sorted, tes)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		_, iEnd := sorted[i].Event.(*event.EndOfTrackEvent)
+		_, jEnd := sorted[j].Event.(*event.EndOfTrackEvent)
+		if iEnd != jEnd {
+			return jEnd
+		}
+		return sorted[i].Tick < sorted[j].Tick
+	})
+
+	t := &Track{
+		Events: make([]event.Event, len(sorted)),
+	}
+
+	var previous uint32
+
+	for i, te := range sorted {
+		tick := te.Tick
+		if tick < previous {
+			tick = previous
+		}
+
+		te.Event.DeltaTime().SetUint32(tick - previous)
+		t.Events[i] = te.Event
+		previous = tick
+	}
+
+	return t
+}
@@ -0,0 +1,86 @@
+package midi
+
+import (
+	"testing"
+
+	"github.com/moutend/go-midi/deltatime"
+	"github.com/moutend/go-midi/event"
+)
+
+func TestTrack_TimedEvents(t *testing.T) {
+	deltaTime1, _ := deltatime.New(0)
+	deltaTime2, _ := deltatime.New(480)
+	deltaTime3, _ := deltatime.New(240)
+
+	event1, _ := event.NewTextEvent(deltaTime1, []byte("txt1"))
+	event2, _ := event.NewTextEvent(deltaTime2, []byte("txt2"))
+	event3, _ := event.NewEndOfTrackEvent(deltaTime3)
+
+	tes := NewTrack(event1, event2, event3).TimedEvents()
+
+	expected := []uint32{0, 480, 720}
+
+	if len(expected) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(tes))
+	}
+	for i, e := range expected {
+		a := tes[i].Tick
+		if e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+}
+
+func TestNewTrackFromTimedEvents(t *testing.T) {
+	event1, _ := event.NewTextEvent(nil, []byte("txt1"))
+	event2, _ := event.NewTextEvent(nil, []byte("txt2"))
+	event3, _ := event.NewEndOfTrackEvent(nil)
+
+	track := NewTrackFromTimedEvents([]TimedEvent{
+		{Tick: 960, Event: event3},
+		{Tick: 480, Event: event2},
+		{Tick: 120, Event: event1},
+	})
+
+	expected := []event.Event{event1, event2, event3}
+	expectedDeltaTimes := []uint32{120, 360, 480}
+
+	if len(expected) != len(track.Events) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(track.Events))
+	}
+	for i, e := range expected {
+		a := track.Events[i]
+		if e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+		if expectedDeltaTimes[i] != a.DeltaTime().Quantity().Uint32() {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, expectedDeltaTimes[i], i, a.DeltaTime().Quantity().Uint32())
+		}
+	}
+}
+
+func TestMIDI_TimedEvents(t *testing.T) {
+	event1, _ := event.NewTextEvent(nil, []byte("txt1"))
+	event2, _ := event.NewTextEvent(nil, []byte("txt2"))
+	event3, _ := event.NewTextEvent(nil, []byte("txt3"))
+	event4, _ := event.NewTextEvent(nil, []byte("txt4"))
+
+	m := &MIDI{
+		Tracks: []*Track{
+			NewTrackFromTimedEvents([]TimedEvent{{Tick: 0, Event: event1}, {Tick: 480, Event: event3}}),
+			NewTrackFromTimedEvents([]TimedEvent{{Tick: 240, Event: event2}, {Tick: 480, Event: event4}}),
+		},
+	}
+
+	tes := m.TimedEvents()
+	expected := []event.Event{event1, event2, event3, event4}
+
+	if len(expected) != len(tes) {
+		t.Fatalf("expected: %v events actual: %v events", len(expected), len(tes))
+	}
+	for i, e := range expected {
+		if a := tes[i].Event; e != a {
+			t.Fatalf("expected[%v] = %v actual[%v] = %v", i, e, i, a)
+		}
+	}
+}
@@ -1,47 +1,124 @@
 package midi
 
-import (
-	"github.com/moutend/go-midi/constant"
-	"github.com/moutend/go-midi/event"
-)
+import "github.com/moutend/go-midi/event"
 
 // Track represents MIDI track.
 type Track struct {
 	Events []event.Event
 }
 
-// Serialize serializes track.
-func (t *Track) Serialize() []byte {
-	data := []byte{}
+// AppendTo appends serialized track to dst and returns the extended buffer.
+// The buffer is grown once to the length of track, so that no intermediate buffer is allocated.
+func (t *Track) AppendTo(dst []byte) []byte {
+	sizeOfData := t.sizeOfData()
+
+	return t.appendTo(grow(dst, 8+sizeOfData+2), sizeOfData)
+}
+
+// appendTo appends serialized track whose chunk data is sizeOfData bytes. The status bytes omitted by running status
+// are appended before they are removed, so that dst needs room for 2 more bytes not to be reallocated.
+func (t *Track) appendTo(dst []byte, sizeOfData int) []byte {
+	dst = append(dst, "MTrk"...)
+	dst = append(dst, byte(sizeOfData>>24), byte(sizeOfData>>16), byte(sizeOfData>>8), byte(sizeOfData))
+
+	for _, event := range t.Events {
+		dst = event.DeltaTime().Quantity().AppendTo(dst)
+
+		status := len(dst)
+		dst = event.AppendTo(dst)
+
+		if event.RunningStatus() {
+			dst = append(dst[:status], dst[status+sizeOfStatus(event):]...)
+		}
+	}
+
+	return dst
+}
+
+// Len returns the length of serialized track including the chunk header.
+func (t *Track) Len() int {
+	return 8 + t.sizeOfData()
+}
+
+// sizeOfData returns the length of MTrk chunk data.
+func (t *Track) sizeOfData() int {
+	size := 0
 
 	for _, event := range t.Events {
-		data = append(data, event.DeltaTime().Quantity().Value()...)
+		size += event.DeltaTime().Quantity().Len() + event.Len()
 
 		if event.RunningStatus() {
-			bs := event.Serialize()
-
-			switch bs[0] {
-			case constant.Meta:
-				data = append(data, bs[2:]...)
-			default:
-				data = append(data, bs[1:]...)
-			}
-		} else {
-			data = append(data, event.Serialize()...)
+			size -= sizeOfStatus(event)
+		}
+	}
+
+	return size
+}
+
+// sizeOfStatus returns the number of bytes omitted by running status, which is 2 for meta events.
+func sizeOfStatus(e event.Event) int {
+	if e.Kind().IsMeta() {
+		return 2
+	}
+
+	return 1
+}
+
+// grow returns dst with room for n more bytes.
+func grow(dst []byte, n int) []byte {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+
+	grown := make([]byte, len(dst), len(dst)+n)
+	copy(grown, dst)
+
+	return grown
+}
+
+// Serialize serializes track.
+func (t *Track) Serialize() []byte {
+	return t.AppendTo(nil)
+}
+
+// DeviceName returns the text of the first device name event, which names the output port of track.
+// It returns false if track has no device name event.
+func (t *Track) DeviceName() (string, bool) {
+	for _, e := range t.Events {
+		if v, ok := e.(*event.DeviceNameEvent); ok {
+			return string(v.Text()), true
 		}
 	}
 
-	stream := []byte("MTrk")
+	return "", false
+}
+
+// Clone returns a deep copy of track.
+func (t *Track) Clone() *Track {
+	clone := &Track{}
 
-	sizeOfData := uint32(len(data))
-	stream = append(stream, byte(sizeOfData>>24))
-	stream = append(stream, byte((sizeOfData&0xff0000)>>16))
-	stream = append(stream, byte((sizeOfData&0xff00)>>8))
-	stream = append(stream, byte(sizeOfData&0xff))
+	if t.Events != nil {
+		clone.Events = make([]event.Event, len(t.Events))
+	}
+	for i, e := range t.Events {
+		clone.Events[i] = e.Clone()
+	}
 
-	stream = append(stream, data...)
+	return clone
+}
+
+// Equal reports whether both tracks have the same events in the same order.
+func (t *Track) Equal(other *Track) bool {
+	if len(t.Events) != len(other.Events) {
+		return false
+	}
+	for i, e := range t.Events {
+		if !e.Equal(other.Events[i]) {
+			return false
+		}
+	}
 
-	return stream
+	return true
 }
 
 func NewTrack(es ...event.Event) *Track {
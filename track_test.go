@@ -3,6 +3,7 @@ package midi
 import (
 	"testing"
 
+	"github.com/moutend/go-midi/constant"
 	"github.com/moutend/go-midi/event"
 )
 
@@ -31,3 +32,38 @@ func TestTrack_Serialize(t *testing.T) {
 		}
 	}
 }
+
+func TestTrack_AppendTo(t *testing.T) {
+	event1, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 100)
+	event2, _ := event.NewNoteOnEvent(nil, 0, constant.C3, 0)
+	event3, _ := event.NewEndOfTrackEvent(nil)
+
+	event2.SetRunningStatus(true)
+
+	expected := []byte{0xaa, 0x4d, 0x54, 0x72, 0x6b, 0x00, 0x00, 0x00, 0x0b, 0x00, 0x90, 0x3c, 0x64, 0x00, 0x3c, 0x00, 0x00, 0xff, 0x2f, 0x00}
+	actual := NewTrack(event1, event2, event3).AppendTo([]byte{0xaa})
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected: %v bytes actual: %v bytes", len(expected), len(actual))
+	}
+	for i, e := range expected {
+		if e != actual[i] {
+			t.Fatalf("expected[%v] = 0x%x actual[%v] = 0x%x", i, e, i, actual[i])
+		}
+	}
+}
+
+func TestTrack_DeviceName(t *testing.T) {
+	name, _ := event.NewSequenceOrTrackNameEvent(nil, []byte("Lead"))
+	device, _ := event.NewDeviceNameEvent(nil, []byte("Port B"))
+	eot, _ := event.NewEndOfTrackEvent(nil)
+
+	if _, ok := NewTrack(name, eot).DeviceName(); ok {
+		t.Fatalf("track must not have device name")
+	}
+
+	actual, ok := NewTrack(name, device, eot).DeviceName()
+	if !ok || actual != "Port B" {
+		t.Fatalf("expected: Port B actual: %v", actual)
+	}
+}